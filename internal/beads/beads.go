@@ -435,6 +435,21 @@ func (b *Beads) Show(id string) (*Issue, error) {
 	return issues[0], nil
 }
 
+// BeadExists reports whether bead id exists in the beads database for dir.
+// A lookup failure other than "not found" (bd missing, database locked) is
+// returned as an error so callers can tell a missing bead from an
+// unavailable database.
+func BeadExists(dir, id string) (bool, error) {
+	_, err := New(dir).Show(id)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // ShowMultiple fetches multiple issues by ID in a single bd call.
 // Returns a map of ID to Issue. Missing IDs are not included in the map.
 func (b *Beads) ShowMultiple(ids []string) (map[string]*Issue, error) {
@@ -0,0 +1,31 @@
+//go:build linux
+
+package beads
+
+import "syscall"
+
+// Filesystem magic numbers (see statfs(2)) for network and FUSE mounts,
+// where the advisory locks bd relies on are unreliable or unsupported.
+var networkFilesystems = map[uint32]string{
+	0x6969:     "nfs",
+	0x517b:     "smb",
+	0xff534d42: "cifs",
+	0xfe534d42: "smb2",
+	0x65735546: "fuse",
+	0x564c:     "ncp",
+	0x73757245: "coda",
+	0x47504653: "gpfs",
+	0x0bd00bd0: "lustre",
+}
+
+// NetworkFilesystem reports whether path lives on a network or FUSE
+// filesystem, returning its type name when it does. Errors are treated as
+// "not network" since the check is advisory.
+func NetworkFilesystem(path string) (string, bool) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return "", false
+	}
+	name, ok := networkFilesystems[uint32(st.Type)] // Type width varies by arch
+	return name, ok
+}
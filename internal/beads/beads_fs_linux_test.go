@@ -0,0 +1,15 @@
+//go:build linux
+
+package beads
+
+import "testing"
+
+func TestNetworkFilesystemLocalDir(t *testing.T) {
+	// t.TempDir is on local disk (or tmpfs) in CI, never a network mount
+	if fsType, ok := NetworkFilesystem(t.TempDir()); ok {
+		t.Skipf("temp dir is on %s; cannot check local detection", fsType)
+	}
+	if _, ok := NetworkFilesystem("/nonexistent/path/xyz"); ok {
+		t.Error("missing path should not be reported as network")
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package beads
+
+// NetworkFilesystem reports whether path lives on a network filesystem.
+// Detection is only implemented on Linux; elsewhere it always reports false.
+func NetworkFilesystem(path string) (string, bool) {
+	return "", false
+}
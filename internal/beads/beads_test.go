@@ -2,10 +2,12 @@ package beads
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -581,7 +583,7 @@ func TestMRFieldsRoundTrip(t *testing.T) {
 		t.Fatal("round-trip parse returned nil")
 	}
 
-	if *parsed != *original {
+	if !reflect.DeepEqual(parsed, original) {
 		t.Errorf("round-trip mismatch:\ngot  %+v\nwant %+v", parsed, original)
 	}
 }
@@ -898,7 +900,7 @@ func TestAttachmentFieldsRoundTrip(t *testing.T) {
 		t.Fatal("round-trip parse returned nil")
 	}
 
-	if *parsed != *original {
+	if !reflect.DeepEqual(parsed, original) {
 		t.Errorf("round-trip mismatch:\ngot  %+v\nwant %+v", parsed, original)
 	}
 }
@@ -971,7 +973,7 @@ func TestNoMergeField(t *testing.T) {
 		if parsed == nil {
 			t.Fatal("round-trip parse returned nil")
 		}
-		if *parsed != *original {
+		if !reflect.DeepEqual(parsed, original) {
 			t.Errorf("round-trip mismatch:\ngot  %+v\nwant %+v", parsed, original)
 		}
 	})
@@ -2549,3 +2551,149 @@ func TestCloseAndClearAgentBead_ReasonVariations(t *testing.T) {
 		})
 	}
 }
+
+func TestBeadExistsUnavailable(t *testing.T) {
+	// Without bd on PATH the lookup fails; that must surface as an error,
+	// not as "bead doesn't exist"
+	t.Setenv("PATH", t.TempDir())
+
+	exists, err := BeadExists(t.TempDir(), "gt-abc")
+	if !errors.Is(err, ErrNotInstalled) {
+		t.Errorf("BeadExists() error = %v, want ErrNotInstalled", err)
+	}
+	if exists {
+		t.Error("BeadExists() = true on lookup failure")
+	}
+}
+
+// TestMetadataFields tests the meta.<key> fields in AttachmentFields.
+func TestMetadataFields(t *testing.T) {
+	t.Run("parse keeps key case", func(t *testing.T) {
+		issue := &Issue{Description: "attached_args: go\nMeta.Priority: high\nmeta.effort: 2h\nmeta.: ignored\n\nProse: not metadata"}
+		fields := ParseAttachmentFields(issue)
+		if fields == nil {
+			t.Fatal("ParseAttachmentFields() = nil")
+		}
+		want := map[string]string{"Priority": "high", "effort": "2h"}
+		if len(fields.Metadata) != len(want) {
+			t.Fatalf("Metadata = %v, want %v", fields.Metadata, want)
+		}
+		for k, v := range want {
+			if fields.Metadata[k] != v {
+				t.Errorf("Metadata[%q] = %q, want %q", k, fields.Metadata[k], v)
+			}
+		}
+	})
+
+	t.Run("format sorts keys", func(t *testing.T) {
+		got := FormatAttachmentFields(&AttachmentFields{
+			DispatchedBy: "mayor",
+			Metadata:     map[string]string{"labels": "ui,bug", "effort": "2h"},
+		})
+		want := "dispatched_by: mayor\nmeta.effort: 2h\nmeta.labels: ui,bug"
+		if got != want {
+			t.Errorf("FormatAttachmentFields() =\n%q\nwant\n%q", got, want)
+		}
+	})
+
+	t.Run("set replaces old metadata", func(t *testing.T) {
+		issue := &Issue{Description: "meta.stale: yes\nmeta.effort: 1h\n\nKeep this text."}
+		got := SetAttachmentFields(issue, &AttachmentFields{Metadata: map[string]string{"effort": "3h"}})
+		want := "meta.effort: 3h\n\nKeep this text."
+		if got != want {
+			t.Errorf("SetAttachmentFields() =\n%q\nwant\n%q", got, want)
+		}
+	})
+
+	t.Run("no metadata is omitted", func(t *testing.T) {
+		got := FormatAttachmentFields(&AttachmentFields{AttachedMolecule: "mol-abc"})
+		if strings.Contains(got, MetadataKeyPrefix) {
+			t.Errorf("FormatAttachmentFields() = %q, want no metadata lines", got)
+		}
+	})
+}
+
+// TestPickedUpAtField tests the picked_up_at field marking hooked work as started.
+func TestPickedUpAtField(t *testing.T) {
+	issue := &Issue{Description: "attached_molecule: gt-wisp-1\npicked_up_at: 2026-01-02T03:04:05Z\n\nFix the widget"}
+	fields := ParseAttachmentFields(issue)
+	if fields == nil || fields.PickedUpAt != "2026-01-02T03:04:05Z" {
+		t.Fatalf("ParseAttachmentFields() = %+v, want PickedUpAt set", fields)
+	}
+
+	fields.PickedUpAt = ""
+	desc := SetAttachmentFields(issue, fields)
+	if strings.Contains(desc, "picked_up_at") {
+		t.Errorf("cleared picked_up_at still in description:\n%s", desc)
+	}
+	if !strings.Contains(desc, "attached_molecule: gt-wisp-1") || !strings.Contains(desc, "Fix the widget") {
+		t.Errorf("SetAttachmentFields() lost content:\n%s", desc)
+	}
+}
+
+// TestClaimedFields tests the claimed_by/claimed_at fields recording a role hook claim.
+func TestClaimedFields(t *testing.T) {
+	issue := &Issue{Description: "dispatched_by: mayor\n\nFix the widget"}
+	desc := SetAttachmentFields(issue, &AttachmentFields{DispatchedBy: "mayor", ClaimedBy: "gastown/crew/max", ClaimedAt: "2026-01-02T03:04:05Z"})
+
+	fields := ParseAttachmentFields(&Issue{Description: desc})
+	if fields == nil || fields.ClaimedBy != "gastown/crew/max" || fields.ClaimedAt != "2026-01-02T03:04:05Z" {
+		t.Fatalf("ParseAttachmentFields() = %+v, want the claim", fields)
+	}
+	if fields.DispatchedBy != "mayor" || !strings.HasSuffix(desc, "Fix the widget") {
+		t.Errorf("SetAttachmentFields() lost content:\n%s", desc)
+	}
+}
+
+// TestPinnedField tests the pinned field exempting a hook from stale sweeps.
+func TestSetAttachmentFieldsKeepsUnknownKeys(t *testing.T) {
+	// A hook written by a newer gt, with a field this version doesn't know
+	issue := &Issue{Description: "attached_molecule: gt-mol\nretry_budget: 3\n\nFix the build"}
+	fields := ParseAttachmentFields(issue)
+	if fields == nil || fields.AttachedMolecule != "gt-mol" {
+		t.Fatalf("ParseAttachmentFields() = %+v", fields)
+	}
+
+	fields.PickedUpAt = "2026-01-02T03:04:05Z"
+	desc := SetAttachmentFields(issue, fields)
+	for _, want := range []string{"attached_molecule: gt-mol", "picked_up_at: 2026-01-02T03:04:05Z", "retry_budget: 3", "Fix the build"} {
+		if !strings.Contains(desc, want) {
+			t.Errorf("rewritten description lost %q:\n%s", want, desc)
+		}
+	}
+}
+
+func TestPinnedField(t *testing.T) {
+	issue := &Issue{Description: "Wait for the vendor fix"}
+	desc := SetAttachmentFields(issue, &AttachmentFields{Pinned: true})
+	if !strings.Contains(desc, "pinned: true") || !strings.Contains(desc, "Wait for the vendor fix") {
+		t.Fatalf("SetAttachmentFields(Pinned) =\n%s", desc)
+	}
+
+	issue.Description = desc
+	fields := ParseAttachmentFields(issue)
+	if fields == nil || !fields.Pinned {
+		t.Fatalf("ParseAttachmentFields() = %+v, want Pinned", fields)
+	}
+
+	fields.Pinned = false
+	if desc := SetAttachmentFields(issue, fields); strings.Contains(desc, "pinned") {
+		t.Errorf("unpinned description still has pinned:\n%s", desc)
+	}
+}
+
+func TestDependsOnField(t *testing.T) {
+	fields := ParseAttachmentFields(&Issue{Description: "depends_on: gt-a, gt-b,\n\nSecond step"})
+	if fields == nil || strings.Join(fields.DependsOn, " ") != "gt-a gt-b" {
+		t.Fatalf("ParseAttachmentFields() = %+v, want depends_on gt-a, gt-b", fields)
+	}
+
+	desc := SetAttachmentFields(&Issue{Description: "Second step"}, fields)
+	if !strings.HasPrefix(desc, "depends_on: gt-a, gt-b\n") {
+		t.Errorf("SetAttachmentFields() = %q, want depends_on line first", desc)
+	}
+	fields.DependsOn = nil
+	if desc = SetAttachmentFields(&Issue{Description: desc}, fields); strings.Contains(desc, "depends_on") {
+		t.Errorf("clearing DependsOn left %q", desc)
+	}
+}
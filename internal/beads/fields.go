@@ -3,6 +3,7 @@ package beads
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -17,13 +18,35 @@ func ParseAgentFieldsFromDescription(description string) *AgentFields {
 // AttachmentFields holds the attachment info for pinned beads.
 // These fields track which molecule is attached to a handoff/pinned bead.
 type AttachmentFields struct {
-	AttachedMolecule string // Root issue ID of the attached molecule
-	AttachedAt       string // ISO 8601 timestamp when attached
-	AttachedArgs     string // Natural language args passed via gt sling --args (no-tmux mode)
-	DispatchedBy     string // Agent ID that dispatched this work (for completion notification)
-	NoMerge          bool   // If true, gt done skips merge queue (for upstream PRs/human review)
+	AttachedMolecule string   // Root issue ID of the attached molecule
+	AttachedAt       string   // ISO 8601 timestamp when attached
+	AttachedArgs     string   // Natural language args passed via gt sling --args (no-tmux mode)
+	DispatchedBy     string   // Agent ID that dispatched this work (for completion notification)
+	NoMerge          bool     // If true, gt done skips merge queue (for upstream PRs/human review)
+	PickedUpAt       string   // ISO 8601 timestamp when the hooked agent started the work
+	Pinned           bool     // If true, stale-hook sweeps leave the hook alone (gt hook pin)
+	ClaimedBy        string   // Agent that claimed the bead from a role hook
+	ClaimedAt        string   // ISO 8601 timestamp of the role hook claim
+	DependsOn        []string // Bead IDs that must be done before this hook is picked up
+	CapturedCwd      string   // Working directory captured by gt sling --capture-env
+	ModifiedAt       string   // ISO 8601 timestamp of the last gt hook edit
+
+	// Metadata holds free-form key/value hints passed via gt sling --meta
+	// (priority, effort, labels). Stored as "meta.<key>: value" lines.
+	Metadata map[string]string
+
+	// CapturedEnv holds environment variables captured by gt sling
+	// --capture-env, restored on resume. Stored as "env.<NAME>: value" lines.
+	CapturedEnv map[string]string
 }
 
+// MetadataKeyPrefix prefixes metadata keys in a bead description.
+const MetadataKeyPrefix = "meta."
+
+// CapturedEnvKeyPrefix prefixes captured environment variables in a bead
+// description.
+const CapturedEnvKeyPrefix = "env."
+
 // ParseAttachmentFields extracts attachment fields from an issue's description.
 // Fields are expected as "key: value" lines. Returns nil if no attachment fields found.
 func ParseAttachmentFields(issue *Issue) *AttachmentFields {
@@ -52,6 +75,24 @@ func ParseAttachmentFields(issue *Issue) *AttachmentFields {
 			continue
 		}
 
+		// Metadata keys keep their case; only the prefix is case-insensitive
+		if metaKey, ok := metadataKey(key); ok {
+			if fields.Metadata == nil {
+				fields.Metadata = make(map[string]string)
+			}
+			fields.Metadata[metaKey] = value
+			hasFields = true
+			continue
+		}
+		if name, ok := capturedEnvKey(key); ok {
+			if fields.CapturedEnv == nil {
+				fields.CapturedEnv = make(map[string]string)
+			}
+			fields.CapturedEnv[name] = value
+			hasFields = true
+			continue
+		}
+
 		// Map keys to fields (case-insensitive)
 		switch strings.ToLower(key) {
 		case "attached_molecule", "attached-molecule", "attachedmolecule":
@@ -69,6 +110,27 @@ func ParseAttachmentFields(issue *Issue) *AttachmentFields {
 		case "no_merge", "no-merge", "nomerge":
 			fields.NoMerge = strings.ToLower(value) == "true"
 			hasFields = true
+		case "picked_up_at", "picked-up-at", "pickedupat":
+			fields.PickedUpAt = value
+			hasFields = true
+		case "pinned":
+			fields.Pinned = strings.ToLower(value) == "true"
+			hasFields = true
+		case "claimed_by", "claimed-by", "claimedby":
+			fields.ClaimedBy = value
+			hasFields = true
+		case "claimed_at", "claimed-at", "claimedat":
+			fields.ClaimedAt = value
+			hasFields = true
+		case "depends_on", "depends-on", "dependson":
+			fields.DependsOn = splitFieldList(value)
+			hasFields = true
+		case "captured_cwd", "captured-cwd", "capturedcwd":
+			fields.CapturedCwd = value
+			hasFields = true
+		case "modified_at", "modified-at", "modifiedat":
+			fields.ModifiedAt = value
+			hasFields = true
 		}
 	}
 
@@ -102,10 +164,77 @@ func FormatAttachmentFields(fields *AttachmentFields) string {
 	if fields.NoMerge {
 		lines = append(lines, "no_merge: true")
 	}
+	if fields.PickedUpAt != "" {
+		lines = append(lines, "picked_up_at: "+fields.PickedUpAt)
+	}
+	if fields.Pinned {
+		lines = append(lines, "pinned: true")
+	}
+	if fields.ClaimedBy != "" {
+		lines = append(lines, "claimed_by: "+fields.ClaimedBy)
+	}
+	if fields.ClaimedAt != "" {
+		lines = append(lines, "claimed_at: "+fields.ClaimedAt)
+	}
+	if len(fields.DependsOn) > 0 {
+		lines = append(lines, "depends_on: "+strings.Join(fields.DependsOn, ", "))
+	}
+	if fields.CapturedCwd != "" {
+		lines = append(lines, "captured_cwd: "+fields.CapturedCwd)
+	}
+	if fields.ModifiedAt != "" {
+		lines = append(lines, "modified_at: "+fields.ModifiedAt)
+	}
+
+	keys := make([]string, 0, len(fields.Metadata))
+	for k := range fields.Metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		lines = append(lines, MetadataKeyPrefix+k+": "+fields.Metadata[k])
+	}
+
+	names := make([]string, 0, len(fields.CapturedEnv))
+	for name := range fields.CapturedEnv {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		lines = append(lines, CapturedEnvKeyPrefix+name+": "+fields.CapturedEnv[name])
+	}
 
 	return strings.Join(lines, "\n")
 }
 
+// splitFieldList splits a comma-separated field value, dropping empty items.
+func splitFieldList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// capturedEnvKey returns the variable name of an "env.<NAME>" description
+// key. Names keep their case.
+func capturedEnvKey(key string) (string, bool) {
+	if len(key) <= len(CapturedEnvKeyPrefix) || !strings.EqualFold(key[:len(CapturedEnvKeyPrefix)], CapturedEnvKeyPrefix) {
+		return "", false
+	}
+	return key[len(CapturedEnvKeyPrefix):], true
+}
+
+// metadataKey returns the metadata key of a "meta.<key>" description key.
+func metadataKey(key string) (string, bool) {
+	if len(key) <= len(MetadataKeyPrefix) || !strings.EqualFold(key[:len(MetadataKeyPrefix)], MetadataKeyPrefix) {
+		return "", false
+	}
+	return key[len(MetadataKeyPrefix):], true
+}
+
 // SetAttachmentFields updates an issue's description with the given attachment fields.
 // Existing attachment field lines are replaced; other content is preserved.
 // Returns the new description string.
@@ -127,6 +256,25 @@ func SetAttachmentFields(issue *Issue, fields *AttachmentFields) string {
 		"no_merge":          true,
 		"no-merge":          true,
 		"nomerge":           true,
+		"picked_up_at":      true,
+		"picked-up-at":      true,
+		"pickedupat":        true,
+		"pinned":            true,
+		"claimed_by":        true,
+		"claimed-by":        true,
+		"claimedby":         true,
+		"claimed_at":        true,
+		"claimed-at":        true,
+		"claimedat":         true,
+		"depends_on":        true,
+		"depends-on":        true,
+		"dependson":         true,
+		"captured_cwd":      true,
+		"captured-cwd":      true,
+		"capturedcwd":       true,
+		"modified_at":       true,
+		"modified-at":       true,
+		"modifiedat":        true,
 	}
 
 	// Collect non-attachment lines from existing description
@@ -148,7 +296,9 @@ func SetAttachmentFields(issue *Issue, fields *AttachmentFields) string {
 			}
 
 			key := strings.ToLower(strings.TrimSpace(trimmed[:colonIdx]))
-			if !attachmentKeys[key] {
+			_, isMeta := metadataKey(key)
+			_, isEnv := capturedEnvKey(key)
+			if !attachmentKeys[key] && !isMeta && !isEnv {
 				otherLines = append(otherLines, line)
 			}
 			// Skip attachment field lines - they'll be replaced
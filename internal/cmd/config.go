@@ -29,7 +29,10 @@ Commands:
   gt config agent get <name>         Show agent configuration
   gt config agent set <name> <cmd>   Set custom agent command
   gt config agent remove <name>      Remove custom agent
-  gt config default-agent [name]     Get or set default agent`,
+  gt config default-agent [name]     Get or set default agent
+  gt config edit                     Edit rig settings in $EDITOR
+  gt config unset <key>              Remove a rig setting (e.g. theme)
+  gt config diff [rig]               Compare rig settings to town defaults`,
 }
 
 // Agent subcommands
@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+var configDiffAllRigs bool
+
+var configDiffCmd = &cobra.Command{
+	Use:   "diff [rig]",
+	Short: "Show where a rig's settings differ from town defaults",
+	Long: `Compare a rig's settings/config.json against the town-level defaults.
+
+For each setting that has a town or built-in default, shows the rig's
+effective value, where it comes from (rig, town or built-in), and the value
+the rig would get without its own settings. Overrides are highlighted.
+
+Compared settings:
+  agent              rig agent, town default_agent, built-in claude
+  role_agents.<role> rig/town role_agents, else the effective agent
+  theme              rig theme, built-in name-based assignment
+  theme.<role>       rig role_themes, town role_defaults, built-in
+                     role themes, else the effective theme
+
+With --all-rigs, shows a matrix of every rig against the town defaults;
+overridden cells are marked with *.
+
+Examples:
+  gt config diff              # Current rig
+  gt config diff gastown      # A specific rig
+  gt config diff --all-rigs   # All rigs side by side`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigDiff,
+}
+
+func init() {
+	configDiffCmd.Flags().BoolVar(&configDiffAllRigs, "all-rigs", false, "Show a matrix of all rigs")
+	configCmd.AddCommand(configDiffCmd)
+}
+
+// Config diff sources.
+const (
+	configSourceRig     = "rig"
+	configSourceTown    = "town"
+	configSourceBuiltin = "built-in"
+)
+
+// configDiffRow is one compared setting for a rig.
+type configDiffRow struct {
+	Key      string
+	Value    string // effective value
+	Source   string // configSourceRig, configSourceTown or configSourceBuiltin
+	Default  string // value without the rig's settings
+	Override bool   // Value differs from Default
+}
+
+// configDiffRoles are the rig-scoped roles with per-role settings.
+var configDiffRoles = []string{"witness", "refinery", "polecat", "crew"}
+
+// configDiffRows compares rig settings (nil if the rig has none) with town
+// settings and the town theme config (nil if unset).
+func configDiffRows(rigName string, rig *config.RigSettings, town *config.TownSettings, townTheme *config.TownThemeConfig) []configDiffRow {
+	effective := configDiffValues(rigName, rig, town, townTheme)
+	defaults := configDiffValues(rigName, nil, town, townTheme)
+	for i := range effective {
+		effective[i].Default = defaults[i].Value
+		effective[i].Override = effective[i].Value != defaults[i].Value
+	}
+	return effective
+}
+
+// configDiffValues resolves each compared setting with its source. With
+// an empty rigName the name-based theme assignment is left symbolic.
+func configDiffValues(rigName string, rig *config.RigSettings, town *config.TownSettings, townTheme *config.TownThemeConfig) []configDiffRow {
+	if rig == nil {
+		rig = &config.RigSettings{}
+	}
+	var rows []configDiffRow
+
+	agent := configDiffRow{Key: "agent", Value: "claude", Source: configSourceBuiltin}
+	switch {
+	case rig.Agent != "":
+		agent.Value, agent.Source = rig.Agent, configSourceRig
+	case town.DefaultAgent != "":
+		agent.Value, agent.Source = town.DefaultAgent, configSourceTown
+	}
+	rows = append(rows, agent)
+
+	for _, role := range configDiffRoles {
+		row := configDiffRow{Key: "role_agents." + role, Value: agent.Value, Source: agent.Source}
+		if v := rig.RoleAgents[role]; v != "" {
+			row.Value, row.Source = v, configSourceRig
+		} else if v := town.RoleAgents[role]; v != "" {
+			row.Value, row.Source = v, configSourceTown
+		}
+		rows = append(rows, row)
+	}
+
+	theme := configDiffRow{Key: "theme", Value: "(by rig name)", Source: configSourceBuiltin}
+	if rigName != "" {
+		theme.Value = tmux.AssignTheme(rigName).Name
+	}
+	if rig.Theme != nil {
+		switch {
+		case rig.Theme.Custom != nil:
+			theme.Value, theme.Source = "custom "+rig.Theme.Custom.BG+"/"+rig.Theme.Custom.FG, configSourceRig
+		case rig.Theme.Name != "":
+			theme.Value, theme.Source = rig.Theme.Name, configSourceRig
+		}
+	}
+	rows = append(rows, theme)
+
+	builtinRoles := config.BuiltinRoleThemes()
+	for _, role := range configDiffRoles {
+		row := configDiffRow{Key: "theme." + role, Value: theme.Value, Source: theme.Source}
+		if v := configDiffRoleTheme(rig, role); v != "" {
+			row.Value, row.Source = v, configSourceRig
+		} else if townTheme != nil && townTheme.RoleDefaults[role] != "" {
+			row.Value, row.Source = townTheme.RoleDefaults[role], configSourceTown
+		} else if v := builtinRoles[role]; v != "" {
+			row.Value, row.Source = v, configSourceBuiltin
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+func configDiffRoleTheme(rig *config.RigSettings, role string) string {
+	if rig.Theme == nil {
+		return ""
+	}
+	return rig.Theme.RoleThemes[role]
+}
+
+// loadConfigDiffRows loads a rig's settings and compares them with the
+// town defaults.
+func loadConfigDiffRows(rigName, rigPath string, town *config.TownSettings, townTheme *config.TownThemeConfig) ([]configDiffRow, error) {
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(rigPath))
+	if errors.Is(err, config.ErrNotFound) {
+		settings, err = nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading %s settings: %w", rigName, err)
+	}
+	return configDiffRows(rigName, settings, town, townTheme), nil
+}
+
+func runConfigDiff(cmd *cobra.Command, args []string) error {
+	if configDiffAllRigs && len(args) > 0 {
+		return fmt.Errorf("--all-rigs does not take a rig argument")
+	}
+	if configDiffAllRigs {
+		return runConfigDiffAllRigs()
+	}
+
+	rigName := ""
+	if len(args) > 0 {
+		rigName = args[0]
+	} else {
+		rigName = detectCurrentRig()
+	}
+	if rigName == "" {
+		return fmt.Errorf("could not detect rig (pass a rig name or --all-rigs)")
+	}
+	townRoot, r, err := getRig(rigName)
+	if err != nil {
+		return err
+	}
+	town, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("loading town settings: %w", err)
+	}
+
+	rows, err := loadConfigDiffRows(rigName, r.Path, town, loadTownThemeConfig())
+	if err != nil {
+		return err
+	}
+	printConfigDiff(rigName, rows)
+	return nil
+}
+
+// printConfigDiff renders one rig's comparison, highlighting overrides.
+func printConfigDiff(rigName string, rows []configDiffRow) {
+	fmt.Printf("%s %s vs town defaults\n\n", style.Bold.Render("⚙"), rigName)
+	fmt.Printf("  %-20s %-20s %-9s %s\n", "KEY", "VALUE", "SOURCE", "DEFAULT")
+
+	overrides := 0
+	for _, row := range rows {
+		line := fmt.Sprintf("%-20s %-20s %-9s", row.Key, row.Value, row.Source)
+		if row.Override {
+			overrides++
+			fmt.Printf("%s %s %s\n", style.Warning.Render("*"), style.Bold.Render(line), row.Default)
+		} else {
+			fmt.Printf("  %s %s\n", line, style.Dim.Render(row.Default))
+		}
+	}
+
+	fmt.Println()
+	if overrides == 0 {
+		fmt.Printf("%s\n", style.Dim.Render("No overrides: rig matches town defaults"))
+	} else {
+		fmt.Printf("%s\n", style.Dim.Render(fmt.Sprintf("* = overridden by this rig (%d)", overrides)))
+	}
+}
+
+func runConfigDiffAllRigs() error {
+	rigs, townRoot, err := getAllRigs()
+	if err != nil {
+		return err
+	}
+	if len(rigs) == 0 {
+		fmt.Println("No rigs found")
+		return nil
+	}
+	sort.Slice(rigs, func(i, j int) bool { return rigs[i].Name < rigs[j].Name })
+
+	town, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("loading town settings: %w", err)
+	}
+	townTheme := loadTownThemeConfig()
+
+	names := make([]string, len(rigs))
+	matrix := make([][]configDiffRow, len(rigs))
+	for i, r := range rigs {
+		rows, err := loadConfigDiffRows(r.Name, r.Path, town, townTheme)
+		if err != nil {
+			return err
+		}
+		names[i] = r.Name
+		matrix[i] = rows
+	}
+
+	fmt.Print(formatConfigDiffMatrix(names, matrix, configDiffValues("", nil, town, townTheme)))
+	return nil
+}
+
+// formatConfigDiffMatrix lays out one column per rig next to the town
+// defaults, with overridden cells marked "*".
+func formatConfigDiffMatrix(names []string, matrix [][]configDiffRow, townDefaults []configDiffRow) string {
+	header := append([]string{"KEY", "TOWN"}, names...)
+	table := [][]string{header}
+	for k, def := range townDefaults {
+		cells := []string{def.Key, def.Value}
+		for i := range names {
+			row := matrix[i][k]
+			cell := row.Value
+			if row.Override {
+				cell += "*"
+			}
+			cells = append(cells, cell)
+		}
+		table = append(table, cells)
+	}
+
+	widths := make([]int, len(header))
+	for _, cells := range table {
+		for c, cell := range cells {
+			widths[c] = max(widths[c], len(cell))
+		}
+	}
+
+	var sb strings.Builder
+	for _, cells := range table {
+		for c, cell := range cells {
+			if c == len(cells)-1 {
+				sb.WriteString(cell)
+			} else {
+				fmt.Fprintf(&sb, "%-*s  ", widths[c], cell)
+			}
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n* = overridden by the rig\n")
+	return sb.String()
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+func TestConfigDiffRows(t *testing.T) {
+	town := config.NewTownSettings()
+	town.DefaultAgent = "gemini"
+	town.RoleAgents["witness"] = "claude-haiku"
+	townTheme := &config.TownThemeConfig{RoleDefaults: map[string]string{"crew": "forest"}}
+
+	rig := config.NewRigSettings()
+	rig.Agent = "codex"
+	rig.Theme = &config.ThemeConfig{Name: "ocean", RoleThemes: map[string]string{"crew": "forest"}}
+
+	rows := configDiffRows("gastown", rig, town, townTheme)
+	got := make(map[string]configDiffRow)
+	for _, row := range rows {
+		got[row.Key] = row
+	}
+
+	tests := []configDiffRow{
+		{Key: "agent", Value: "codex", Source: configSourceRig, Default: "gemini", Override: true},
+		{Key: "role_agents.witness", Value: "claude-haiku", Source: configSourceTown, Default: "claude-haiku"},
+		{Key: "role_agents.crew", Value: "codex", Source: configSourceRig, Default: "gemini", Override: true},
+		{Key: "theme", Value: "ocean", Source: configSourceRig, Default: tmux.AssignTheme("gastown").Name, Override: tmux.AssignTheme("gastown").Name != "ocean"},
+		{Key: "theme.witness", Value: "rust", Source: configSourceBuiltin, Default: "rust"},
+		{Key: "theme.crew", Value: "forest", Source: configSourceRig, Default: "forest"},
+		{Key: "theme.polecat", Value: "ocean", Source: configSourceRig, Default: tmux.AssignTheme("gastown").Name, Override: tmux.AssignTheme("gastown").Name != "ocean"},
+	}
+	for _, want := range tests {
+		if got[want.Key] != want {
+			t.Errorf("%s = %+v, want %+v", want.Key, got[want.Key], want)
+		}
+	}
+
+	// No rig settings: nothing is overridden
+	for _, row := range configDiffRows("gastown", nil, town, townTheme) {
+		if row.Override || row.Source == configSourceRig {
+			t.Errorf("without rig settings, %s = %+v, want no override", row.Key, row)
+		}
+	}
+}
+
+func TestFormatConfigDiffMatrix(t *testing.T) {
+	town := config.NewTownSettings()
+	rig := config.NewRigSettings()
+	rig.Agent = "gemini"
+
+	matrix := [][]configDiffRow{
+		configDiffRows("alpha", rig, town, nil),
+		configDiffRows("beta", nil, town, nil),
+	}
+	out := formatConfigDiffMatrix([]string{"alpha", "beta"}, matrix, configDiffValues("", nil, town, nil))
+
+	lines := strings.Split(out, "\n")
+	if fields := strings.Fields(lines[0]); strings.Join(fields, " ") != "KEY TOWN alpha beta" {
+		t.Errorf("header = %q", lines[0])
+	}
+	if fields := strings.Fields(lines[1]); strings.Join(fields, " ") != "agent claude gemini* claude" {
+		t.Errorf("agent row = %q", lines[1])
+	}
+	if !strings.Contains(out, "(by rig name)") {
+		t.Errorf("town theme default should be symbolic:\n%s", out)
+	}
+}
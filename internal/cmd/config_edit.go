@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+var configEditRig string
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Edit the rig's settings/config.json in $EDITOR",
+	Long: `Open the current rig's settings/config.json in $EDITOR.
+
+The file is edited as a temporary copy. When the editor exits, the result
+is parsed and validated; only a valid config replaces the original, and
+the replacement is written atomically. If validation fails you are asked
+whether to re-open the editor; declining aborts and leaves the original
+file untouched.
+
+If the rig has no settings file yet, the editor starts from the defaults.
+The editor is taken from $VISUAL, then $EDITOR, falling back to vi.
+
+Examples:
+  gt config edit               # Edit the current rig's settings
+  gt config edit --rig gastown # Edit a specific rig's settings`,
+	Args: cobra.NoArgs,
+	RunE: runConfigEdit,
+}
+
+func init() {
+	configEditCmd.Flags().StringVar(&configEditRig, "rig", "", "Rig to edit (default: detected from environment)")
+	configCmd.AddCommand(configEditCmd)
+}
+
+func runConfigEdit(cmd *cobra.Command, args []string) error {
+	rigName := configEditRig
+	if rigName == "" {
+		rigName = detectCurrentRig()
+	}
+	if rigName == "" {
+		return fmt.Errorf("could not detect rig (use --rig)")
+	}
+	townRoot, _, err := getRig(rigName)
+	if err != nil {
+		return err
+	}
+	settingsPath := filepath.Join(config.RigDir(townRoot, rigName), "settings", "config.json")
+
+	original, err := os.ReadFile(settingsPath) //nolint:gosec // G304: path is constructed internally
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("reading settings: %w", err)
+		}
+		original, err = json.MarshalIndent(config.NewRigSettings(), "", "  ")
+		if err != nil {
+			return fmt.Errorf("encoding default settings: %w", err)
+		}
+	}
+
+	tmp, err := os.CreateTemp("", "gt-config-*.json")
+	if err != nil {
+		return fmt.Errorf("creating temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+	_, err = tmp.Write(original)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("writing temp file: %w", err)
+	}
+
+	for {
+		if err := runEditor(tmpPath); err != nil {
+			return fmt.Errorf("running editor: %w (original left unchanged)", err)
+		}
+
+		edited, err := os.ReadFile(tmpPath) //nolint:gosec // G304: temp file we created
+		if err != nil {
+			return fmt.Errorf("reading edited file: %w", err)
+		}
+		if bytes.Equal(bytes.TrimSpace(edited), bytes.TrimSpace(original)) {
+			fmt.Println("No changes")
+			return nil
+		}
+
+		if _, err := config.ParseRigSettings(edited); err != nil {
+			fmt.Fprintf(os.Stderr, "%s Invalid config: %v\n", style.Warning.Render("⚠"), err)
+			if promptYesNo("Re-open editor to fix it?") {
+				continue
+			}
+			return fmt.Errorf("aborted: %s left unchanged", settingsPath)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(settingsPath), 0755); err != nil {
+			return fmt.Errorf("creating settings directory: %w", err)
+		}
+		if err := util.AtomicWriteFile(settingsPath, edited, 0644); err != nil {
+			return fmt.Errorf("saving settings: %w", err)
+		}
+		fmt.Printf("%s Saved %s\n", style.Success.Render("✓"), settingsPath)
+		return nil
+	}
+}
+
+// runEditor opens path in the user's editor and waits for it to exit.
+// The editor value may include arguments (e.g. "code --wait").
+func runEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	parts := strings.Fields(editor)
+
+	c := exec.Command(parts[0], append(parts[1:], path)...) //nolint:gosec // G204: editor is chosen by the user
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	return c.Run()
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// writeEditorScript creates a fake $EDITOR that replaces the file it is
+// given with content.
+func writeEditorScript(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	src := filepath.Join(dir, "content.json")
+	if err := os.WriteFile(src, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	script := filepath.Join(dir, "editor.sh")
+	body := "#!/bin/sh\ncp " + src + " \"$1\"\n"
+	if err := os.WriteFile(script, []byte(body), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return script
+}
+
+func TestConfigEdit(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake editor is a shell script")
+	}
+	townRoot, rigName := setupTestRigForSettings(t)
+	settingsPath := filepath.Join(townRoot, rigName, "settings", "config.json")
+	t.Setenv("VISUAL", "")
+	configEditRig = rigName
+	defer func() { configEditRig = "" }()
+
+	// Valid edit is saved
+	t.Setenv("EDITOR", writeEditorScript(t, `{"type":"rig-settings","version":1,"theme":{"name":"forest"}}`))
+	if err := runConfigEdit(nil, nil); err != nil {
+		t.Fatalf("runConfigEdit (valid): %v", err)
+	}
+	saved, err := config.LoadRigSettings(settingsPath)
+	if err != nil {
+		t.Fatalf("LoadRigSettings: %v", err)
+	}
+	if saved.Theme == nil || saved.Theme.Name != "forest" {
+		t.Errorf("saved theme = %+v, want forest", saved.Theme)
+	}
+	before, _ := os.ReadFile(settingsPath)
+
+	// Invalid edit aborts (no tty answer => no retry) and leaves the file intact
+	t.Setenv("EDITOR", writeEditorScript(t, `{"type":"rig-settings",`))
+	stdin := os.Stdin
+	devNull, _ := os.Open(os.DevNull)
+	os.Stdin = devNull
+	defer func() { os.Stdin = stdin; _ = devNull.Close() }()
+
+	err = runConfigEdit(nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "left unchanged") {
+		t.Fatalf("runConfigEdit (invalid) error = %v, want abort", err)
+	}
+	after, _ := os.ReadFile(settingsPath)
+	if string(after) != string(before) {
+		t.Error("invalid edit modified the original settings file")
+	}
+}
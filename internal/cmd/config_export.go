@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var configImportForce bool
+
+var configExportCmd = &cobra.Command{
+	Use:   "export [rig]",
+	Short: "Export a rig's full configuration as JSON",
+	Long: `Write a rig's configuration to stdout as JSON.
+
+The export holds the rig's identity (config.json: name, git URL, beads
+prefix, ...) and its settings (settings/config.json: theme, merge queue,
+agents, ...), so the rig can be restored on another machine with
+'gt config import'. Without a rig argument, the current rig is used.
+
+Examples:
+  gt config export > gastown.json
+  gt config export gastown > gastown.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigExport,
+}
+
+var configImportCmd = &cobra.Command{
+	Use:   "import [rig] <file>",
+	Short: "Restore a rig's configuration from 'gt config export'",
+	Long: `Install a rig configuration written by 'gt config export'.
+
+The file is validated before anything is written: the rig config and
+settings must pass the same checks as when gt loads them, and files from a
+newer gt (a version this one doesn't support) are rejected. Both files are
+then written atomically. The settings are only replaced if the export has
+them.
+
+The rig must already be registered in this town and the export must be
+for a rig of the same name. A rig that already has a config.json or
+settings/config.json is not overwritten without --force.
+
+Without a rig argument, the current rig is used. Use - to read from stdin.
+With the global --dry-run flag, the file is validated and nothing is
+written.
+
+Examples:
+  gt config import gastown.json
+  gt config import gastown gastown.json --force`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runConfigImport,
+}
+
+func init() {
+	configImportCmd.Flags().BoolVar(&configImportForce, "force", false, "Overwrite the rig's existing configuration")
+	configCmd.AddCommand(configExportCmd)
+	configCmd.AddCommand(configImportCmd)
+}
+
+// RigConfigExport is the file format of gt config export.
+type RigConfigExport struct {
+	Config   *config.RigConfig   `json:"config"`
+	Settings *config.RigSettings `json:"settings,omitempty"`
+}
+
+// configRigPaths returns a rig's config.json and settings/config.json
+// paths, using the current rig when rigName is empty.
+func configRigPaths(rigName string) (name, configPath, settingsPath string, err error) {
+	if rigName == "" {
+		rigName = detectCurrentRig()
+	}
+	if rigName == "" {
+		return "", "", "", fmt.Errorf("could not detect rig (pass a rig name)")
+	}
+	_, r, err := getRig(rigName)
+	if err != nil {
+		return "", "", "", err
+	}
+	return rigName, filepath.Join(r.Path, "config.json"), config.RigSettingsPath(r.Path), nil
+}
+
+func runConfigExport(cmd *cobra.Command, args []string) error {
+	var rigName string
+	if len(args) > 0 {
+		rigName = args[0]
+	}
+	rigName, configPath, settingsPath, err := configRigPaths(rigName)
+	if err != nil {
+		return err
+	}
+
+	export := RigConfigExport{}
+	if export.Config, err = config.LoadRigConfig(configPath); err != nil {
+		return fmt.Errorf("loading config for rig '%s': %w", rigName, err)
+	}
+	if export.Settings, err = config.LoadRigSettings(settingsPath); err != nil && !errors.Is(err, config.ErrNotFound) {
+		return fmt.Errorf("loading settings for rig '%s': %w", rigName, err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(export)
+}
+
+// parseRigConfigExport parses and validates an export for rigName.
+func parseRigConfigExport(data []byte, rigName string) (*RigConfigExport, error) {
+	var export RigConfigExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("parsing export: %w", err)
+	}
+	if export.Config == nil {
+		return nil, fmt.Errorf("export has no rig config")
+	}
+	if err := config.ValidateRigConfig(export.Config); err != nil {
+		return nil, fmt.Errorf("invalid rig config: %w", err)
+	}
+	if export.Config.Name != rigName {
+		return nil, fmt.Errorf("export is for rig '%s', not '%s'", export.Config.Name, rigName)
+	}
+	if export.Settings != nil {
+		if err := config.ValidateRigSettings(export.Settings); err != nil {
+			return nil, fmt.Errorf("invalid rig settings: %w", err)
+		}
+	}
+	return &export, nil
+}
+
+func runConfigImport(cmd *cobra.Command, args []string) error {
+	var rigName string
+	file := args[len(args)-1]
+	if len(args) == 2 {
+		rigName = args[0]
+	}
+	rigName, configPath, settingsPath, err := configRigPaths(rigName)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if file == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(file) //nolint:gosec // G304: file is chosen by the user
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", file, err)
+	}
+	export, err := parseRigConfigExport(data, rigName)
+	if err != nil {
+		return err
+	}
+
+	if !configImportForce {
+		for _, path := range []string{configPath, settingsPath} {
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("rig '%s' already has %s (use --force to overwrite)", rigName, path)
+			}
+		}
+	}
+
+	if globalDryRun {
+		fmt.Printf("Would import config for rig '%s' (settings: %v)\n", rigName, export.Settings != nil)
+		return nil
+	}
+
+	if err := config.SaveRigConfig(configPath, export.Config); err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+	if export.Settings != nil {
+		if err := config.SaveRigSettings(settingsPath, export.Settings); err != nil {
+			return fmt.Errorf("saving settings: %w", err)
+		}
+	}
+	fmt.Printf("%s Imported config for rig '%s'\n", style.Success.Render("✓"), rigName)
+	return nil
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestParseRigConfigExport(t *testing.T) {
+	valid := `{"config": {"type": "rig", "version": 1, "name": "gastown", "git_url": "git@example.com:g.git"},
+		"settings": {"type": "rig-settings", "version": 1, "theme": {"name": "ocean"}}}`
+	export, err := parseRigConfigExport([]byte(valid), "gastown")
+	if err != nil {
+		t.Fatalf("parseRigConfigExport: %v", err)
+	}
+	if export.Config.GitURL != "git@example.com:g.git" || export.Settings.Theme.Name != "ocean" {
+		t.Errorf("export = %+v", export)
+	}
+
+	tests := map[string]struct {
+		data, want string
+	}{
+		"no config":        {`{"settings": {}}`, "no rig config"},
+		"other rig":        {`{"config": {"type": "rig", "name": "beads"}}`, "for rig 'beads'"},
+		"newer config":     {`{"config": {"type": "rig", "version": 99, "name": "gastown"}}`, "max supported"},
+		"newer settings":   {`{"config": {"name": "gastown"}, "settings": {"version": 99}}`, "max supported"},
+		"wrong type":       {`{"config": {"type": "town", "name": "gastown"}}`, "expected type"},
+		"invalid settings": {`{"config": {"name": "gastown"}, "settings": {"merge_queue": {"on_conflict": "explode"}}}`, "invalid rig settings"},
+		"not json":         {`{`, "parsing export"},
+	}
+	for name, tt := range tests {
+		if _, err := parseRigConfigExport([]byte(tt.data), "gastown"); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: err = %v, want %q", name, err, tt.want)
+		}
+	}
+}
+
+func TestConfigExportImportRoundTrip(t *testing.T) {
+	townRoot, rigName := setupTestRigForSettings(t)
+	rigPath := filepath.Join(townRoot, rigName)
+	settingsPath := config.RigSettingsPath(rigPath)
+	settings := config.NewRigSettings()
+	settings.Theme = &config.ThemeConfig{Name: "forest"}
+	if err := config.SaveRigSettings(settingsPath, settings); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runConfigExport(nil, []string{rigName}); err != nil {
+			t.Fatalf("export: %v", err)
+		}
+	})
+	var export RigConfigExport
+	if err := json.Unmarshal([]byte(out), &export); err != nil {
+		t.Fatalf("export output: %v\n%s", err, out)
+	}
+	if export.Config.Name != rigName || export.Settings.Theme.Name != "forest" {
+		t.Fatalf("export = %+v", export)
+	}
+
+	// Change the rig, then restore the export over it
+	file := filepath.Join(t.TempDir(), "export.json")
+	if err := os.WriteFile(file, []byte(out), 0644); err != nil {
+		t.Fatal(err)
+	}
+	settings.Theme.Name = "ocean"
+	if err := config.SaveRigSettings(settingsPath, settings); err != nil {
+		t.Fatal(err)
+	}
+
+	configImportForce = false
+	t.Cleanup(func() { configImportForce = false })
+	if err := runConfigImport(nil, []string{rigName, file}); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("import without --force: err = %v, want refusal", err)
+	}
+	configImportForce = true
+	captureStdout(t, func() {
+		if err := runConfigImport(nil, []string{rigName, file}); err != nil {
+			t.Fatalf("import --force: %v", err)
+		}
+	})
+
+	got, err := config.LoadRigSettings(settingsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Theme.Name != "forest" {
+		t.Errorf("theme after import = %q, want forest", got.Theme.Name)
+	}
+	if _, err := config.LoadRigConfig(filepath.Join(rigPath, "config.json")); err != nil {
+		t.Errorf("rig config after import: %v", err)
+	}
+}
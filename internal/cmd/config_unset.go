@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var configUnsetRig string
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a setting from the rig's settings/config.json",
+	Long: `Remove one setting from the current rig's settings/config.json.
+
+The key is a dotted path of the setting's JSON names. A section name
+removes the whole section, so 'gt config unset theme' drops the rig's theme
+block and the rig goes back to its hash-assigned default theme. Inside a
+map the last part is the map key (theme.role_themes.witness, theme.workers.max,
+role_agents.polecat).
+
+Everything else in the file is kept, and the change is written atomically
+under the settings lock. Unsetting a key that isn't set does nothing. An
+unknown key fails with the keys valid at that point.
+
+With the global --dry-run flag, the key is checked and nothing is written.
+
+Examples:
+  gt config unset theme                    # Back to the default theme
+  gt config unset theme.idle_dim_minutes
+  gt config unset theme.role_themes.witness --rig gastown`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigUnset,
+}
+
+func init() {
+	configUnsetCmd.Flags().StringVar(&configUnsetRig, "rig", "", "Rig to change (default: detected from environment)")
+	configCmd.AddCommand(configUnsetCmd)
+}
+
+func runConfigUnset(cmd *cobra.Command, args []string) error {
+	key := args[0]
+	if err := checkSettingsKey(key); err != nil {
+		return err
+	}
+	rigName, _, settingsPath, err := configRigPaths(configUnsetRig)
+	if err != nil {
+		return err
+	}
+
+	if globalDryRun {
+		fmt.Printf("Would unset %s in %s\n", key, settingsPath)
+		return nil
+	}
+
+	var removed bool
+	err = config.UpdateRigSettings(settingsPath, func(settings *config.RigSettings) error {
+		removed, err = unsetSettingsKey(settings, key)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("unsetting %s: %w", key, err)
+	}
+	if !removed {
+		fmt.Printf("%s is not set for rig %s\n", key, rigName)
+		return nil
+	}
+	fmt.Printf("%s Unset %s for rig %s\n", style.Success.Render("✓"), key, rigName)
+	return nil
+}
+
+// checkSettingsKey checks that key is a dotted path of JSON field names in
+// config.RigSettings; inside a map, any segment is accepted as the map key.
+// For an unknown name the error lists the names valid there. The type and
+// version header can't be unset.
+func checkSettingsKey(key string) error {
+	parts := strings.Split(key, ".")
+	if parts[0] == "type" || parts[0] == "version" {
+		return fmt.Errorf("cannot unset %s: it identifies the settings file", parts[0])
+	}
+
+	t := reflect.TypeOf(config.RigSettings{})
+	for i, part := range parts {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		path := strings.Join(parts[:i], ".")
+		switch t.Kind() {
+		case reflect.Struct:
+			field, ok := jsonField(t, part)
+			if !ok {
+				where := "valid keys"
+				if path != "" {
+					where += " under " + path
+				}
+				return fmt.Errorf("unknown key %q (%s: %s)", key, where, strings.Join(jsonFieldNames(t), ", "))
+			}
+			t = field.Type
+		case reflect.Map:
+			if part == "" {
+				return fmt.Errorf("unknown key %q: empty map key under %s", key, path)
+			}
+			t = t.Elem()
+		default:
+			return fmt.Errorf("unknown key %q: %s is a single setting, not a section", key, path)
+		}
+	}
+	return nil
+}
+
+// jsonField finds the field of struct type t with JSON name name.
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); jsonName(f) == name {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// jsonFieldNames returns the JSON names of struct type t's fields, sorted,
+// leaving out the settings header (type, version).
+func jsonFieldNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		if name := jsonName(t.Field(i)); name != "" && name != "type" && name != "version" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// jsonName is the name a struct field is encoded under, or "" if it isn't.
+func jsonName(f reflect.StructField) string {
+	if !f.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return f.Name
+	}
+	return name
+}
+
+// unsetSettingsKey removes the setting at key (see checkSettingsKey) from
+// settings, reporting whether it was set. The settings round-trip through
+// their JSON form, so removing a key is exactly dropping it from the file.
+func unsetSettingsKey(settings *config.RigSettings, key string) (bool, error) {
+	if err := checkSettingsKey(key); err != nil {
+		return false, err
+	}
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return false, err
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return false, err
+	}
+
+	parts := strings.Split(key, ".")
+	section := doc
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := section[part].(map[string]interface{})
+		if !ok {
+			return false, nil
+		}
+		section = next
+	}
+	last := parts[len(parts)-1]
+	if _, ok := section[last]; !ok {
+		return false, nil
+	}
+	delete(section, last)
+
+	if data, err = json.Marshal(doc); err != nil {
+		return false, err
+	}
+	var updated config.RigSettings
+	if err := json.Unmarshal(data, &updated); err != nil {
+		return false, err
+	}
+	*settings = updated
+	return true, nil
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+func TestCheckSettingsKey(t *testing.T) {
+	for _, key := range []string{"theme", "theme.idle_dim_minutes", "theme.role_themes.witness", "merge_queue", "role_agents.polecat"} {
+		if err := checkSettingsKey(key); err != nil {
+			t.Errorf("checkSettingsKey(%q) = %v", key, err)
+		}
+	}
+
+	err := checkSettingsKey("theme.nmae")
+	if err == nil || !strings.Contains(err.Error(), "valid keys under theme:") || !strings.Contains(err.Error(), "name,") {
+		t.Errorf("checkSettingsKey(theme.nmae) = %v, want the theme keys listed", err)
+	}
+	for _, key := range []string{"themes", "theme.name.x", "version", "theme.role_themes."} {
+		if err := checkSettingsKey(key); err == nil {
+			t.Errorf("checkSettingsKey(%q) accepted", key)
+		}
+	}
+}
+
+func TestConfigUnset(t *testing.T) {
+	townRoot, rigName := setupTestRigForSettings(t)
+	settingsPath := filepath.Join(townRoot, rigName, "settings", "config.json")
+	configUnsetRig = rigName
+	defer func() { configUnsetRig = "" }()
+
+	settings := config.NewRigSettings()
+	settings.Agent = "claude"
+	settings.Theme = &config.ThemeConfig{Name: "forest", RoleThemes: map[string]string{"witness": "plum", "crew": "slate"}}
+	if err := config.SaveRigSettings(settingsPath, settings); err != nil {
+		t.Fatalf("SaveRigSettings: %v", err)
+	}
+	load := func() *config.RigSettings {
+		t.Helper()
+		s, err := config.LoadRigSettings(settingsPath)
+		if err != nil {
+			t.Fatalf("LoadRigSettings: %v", err)
+		}
+		return s
+	}
+
+	captureStdout(t, func() {
+		if err := runConfigUnset(nil, []string{"theme.role_themes.witness"}); err != nil {
+			t.Fatalf("unset theme.role_themes.witness: %v", err)
+		}
+	})
+	if got := load().Theme; got.Name != "forest" || len(got.RoleThemes) != 1 || got.RoleThemes["crew"] != "slate" {
+		t.Errorf("after unsetting one role theme: %+v", got)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runConfigUnset(nil, []string{"theme"}); err != nil {
+			t.Fatalf("unset theme: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Unset theme") {
+		t.Errorf("output = %q", out)
+	}
+	if got := load(); got.Theme != nil || got.Agent != "claude" {
+		t.Errorf("after unset theme: theme = %+v, agent = %q; want theme gone, agent kept", got.Theme, got.Agent)
+	}
+	if got := getThemeForRig(rigName); got.Name != tmux.AssignTheme(rigName).Name {
+		t.Errorf("theme after unset = %s, want hash default", got.Name)
+	}
+
+	// Unsetting again is a no-op
+	out = captureStdout(t, func() {
+		if err := runConfigUnset(nil, []string{"theme"}); err != nil {
+			t.Fatalf("unset theme again: %v", err)
+		}
+	})
+	if !strings.Contains(out, "not set") {
+		t.Errorf("second unset output = %q, want not set", out)
+	}
+
+	if err := runConfigUnset(nil, []string{"bogus"}); err == nil || !strings.Contains(err.Error(), "theme") {
+		t.Errorf("unset bogus = %v, want error listing valid keys", err)
+	}
+}
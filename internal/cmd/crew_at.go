@@ -179,7 +179,7 @@ func runCrewAt(cmd *cobra.Command, args []string) error {
 		// Apply rig-based theming (non-fatal: theming failure doesn't affect operation)
 		// Note: ConfigureGasTownSession includes cycle bindings
 		theme := getThemeForRig(r.Name)
-		_ = t.ConfigureGasTownSession(sessionID, theme, r.Name, name, "crew")
+		_ = t.ConfigureGasTownSession(sessionID, theme, r.DisplayName(), name, "crew")
 
 		// Wait for shell to be ready after session creation
 		if err := t.WaitForShellReady(sessionID, constants.ShellReadyTimeout); err != nil {
@@ -205,6 +205,7 @@ var deaconStaleHooksCmd = &cobra.Command{
 Beads can get stuck in 'hooked' status when agents die or abandon work.
 This command finds hooked beads older than the threshold (default: 1 hour),
 checks if the assignee agent is still alive, and unhooks them if not.
+Hooks pinned with 'gt hook pin' are never unhooked.
 
 Examples:
   gt deacon stale-hooks                 # Find and unhook stale beads
@@ -1072,7 +1073,9 @@ func runDeaconStaleHooks(cmd *cobra.Command, args []string) error {
 		status := style.Dim.Render("○")
 		action := "skipped (agent alive)"
 
-		if !r.AgentAlive {
+		if r.Pinned {
+			action = "skipped (pinned)"
+		} else if !r.AgentAlive {
 			if staleHooksDryRun {
 				status = style.Bold.Render("?")
 				action = "would unhook (agent dead)"
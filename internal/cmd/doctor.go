@@ -72,6 +72,11 @@ Routing checks (fixable):
   - prefix-mismatch          Detect rigs.json vs routes.jsonl prefix mismatches (fixable)
   - database-prefix          Detect database vs routes.jsonl prefix mismatches (fixable)
 
+Configuration checks:
+  - rig-settings             Check rigs have a settings/ directory (fixable)
+  - rig-settings-valid       Check rig settings/config.json parses and validates
+  - rig-detection            Check GT_RIG, session name and cwd agree on the rig
+
 Session hook checks:
   - session-hooks            Check settings.json use session-start.sh
   - claude-settings          Check Claude settings.json match templates (fixable)
@@ -155,6 +160,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	d.Register(doctor.NewIdentityCollisionCheck())
 	d.Register(doctor.NewLinkedPaneCheck())
 	d.Register(doctor.NewThemeCheck())
+	d.Register(doctor.NewRigDetectionCheck())
 	d.Register(doctor.NewCrashReportCheck())
 	d.Register(doctor.NewEnvVarsCheck())
 
@@ -173,6 +179,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 
 	// Config architecture checks
 	d.Register(doctor.NewSettingsCheck())
+	d.Register(doctor.NewRigSettingsValidCheck())
 	d.Register(doctor.NewSessionHookCheck())
 	d.Register(doctor.NewRuntimeGitignoreCheck())
 	d.Register(doctor.NewLegacyGastownCheck())
@@ -191,6 +198,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 
 	// Hook attachment checks
 	d.Register(doctor.NewHookAttachmentValidCheck())
+	d.Register(doctor.NewHookValidCheck())
 	d.Register(doctor.NewHookSingletonCheck())
 	d.Register(doctor.NewOrphanedAttachmentsCheck())
 
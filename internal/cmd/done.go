@@ -671,8 +671,9 @@ func updateAgentStateOnDone(cwd, townRoot, exitType, _ string) { // issueID unus
 
 	if agentBead.HookBead != "" {
 		hookedBeadID := agentBead.HookBead
-		// Only close if the hooked bead exists and is still in "hooked" status
-		if hookedBead, err := bd.Show(hookedBeadID); err == nil && hookedBead.Status == beads.StatusHooked {
+		// Only close if the hooked bead exists and is still on the hook
+		// (waiting or in progress; see wisp's hook states)
+		if hookedBead, err := bd.Show(hookedBeadID); err == nil && (hookedBead.Status == beads.StatusHooked || hookedBead.Status == "in_progress") {
 			// BUG FIX: Close attached molecule (wisp) BEFORE closing hooked bead.
 			// When using formula-on-bead (gt sling formula --on bead), the base bead
 			// has attached_molecule pointing to the wisp. Without this fix, gt done
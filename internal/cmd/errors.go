@@ -1,8 +1,14 @@
 package cmd
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 // SilentExitError signals that the command should exit with a specific code
@@ -34,3 +40,65 @@ func IsSilentExit(err error) (int, bool) {
 	}
 	return 0, false
 }
+
+// ErrorCode is the stable, machine-readable category of a command failure,
+// reported by --json-errors.
+type ErrorCode string
+
+// Error codes, each with its own exit status under --json-errors.
+const (
+	CodeError           ErrorCode = "error"            // anything uncategorized (exit 1)
+	CodeNotInTown       ErrorCode = "not_in_town"      // exit 3
+	CodeConfigNotFound  ErrorCode = "config_not_found" // exit 4
+	CodeUnknownTheme    ErrorCode = "unknown_theme"    // exit 5
+	CodeTmuxUnavailable ErrorCode = "tmux_unavailable" // exit 6
+)
+
+// errorCodeExits maps error codes to exit statuses. Exit 2 is left for
+// usage errors.
+var errorCodeExits = map[ErrorCode]int{
+	CodeError:           1,
+	CodeNotInTown:       3,
+	CodeConfigNotFound:  4,
+	CodeUnknownTheme:    5,
+	CodeTmuxUnavailable: 6,
+}
+
+// ExitCode returns the process exit status for the code.
+func (c ErrorCode) ExitCode() int {
+	if code, ok := errorCodeExits[c]; ok {
+		return code
+	}
+	return 1
+}
+
+// ErrorCodeOf categorizes err by the typed errors it wraps.
+func ErrorCodeOf(err error) ErrorCode {
+	switch {
+	case errors.Is(err, workspace.ErrNotFound):
+		return CodeNotInTown
+	case errors.Is(err, config.ErrNotFound):
+		return CodeConfigNotFound
+	case errors.Is(err, errUnknownTheme):
+		return CodeUnknownTheme
+	case errors.Is(err, tmux.ErrNotInstalled), errors.Is(err, tmux.ErrNoServer),
+		errors.Is(err, tmux.ErrServerBusy), errors.Is(err, tmux.ErrTmuxTimeout):
+		return CodeTmuxUnavailable
+	}
+	return CodeError
+}
+
+// jsonError is the --json-errors report of a failed command.
+type jsonError struct {
+	Error string    `json:"error"`
+	Code  ErrorCode `json:"code"`
+}
+
+// writeJSONError reports err to w as a single JSON object and returns the
+// exit status for it.
+func writeJSONError(w io.Writer, err error) int {
+	code := ErrorCodeOf(err)
+	data, _ := json.Marshal(jsonError{Error: err.Error(), Code: code})
+	fmt.Fprintln(w, string(data))
+	return code.ExitCode()
+}
@@ -1,9 +1,15 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 func TestSilentExitError_Error(t *testing.T) {
@@ -90,3 +96,39 @@ func TestSilentExitError_Is(t *testing.T) {
 		t.Errorf("errors.As extracted code = %d, want 1", target.Code)
 	}
 }
+
+func TestErrorCodeOf(t *testing.T) {
+	tests := []struct {
+		err  error
+		want ErrorCode
+	}{
+		{fmt.Errorf("not in a Gas Town workspace: %w", workspace.ErrNotFound), CodeNotInTown},
+		{fmt.Errorf("loading settings: %w", config.ErrNotFound), CodeConfigNotFound},
+		{fmt.Errorf("%w: nope", errUnknownTheme), CodeUnknownTheme},
+		{fmt.Errorf("listing sessions: %w", tmux.ErrNoServer), CodeTmuxUnavailable},
+		{tmux.ErrNotInstalled, CodeTmuxUnavailable},
+		{errors.New("something else"), CodeError},
+	}
+	for _, tt := range tests {
+		if got := ErrorCodeOf(tt.err); got != tt.want {
+			t.Errorf("ErrorCodeOf(%v) = %s, want %s", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestWriteJSONError(t *testing.T) {
+	_, err := matchThemeName("", "no-such-theme")
+	var buf bytes.Buffer
+	exit := writeJSONError(&buf, err)
+	if exit != CodeUnknownTheme.ExitCode() || exit == 1 {
+		t.Errorf("exit = %d, want the unknown_theme status %d", exit, CodeUnknownTheme.ExitCode())
+	}
+
+	var got jsonError
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+	if got.Code != CodeUnknownTheme || got.Error != err.Error() {
+		t.Errorf("report = %+v, want code unknown_theme and error %q", got, err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/events"
@@ -15,6 +16,7 @@ import (
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/wisp"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -46,6 +48,16 @@ The --collect (-c) flag gathers current state (hooked work, inbox, ready beads,
 in-progress items) and includes it in the handoff mail. This provides context
 for the next session without manual summarization.
 
+Use --preview to proofread the handoff mail (subject and message, with
+defaults filled in) before anything is hooked: gt prints it and asks
+whether to go ahead. --edit opens the mail in $VISUAL or $EDITOR first,
+and can re-open it before you confirm. Declining leaves the hook and the
+session untouched.
+
+When handing off a bead, it must exist. If its existence can't be checked
+(bd unavailable, database locked) handoff warns and continues; use --strict
+to fail instead.
+
 Any molecule on the hook will be auto-continued by the new session.
 The SessionStart hook runs 'gt prime' to restore context.`,
 	RunE: runHandoff,
@@ -57,6 +69,9 @@ var (
 	handoffSubject string
 	handoffMessage string
 	handoffCollect bool
+	handoffStrict  bool
+	handoffPreview bool
+	handoffEdit    bool
 )
 
 func init() {
@@ -65,6 +80,9 @@ func init() {
 	handoffCmd.Flags().StringVarP(&handoffSubject, "subject", "s", "", "Subject for handoff mail (optional)")
 	handoffCmd.Flags().StringVarP(&handoffMessage, "message", "m", "", "Message body for handoff mail (optional)")
 	handoffCmd.Flags().BoolVarP(&handoffCollect, "collect", "c", false, "Auto-collect state (status, inbox, beads) into handoff message")
+	handoffCmd.Flags().BoolVar(&handoffStrict, "strict", false, "Fail if the bead's existence cannot be verified")
+	handoffCmd.Flags().BoolVar(&handoffPreview, "preview", false, "Show the handoff mail and ask before hooking anything")
+	handoffCmd.Flags().BoolVar(&handoffEdit, "edit", false, "Edit the handoff mail in $EDITOR, then confirm before hooking anything")
 	rootCmd.AddCommand(handoffCmd)
 }
 
@@ -115,15 +133,13 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 
 	// Determine target session and check for bead hook
 	targetSession := currentSession
+	var hookBead string
 	if len(args) > 0 {
 		arg := args[0]
 
 		// Check if arg is a bead ID (gt-xxx, hq-xxx, bd-xxx, etc.)
 		if looksLikeBeadID(arg) {
-			// Hook the bead first
-			if err := hookBeadForHandoff(arg); err != nil {
-				return fmt.Errorf("hooking bead: %w", err)
-			}
+			hookBead = arg
 			// Update subject if not set
 			if handoffSubject == "" {
 				handoffSubject = fmt.Sprintf("🪝 HOOKED: %s", arg)
@@ -137,6 +153,26 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// With --preview/--edit, nothing is hooked until the mail is confirmed
+	if handoffPreview || handoffEdit {
+		m, ok, err := confirmHandoffMail(wisp.RenderHandoffMail(handoffSubject, handoffMessage), handoffEdit)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Handoff cancelled; nothing was hooked")
+			return nil
+		}
+		handoffSubject, handoffMessage = m.Subject, m.Body
+	}
+
+	// Hook the bead first
+	if hookBead != "" {
+		if err := hookBeadForHandoff(hookBead); err != nil {
+			return fmt.Errorf("hooking bead: %w", err)
+		}
+	}
+
 	// Build the restart command
 	restartCmd, err := buildRestartCommand(targetSession)
 	if err != nil {
@@ -235,6 +271,54 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 	return t.RespawnPane(pane, restartCmd)
 }
 
+// confirmHandoffMail shows the handoff mail m and asks whether to send it,
+// first opening it in the user's editor when edit is set. Choosing to edit
+// again re-opens the editor. ok is false if the user declines.
+func confirmHandoffMail(m wisp.HandoffMail, edit bool) (_ wisp.HandoffMail, ok bool, err error) {
+	for {
+		if edit {
+			if m, err = editHandoffMail(m); err != nil {
+				return m, false, err
+			}
+		}
+		fmt.Printf("%s Handoff mail:\n\n%s\n", style.Bold.Render("📬"), m)
+		if promptYesNo("Send this handoff?") {
+			return m, true, nil
+		}
+		if !promptYesNo("Edit it?") {
+			return m, false, nil
+		}
+		edit = true
+	}
+}
+
+// editHandoffMail opens m in the user's editor and returns it as
+// saved.
+func editHandoffMail(m wisp.HandoffMail) (wisp.HandoffMail, error) {
+	tmp, err := os.CreateTemp("", "gt-handoff-*.txt")
+	if err != nil {
+		return m, fmt.Errorf("creating temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+	_, err = tmp.WriteString(m.String())
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return m, fmt.Errorf("writing temp file: %w", err)
+	}
+
+	if err := runEditor(tmpPath); err != nil {
+		return m, fmt.Errorf("running editor: %w", err)
+	}
+	edited, err := os.ReadFile(tmpPath) //nolint:gosec // G304: temp file we created
+	if err != nil {
+		return m, fmt.Errorf("reading edited mail: %w", err)
+	}
+	return wisp.ParseHandoffMail(string(edited)), nil
+}
+
 // getCurrentTmuxSession returns the current tmux session name.
 func getCurrentTmuxSession() (string, error) {
 	out, err := exec.Command("tmux", "display-message", "-p", "#{session_name}").Output()
@@ -656,17 +740,9 @@ func getSessionPane(sessionName string) (string, error) {
 // sendHandoffMail sends a handoff mail to self and auto-hooks it.
 // Returns the created bead ID and any error.
 func sendHandoffMail(subject, message string) (string, error) {
-	// Build subject with handoff prefix if not already present
-	if subject == "" {
-		subject = "🤝 HANDOFF: Session cycling"
-	} else if !strings.Contains(subject, "HANDOFF") {
-		subject = "🤝 HANDOFF: " + subject
-	}
-
-	// Default message if not provided
-	if message == "" {
-		message = "Context cycling. Check bd ready for pending work."
-	}
+	// Apply the handoff prefix and defaults
+	rendered := wisp.RenderHandoffMail(subject, message)
+	subject, message = rendered.Subject, rendered.Body
 
 	// Detect agent identity for self-mail
 	agentID, _, _, err := resolveSelfTarget()
@@ -772,9 +848,15 @@ func looksLikeBeadID(s string) bool {
 
 // hookBeadForHandoff attaches a bead to the current agent's hook.
 func hookBeadForHandoff(beadID string) error {
-	// Verify the bead exists first
-	verifyCmd := exec.Command("bd", "show", beadID, "--json")
-	if err := verifyCmd.Run(); err != nil {
+	// Verify the bead exists first, so a typo is caught now rather than
+	// when the next session tries to pick up the work
+	exists, err := beads.BeadExists(resolveBeadDir(beadID), beadID)
+	if err != nil {
+		if handoffStrict {
+			return fmt.Errorf("verifying bead '%s': %w", beadID, err)
+		}
+		style.PrintWarning("could not verify bead %s exists: %v", beadID, err)
+	} else if !exists {
 		return fmt.Errorf("bead '%s' not found", beadID)
 	}
 
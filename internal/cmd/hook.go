@@ -7,12 +7,15 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/runtime"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/wisp"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -34,8 +37,20 @@ you left off.
 Examples:
   gt hook                           # Show what's on my hook
   gt hook status                    # Same as above
+  gt hook watch --timeout 10m       # Wait for work to be hooked
   gt hook gt-abc                    # Attach issue gt-abc to your hook
   gt hook gt-abc -s "Fix the bug"   # With subject for handoff mail
+  gt hook gt-abc --beads-dir /mnt/shared/.beads  # Hook from a shared beads DB
+
+--beads-dir points hook operations at an explicit .beads directory (for
+example a central database on a network mount shared by several towns)
+instead of the one discovered from the current directory. A warning is
+printed if it lives on a network filesystem, where bd's file locking may
+not be reliable.
+
+The context message (-m) is capped at hook_context_max bytes in
+settings/config.json (default 4096). Longer messages are truncated with a
+note saying how much was dropped; use --strict to reject them instead.
 
 Related commands:
   gt sling <bead>    # Hook + start now (keep context)
@@ -80,21 +95,28 @@ Examples:
   gt hook show gastown/polecats/nux    # What's nux working on?
   gt hook show gastown/witness         # What's the witness hooked to?
   gt hook show mayor                   # What's the mayor working on?
+  gt hook show mayor --beads-dir /mnt/shared/.beads
 
-Output format (one line):
+Output format (one line; pinned hooks end in "(pinned)"):
   gastown/polecats/nux: gt-abc123 'Fix the widget bug' [in_progress]`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runHookShow,
 }
 
 var (
-	hookSubject string
-	hookMessage string
-	hookDryRun  bool
-	hookForce   bool
-	hookClear   bool
+	hookSubject  string
+	hookMessage  string
+	hookDryRun   bool
+	hookForce    bool
+	hookClear    bool
+	hookBeadsDir string
+	hookStrict   bool
 )
 
+// DefaultHookContextMax is the default cap, in bytes, on the hook context
+// message (settings/config.json hook_context_max).
+const DefaultHookContextMax = 4096
+
 func init() {
 	// Flags for attaching work (gt hook <bead-id>)
 	hookCmd.Flags().StringVarP(&hookSubject, "subject", "s", "", "Subject for handoff mail (optional)")
@@ -102,6 +124,9 @@ func init() {
 	hookCmd.Flags().BoolVarP(&hookDryRun, "dry-run", "n", false, "Show what would be done")
 	hookCmd.Flags().BoolVarP(&hookForce, "force", "f", false, "Replace existing incomplete hooked bead")
 	hookCmd.Flags().BoolVar(&hookClear, "clear", false, "Clear your hook (alias for 'gt unhook')")
+	hookCmd.Flags().StringVar(&hookBeadsDir, "beads-dir", "", "Use this .beads directory instead of the discovered one")
+	hookCmd.Flags().BoolVar(&hookStrict, "strict", false, "Reject a context message over the length limit instead of truncating it")
+	hookShowCmd.Flags().StringVar(&hookBeadsDir, "beads-dir", "", "Use this .beads directory instead of the discovered one")
 
 	// --json flag for status output (used when no args, i.e., gt hook --json)
 	hookCmd.Flags().BoolVar(&moleculeJSON, "json", false, "Output as JSON (for status)")
@@ -138,8 +163,31 @@ func runHook(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("polecats cannot hook work (use gt done for handoff)")
 	}
 
+	if hookMessage != "" {
+		limit := hookContextMax()
+		if msg, truncated := limitHookContext(hookMessage, limit); truncated {
+			if hookStrict {
+				return fmt.Errorf("context message is %d bytes, over the %d byte limit (drop --strict to truncate)",
+					len(hookMessage), limit)
+			}
+			fmt.Fprintf(os.Stderr, "%s Warning: context message truncated to %d bytes\n",
+				style.Dim.Render("⚠"), limit)
+			hookMessage = msg
+		}
+	}
+
+	// Find beads directory
+	b, workDir, err := hookBeads()
+	if err != nil {
+		return err
+	}
+
 	// Verify the bead exists
-	if err := verifyBeadExists(beadID); err != nil {
+	if hookBeadsDir != "" {
+		if _, err := b.Show(beadID); err != nil {
+			return fmt.Errorf("bead '%s' not found in %s", beadID, hookBeadsDir)
+		}
+	} else if err := verifyBeadExists(beadID); err != nil {
 		return err
 	}
 
@@ -148,15 +196,11 @@ func runHook(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("detecting agent identity: %w", err)
 	}
-
-	// Find beads directory
-	workDir, err := findLocalBeadsDir()
-	if err != nil {
-		return fmt.Errorf("not in a beads workspace: %w", err)
+	agentID = wisp.NormalizeIdentity(agentID)
+	if err := wisp.ValidateHookTarget(beadID, agentID); err != nil {
+		return err
 	}
 
-	b := beads.New(workDir)
-
 	// Check for existing hooked bead for this agent
 	existingPinned, err := b.List(beads.ListOptions{
 		Status:   beads.StatusHooked,
@@ -192,6 +236,7 @@ func runHook(_ *cobra.Command, args []string) error {
 						closeArgs = append(closeArgs, "--session="+sessionID)
 					}
 					closeCmd := exec.Command("bd", closeArgs...)
+					closeCmd.Env = hookBeadsEnv()
 					closeCmd.Stderr = os.Stderr
 					if err := closeCmd.Run(); err != nil {
 						return fmt.Errorf("closing completed bead %s: %w", existing.ID, err)
@@ -246,6 +291,7 @@ func runHook(_ *cobra.Command, args []string) error {
 	// This is essential for hooking convoys (hq-* prefix) stored in town beads.
 	hookCmd := exec.Command("bd", "update", beadID, "--status=hooked", "--assignee="+agentID)
 	hookCmd.Dir = townRoot
+	hookCmd.Env = hookBeadsEnv()
 	hookCmd.Stderr = os.Stderr
 	if err := hookCmd.Run(); err != nil {
 		return fmt.Errorf("hooking bead: %w", err)
@@ -268,6 +314,40 @@ func runHook(_ *cobra.Command, args []string) error {
 	return nil
 }
 
+// hookContextMax returns the town's hook context length limit, falling back
+// to DefaultHookContextMax.
+func hookContextMax() int {
+	townRoot, err := workspace.FindFromCwd()
+	if err == nil && townRoot != "" {
+		settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+		if err == nil && settings.HookContextMax > 0 {
+			return settings.HookContextMax
+		}
+	}
+	return DefaultHookContextMax
+}
+
+// limitHookContext truncates msg to at most max bytes, cutting at a rune
+// boundary and ending with a note that records how much was dropped.
+// Reports whether msg was truncated.
+func limitHookContext(msg string, max int) (string, bool) {
+	if len(msg) <= max {
+		return msg, false
+	}
+
+	// The note's length depends on the dropped count, so size it for the
+	// worst case (the whole message dropped).
+	note := fmt.Sprintf("… [truncated %d bytes]", len(msg))
+	cut := max - len(note)
+	if cut < 0 {
+		cut = 0
+	}
+	for cut > 0 && !utf8.RuneStart(msg[cut]) {
+		cut--
+	}
+	return msg[:cut] + fmt.Sprintf("… [truncated %d bytes]", len(msg)-cut), true
+}
+
 // checkPinnedBeadComplete checks if a pinned bead's attached molecule is 100% complete.
 // Returns (isComplete, hasAttachment):
 // - isComplete=true if no molecule attached OR all molecule steps are closed
@@ -299,7 +379,10 @@ func checkPinnedBeadComplete(b *beads.Beads, issue *beads.Issue) (isComplete boo
 func runHookShow(cmd *cobra.Command, args []string) error {
 	var target string
 	if len(args) > 0 {
-		target = args[0]
+		if err := wisp.ValidateIdentity(args[0]); err != nil {
+			return err
+		}
+		target = wisp.NormalizeIdentity(args[0])
 	} else {
 		// Auto-detect current agent from context
 		agentID, _, _, err := resolveSelfTarget()
@@ -309,13 +392,57 @@ func runHookShow(cmd *cobra.Command, args []string) error {
 		target = agentID
 	}
 
-	// Find beads directory
-	workDir, err := findLocalBeadsDir()
+	hookedBeads, _, err := agentHookedBeads(target)
 	if err != nil {
-		return fmt.Errorf("not in a beads workspace: %w", err)
+		return err
 	}
 
-	b := beads.New(workDir)
+	// JSON output
+	if moleculeJSON {
+		type compactInfo struct {
+			Agent  string `json:"agent"`
+			BeadID string `json:"bead_id,omitempty"`
+			Title  string `json:"title,omitempty"`
+			Status string `json:"status"`
+			Pinned bool   `json:"pinned,omitempty"`
+		}
+		info := compactInfo{Agent: target}
+		if len(hookedBeads) > 0 {
+			info.BeadID = hookedBeads[0].ID
+			info.Title = hookedBeads[0].Title
+			info.Status = hookedBeads[0].Status
+			info.Pinned = wisp.HookPinned(hookedBeads[0])
+		} else {
+			info.Status = "empty"
+		}
+		enc := json.NewEncoder(os.Stdout)
+		return enc.Encode(info)
+	}
+
+	// Compact one-line output
+	if len(hookedBeads) == 0 {
+		fmt.Printf("%s: (empty)\n", target)
+		return nil
+	}
+
+	bead := hookedBeads[0]
+	pinned := ""
+	if wisp.HookPinned(bead) {
+		pinned = " (pinned)"
+	}
+	fmt.Printf("%s: %s '%s' [%s]%s\n", target, bead.ID, bead.Title, bead.Status, pinned)
+	return nil
+}
+
+// agentHookedBeads returns the beads on target's hook and a database to
+// update them through. It looks in the hook database (see hookBeads), then
+// town beads, where convoys live, then for town-level roles every rig.
+func agentHookedBeads(target string) ([]*beads.Issue, *beads.Beads, error) {
+	// Find beads directory
+	b, _, err := hookBeads()
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// Query for hooked beads assigned to the target
 	hookedBeads, err := b.List(beads.ListOptions{
@@ -324,13 +451,14 @@ func runHookShow(cmd *cobra.Command, args []string) error {
 		Priority: -1,
 	})
 	if err != nil {
-		return fmt.Errorf("listing hooked beads: %w", err)
+		return nil, nil, fmt.Errorf("listing hooked beads: %w", err)
 	}
 
 	// If nothing found in local beads, also check town beads for hooked convoys.
 	// Convoys (hq-cv-*) are stored in town beads (~/gt/.beads) and any agent
-	// can hook them for convoy-driver mode.
-	if len(hookedBeads) == 0 {
+	// can hook them for convoy-driver mode. An explicit --beads-dir is
+	// authoritative, so the fallbacks are skipped.
+	if len(hookedBeads) == 0 && hookBeadsDir == "" {
 		townRoot, err := findTownRoot()
 		if err == nil && townRoot != "" {
 			// Check town beads for hooked items
@@ -343,46 +471,61 @@ func runHookShow(cmd *cobra.Command, args []string) error {
 					Priority: -1,
 				})
 				if err == nil && len(townHooked) > 0 {
-					hookedBeads = townHooked
+					return townHooked, townBeads, nil
 				}
 			}
 
-			// If still nothing found and town-level role, scan all rigs
-			if len(hookedBeads) == 0 && isTownLevelRole(target) {
-				hookedBeads = scanAllRigsForHookedBeads(townRoot, target)
+			// If still nothing found and town-level role, scan all rigs.
+			// From the town root bd routes updates to the rig by ID prefix.
+			if isTownLevelRole(target) {
+				if rigHooked := scanAllRigsForHookedBeads(townRoot, target); len(rigHooked) > 0 {
+					return rigHooked, beads.New(townRoot), nil
+				}
 			}
 		}
 	}
 
-	// JSON output
-	if moleculeJSON {
-		type compactInfo struct {
-			Agent  string `json:"agent"`
-			BeadID string `json:"bead_id,omitempty"`
-			Title  string `json:"title,omitempty"`
-			Status string `json:"status"`
-		}
-		info := compactInfo{Agent: target}
-		if len(hookedBeads) > 0 {
-			info.BeadID = hookedBeads[0].ID
-			info.Title = hookedBeads[0].Title
-			info.Status = hookedBeads[0].Status
-		} else {
-			info.Status = "empty"
+	return hookedBeads, b, nil
+}
+
+// hookBeads returns the beads wrapper and working directory for hook
+// operations: the --beads-dir database when given, otherwise the local one.
+func hookBeads() (*beads.Beads, string, error) {
+	if hookBeadsDir == "" {
+		workDir, err := findLocalBeadsDir()
+		if err != nil {
+			return nil, "", fmt.Errorf("not in a beads workspace: %w", err)
 		}
-		enc := json.NewEncoder(os.Stdout)
-		return enc.Encode(info)
+		return beads.New(workDir), workDir, nil
 	}
 
-	// Compact one-line output
-	if len(hookedBeads) == 0 {
-		fmt.Printf("%s: (empty)\n", target)
-		return nil
+	dir, err := filepath.Abs(hookBeadsDir)
+	if err != nil {
+		return nil, "", fmt.Errorf("resolving --beads-dir: %w", err)
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, "", fmt.Errorf("--beads-dir: %w", err)
 	}
+	if !info.IsDir() {
+		return nil, "", fmt.Errorf("--beads-dir %s is not a directory", dir)
+	}
+	if fsType, ok := beads.NetworkFilesystem(dir); ok {
+		fmt.Fprintf(os.Stderr, "%s Warning: %s is on a %s filesystem; bd's file locking may not be reliable there\n",
+			style.Dim.Render("⚠"), dir, fsType)
+	}
+	hookBeadsDir = dir
+	workDir := filepath.Dir(dir)
+	return beads.NewWithBeadsDir(workDir, dir), workDir, nil
+}
 
-	bead := hookedBeads[0]
-	fmt.Printf("%s: %s '%s' [%s]\n", target, bead.ID, bead.Title, bead.Status)
-	return nil
+// hookBeadsEnv returns the environment for bd subprocesses run by hook
+// commands, pointing them at --beads-dir when it is set.
+func hookBeadsEnv() []string {
+	if hookBeadsDir == "" {
+		return nil // inherit
+	}
+	return append(os.Environ(), "BEADS_DIR="+hookBeadsDir)
 }
 
 // findTownRoot finds the Gas Town root directory.
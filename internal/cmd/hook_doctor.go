@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/wisp"
+)
+
+var hookDoctorJSON bool
+
+var hookDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Find hooks with no one to pick them up",
+	Long: `Cross-check pending hooks against running sessions and known workers.
+
+Every bead with status=hooked (in the town and every routed rig database)
+is checked for a plausible recipient: a running tmux session for the
+assignee (gt-<rig>-<worker>, gt-<rig>-crew-<name>, ...) or a worker the rig
+knows about (a polecats/ or crew/ directory). Town agents (mayor, deacon and
+its dogs) always count, as do a rig's witness and refinery and its crew role
+hook while the rig exists.
+
+Hooks that fail the check are orphaned: nothing will ever run them. For
+each one the command suggests moving it to a live worker with 'gt sling' or
+releasing it with 'gt unsling'. Exits non-zero when orphans are found.
+
+Examples:
+  gt hook doctor          # Report orphaned hooks
+  gt hook doctor --json   # Machine-readable`,
+	Args: cobra.NoArgs,
+	RunE: runHookDoctor,
+}
+
+func init() {
+	hookDoctorCmd.Flags().BoolVar(&hookDoctorJSON, "json", false, "Output as JSON")
+	hookCmd.AddCommand(hookDoctorCmd)
+}
+
+// OrphanedHook is a pending hook with no plausible recipient.
+type OrphanedHook struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Assignee string `json:"assignee"`
+	Reason   string `json:"reason"`
+}
+
+// hookRecipient reports whether a hook's assignee could ever pick it up,
+// given the town's rigs and the running tmux sessions. The reason explains
+// a false result.
+func hookRecipient(assignee string, rigs map[string]*rig.Rig, sessions map[string]bool) (bool, string) {
+	assignee = strings.TrimSpace(assignee)
+	if assignee == "" {
+		return false, "no assignee"
+	}
+	if assignee == "deacon" || strings.HasPrefix(assignee, "deacon/") {
+		return true, "" // the deacon and its dogs are started on demand
+	}
+	if rigName, ok := wisp.IsRoleHookIdentity(assignee); ok {
+		r := rigs[rigName]
+		switch {
+		case r == nil:
+			return false, fmt.Sprintf("rig %s not found", rigName)
+		case len(r.Crew) == 0:
+			return false, fmt.Sprintf("rig %s has no crew to claim the role hook", rigName)
+		}
+		return true, ""
+	}
+
+	id, err := session.ParseAddress(assignee)
+	if err != nil {
+		return false, fmt.Sprintf("unrecognized assignee: %v", err)
+	}
+	if id.Role == session.RoleMayor {
+		return true, ""
+	}
+	r := rigs[id.Rig]
+	if r == nil {
+		return false, fmt.Sprintf("rig %s not found", id.Rig)
+	}
+	switch id.Role {
+	case session.RoleCrew:
+		if sessions[id.SessionName()] || slices.Contains(r.Crew, id.Name) {
+			return true, ""
+		}
+		return false, fmt.Sprintf("no session %s and no crew member %s in rig %s", id.SessionName(), id.Name, id.Rig)
+	case session.RolePolecat:
+		if sessions[id.SessionName()] || slices.Contains(r.Polecats, id.Name) {
+			return true, ""
+		}
+		return false, fmt.Sprintf("no session %s and no polecat %s in rig %s", id.SessionName(), id.Name, id.Rig)
+	}
+	return true, "" // witness and refinery exist wherever the rig does
+}
+
+func runHookDoctor(cmd *cobra.Command, args []string) error {
+	rigList, townRoot, err := getAllRigs()
+	if err != nil {
+		return err
+	}
+	rigs := make(map[string]*rig.Rig, len(rigList))
+	for _, r := range rigList {
+		rigs[r.Name] = r
+	}
+
+	sessions := make(map[string]bool)
+	names, err := tmux.NewTmux().ListSessions()
+	if err != nil {
+		// Without sessions only workers on disk count; say so rather than fail
+		fmt.Fprintf(os.Stderr, "%s Warning: listing tmux sessions: %v\n", style.Dim.Render("⚠"), err)
+	}
+	for _, name := range names {
+		sessions[name] = true
+	}
+
+	hooked := collectHookedBeads(townRoot)
+	orphans := []OrphanedHook{}
+	for _, issue := range hooked {
+		if ok, reason := hookRecipient(issue.Assignee, rigs, sessions); !ok {
+			orphans = append(orphans, OrphanedHook{ID: issue.ID, Title: issue.Title, Assignee: issue.Assignee, Reason: reason})
+		}
+	}
+	sort.Slice(orphans, func(i, j int) bool { return orphans[i].ID < orphans[j].ID })
+
+	if hookDoctorJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(orphans); err != nil {
+			return err
+		}
+	} else {
+		printOrphanedHooks(len(hooked), orphans)
+	}
+	if len(orphans) > 0 {
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+// printOrphanedHooks lists orphaned hooks with the commands that fix them.
+func printOrphanedHooks(pending int, orphans []OrphanedHook) {
+	if len(orphans) == 0 {
+		fmt.Printf("%s All %d pending hooks have a plausible recipient\n", style.Bold.Render("✓"), pending)
+		return
+	}
+	fmt.Printf("%s %d of %d pending hooks are orphaned\n\n", style.Bold.Render("⚠"), len(orphans), pending)
+	for _, o := range orphans {
+		fmt.Printf("  %s %s\n", style.Bold.Render(o.ID), o.Title)
+		fmt.Printf("    assignee: %s\n", orUnknown(o.Assignee))
+		fmt.Printf("    %s\n", style.Dim.Render(o.Reason))
+		fmt.Printf("    transfer: gt sling %s <rig>/<worker>\n", o.ID)
+		if o.Assignee != "" {
+			fmt.Printf("    release:  gt unsling %s %s\n", o.ID, o.Assignee)
+		}
+	}
+}
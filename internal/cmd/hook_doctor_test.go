@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestHookRecipient(t *testing.T) {
+	rigs := map[string]*rig.Rig{
+		"gastown": {Name: "gastown", Crew: []string{"max"}, Polecats: []string{"Toast"}},
+		"beads":   {Name: "beads"},
+	}
+	sessions := map[string]bool{
+		"gt-beads-Nux":      true,
+		"gt-beads-crew-joe": true,
+	}
+
+	tests := []struct {
+		assignee string
+		want     bool
+	}{
+		{"mayor", true},
+		{"mayor/", true},
+		{"deacon", true},
+		{"deacon/dogs/alpha", true},
+		{"gastown/witness", true},
+		{"beads/refinery", true},
+		{"gastown/crew", true},            // role hook, rig has crew
+		{"beads/crew", false},             // role hook, nobody to claim it
+		{"gastown/crew/max", true},        // known crew member
+		{"gastown/polecats/Toast", true},  // known polecat
+		{"gastown/Toast", true},           // short polecat address
+		{"beads/polecats/Nux", true},      // running session only
+		{"beads/crew/joe", true},          // running session only
+		{"gastown/polecats/Ghost", false}, // neither
+		{"gastown/crew/ghost", false},
+		{"oldrig/witness", false}, // rig removed
+		{"oldrig/crew", false},
+		{"", false},
+		{"overseer", false},
+		{"a/b/c/d", false},
+	}
+	for _, tt := range tests {
+		got, reason := hookRecipient(tt.assignee, rigs, sessions)
+		if got != tt.want {
+			t.Errorf("hookRecipient(%q) = %v (%s), want %v", tt.assignee, got, reason, tt.want)
+		}
+		if !got && reason == "" {
+			t.Errorf("hookRecipient(%q) gave no reason", tt.assignee)
+		}
+	}
+}
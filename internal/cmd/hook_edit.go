@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/wisp"
+)
+
+var hookEditCmd = &cobra.Command{
+	Use:   "edit [agent]",
+	Short: "Edit the work on an agent's hook in $EDITOR",
+	Long: `Fix a hook in place instead of unslinging and re-slinging it.
+
+The hook opens in $EDITOR as JSON with the fields 'gt sling' set:
+
+  bead        the hooked bead; changing it swaps in another bead
+  args        --args instructions for the agent
+  meta        --meta key/value pairs
+  depends_on  --after dependencies
+  no_merge    --no-merge
+  pinned      'gt hook pin'
+
+When the editor exits the edit is validated: a well-formed bead ID,
+single-line values, and dependencies that exist and don't form a cycle.
+If validation fails you are asked whether to re-open the editor; declining
+aborts and leaves the hook untouched. A valid edit is written to the bead
+in one update and stamped with modified_at; the bead keeps its creation
+time and everything else on it.
+
+Swapping the bead hooks the new one to the agent with the edited fields
+and returns the old one to open, as 'gt unsling' would. The new bead must
+not be hooked or closed, and a hook whose work has started can't be
+swapped. The editor is taken from $VISUAL, then $EDITOR, falling back to
+vi. With no argument, edits your own hook.
+
+Examples:
+  gt hook edit                       # Edit my hook
+  gt hook edit gastown/crew/max      # Fix the context slung to max`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runHookEdit,
+}
+
+func init() {
+	hookEditCmd.Flags().StringVar(&hookBeadsDir, "beads-dir", "", "Use this .beads directory instead of the discovered one")
+	hookCmd.AddCommand(hookEditCmd)
+}
+
+func runHookEdit(cmd *cobra.Command, args []string) error {
+	var target string
+	if len(args) > 0 {
+		if err := wisp.ValidateIdentity(args[0]); err != nil {
+			return err
+		}
+		target = wisp.NormalizeIdentity(args[0])
+	} else {
+		agentID, _, _, err := resolveSelfTarget()
+		if err != nil {
+			return fmt.Errorf("auto-detecting agent (use explicit argument): %w", err)
+		}
+		target = agentID
+	}
+
+	hooked, b, err := agentHookedBeads(target)
+	if err != nil {
+		return err
+	}
+	if len(hooked) == 0 {
+		return fmt.Errorf("%s: %w", target, wisp.ErrNoHook)
+	}
+	bead := hooked[0]
+
+	original, err := json.MarshalIndent(wisp.NewHookEdit(bead), "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding hook: %w", err)
+	}
+	original = append(original, '\n')
+
+	tmp, err := os.CreateTemp("", "gt-hook-*.json")
+	if err != nil {
+		return fmt.Errorf("creating temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+	_, err = tmp.Write(original)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("writing temp file: %w", err)
+	}
+
+	for {
+		if err := runEditor(tmpPath); err != nil {
+			return fmt.Errorf("running editor: %w (hook left unchanged)", err)
+		}
+
+		edited, err := os.ReadFile(tmpPath) //nolint:gosec // G304: temp file we created
+		if err != nil {
+			return fmt.Errorf("reading edited file: %w", err)
+		}
+		if bytes.Equal(bytes.TrimSpace(edited), bytes.TrimSpace(original)) {
+			fmt.Println("No changes")
+			return nil
+		}
+
+		edit, next, err := checkHookEdit(b, bead, edited)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Invalid hook: %v\n", style.Warning.Render("⚠"), err)
+			if promptYesNo("Re-open editor to fix it?") {
+				continue
+			}
+			return fmt.Errorf("aborted: %s's hook left unchanged", target)
+		}
+
+		return saveHookEdit(b, target, bead, next, edit)
+	}
+}
+
+// checkHookEdit parses and validates an edited hook document for the hook
+// on bead. It returns the edit and the bead that will be on the hook: bead
+// itself, or the bead the edit swaps in.
+func checkHookEdit(b *beads.Beads, bead *beads.Issue, data []byte) (wisp.HookEdit, *beads.Issue, error) {
+	var edit wisp.HookEdit
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&edit); err != nil {
+		return edit, nil, fmt.Errorf("parsing JSON: %w", err)
+	}
+	if err := edit.Validate(); err != nil {
+		return edit, nil, err
+	}
+
+	next := bead
+	if edit.Bead != bead.ID {
+		if wisp.HookInProgress(bead) {
+			return edit, nil, fmt.Errorf("work on %s has started; it can't be swapped for %s", bead.ID, edit.Bead)
+		}
+		issue, err := b.Show(edit.Bead)
+		if err != nil {
+			return edit, nil, fmt.Errorf("bead %s: %w", edit.Bead, err)
+		}
+		switch issue.Status {
+		case beads.StatusHooked, "in_progress":
+			return edit, nil, fmt.Errorf("%s is already on %s's hook", issue.ID, issue.Assignee)
+		case "closed":
+			return edit, nil, fmt.Errorf("%s is closed", issue.ID)
+		}
+		next = issue
+	}
+	if err := wisp.CheckHookDependencies(b, edit.Bead, edit.DependsOn); err != nil {
+		return edit, nil, err
+	}
+	return edit, next, nil
+}
+
+// saveHookEdit writes edit to next, the bead that stays on or is swapped
+// onto target's hook in place of bead.
+func saveHookEdit(b *beads.Beads, target string, bead, next *beads.Issue, edit wisp.HookEdit) error {
+	desc := wisp.EditedHookDescription(bead, next, edit, time.Now())
+	if next.ID == bead.ID {
+		if globalDryRun {
+			fmt.Printf("Would update %s on %s's hook\n", bead.ID, target)
+			return nil
+		}
+		if err := b.Update(bead.ID, beads.UpdateOptions{Description: &desc}); err != nil {
+			return fmt.Errorf("updating %s: %w", bead.ID, err)
+		}
+		fmt.Printf("%s Updated %s on %s's hook\n", style.Success.Render("✓"), bead.ID, target)
+		return nil
+	}
+
+	if globalDryRun {
+		fmt.Printf("Would hook %s to %s in place of %s and return %s to open\n", next.ID, target, bead.ID, bead.ID)
+		return nil
+	}
+	status := beads.StatusHooked
+	if err := b.Update(next.ID, beads.UpdateOptions{Status: &status, Assignee: &target, Description: &desc}); err != nil {
+		return fmt.Errorf("hooking %s to %s: %w", next.ID, target, err)
+	}
+
+	// bead was never picked up (checkHookEdit refuses to swap started
+	// work), so returning it to open is all 'gt unsling' would do.
+	openStatus := "open"
+	emptyAssignee := ""
+	if err := b.Update(bead.ID, beads.UpdateOptions{Status: &openStatus, Assignee: &emptyAssignee}); err != nil {
+		fmt.Fprintf(os.Stderr, "%s %s is hooked, but returning %s to open failed: %v\n", style.Dim.Render("⚠"), next.ID, bead.ID, err)
+	}
+	if townRoot, err := findTownRoot(); err == nil && townRoot != "" {
+		updateAgentHookBead(target, next.ID, "", filepath.Join(townRoot, ".beads"))
+	}
+
+	fmt.Printf("%s Hooked %s to %s in place of %s\n", style.Success.Render("✓"), next.ID, target, bead.ID)
+	return nil
+}
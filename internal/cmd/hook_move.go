@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/wisp"
+)
+
+var hookMoveFrom string
+
+var hookMoveToRigCmd = &cobra.Command{
+	Use:   "move-to-rig <rig> <agent>",
+	Short: "Move hooked work to an agent in another rig",
+	Long: `Move the work on an agent's hook to an agent in a different rig.
+
+Each rig has its own beads database, so a hook can't simply be reassigned
+across rigs. move-to-rig copies the hooked bead (title, type, priority and
+description) into the target rig's beads, hooks the copy to the target
+agent and closes the original with a note saying where it went. The copy
+is created by the source agent and records the original in its
+description as "meta.moved_from: <rig>:<bead-id>"; it starts out waiting,
+as if freshly slung.
+
+The agent is given relative to the target rig (crew/max, polecats/nux,
+witness, or crew for the rig's crew role hook). Both rigs are resolved
+from the town root, and the target rig must exist. With no --from, your
+own hook is moved. An agent whose hook is already occupied is refused.
+
+With the global --dry-run flag, the move is described and nothing changes.
+
+Examples:
+  gt hook move-to-rig beads crew/max                 # Hand my work to beads/crew/max
+  gt hook move-to-rig beads witness --from gastown/crew/joe`,
+	Args: cobra.ExactArgs(2),
+	RunE: runHookMoveToRig,
+}
+
+func init() {
+	hookMoveToRigCmd.Flags().StringVar(&hookMoveFrom, "from", "", "Agent whose hook to move (default: you)")
+	hookCmd.AddCommand(hookMoveToRigCmd)
+}
+
+func runHookMoveToRig(cmd *cobra.Command, args []string) error {
+	source := hookMoveFrom
+	if source == "" {
+		agentID, _, _, err := resolveSelfTarget()
+		if err != nil {
+			return fmt.Errorf("auto-detecting agent (use --from): %w", err)
+		}
+		source = agentID
+	} else if err := wisp.ValidateIdentity(source); err != nil {
+		return err
+	}
+	source = wisp.NormalizeIdentity(source)
+	sourceRig, _, ok := strings.Cut(source, "/")
+	if !ok {
+		return fmt.Errorf("%s is a town-level agent with no rig beads; hook its work from the town instead", source)
+	}
+
+	targetRig := args[0]
+	target := wisp.NormalizeIdentity(targetRig + "/" + strings.TrimPrefix(args[1], targetRig+"/"))
+	if targetRig == sourceRig {
+		return fmt.Errorf("%s and %s are both in rig %s (use 'gt sling' to move work within a rig)", source, target, targetRig)
+	}
+
+	_, srcRig, err := getRig(sourceRig)
+	if err != nil {
+		return fmt.Errorf("source: %w", err)
+	}
+	townRoot, dstRig, err := getRig(targetRig)
+	if err != nil {
+		return err
+	}
+
+	issue, err := wisp.AgentHook(srcRig.Path, source)
+	if err != nil {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+	if err := wisp.ValidateHookTarget(issue.ID, target); err != nil {
+		return err
+	}
+	if existing, err := wisp.AgentHook(dstRig.Path, target); err == nil {
+		return fmt.Errorf("%s already has %s on its hook; clear it first", target, existing.ID)
+	} else if !errors.Is(err, wisp.ErrNoHook) {
+		return fmt.Errorf("checking %s's hook: %w", target, err)
+	}
+
+	if globalDryRun {
+		fmt.Printf("Would move %s (%s) from %s to %s and close the original\n", issue.ID, issue.Title, source, target)
+		return nil
+	}
+
+	dst := beads.New(dstRig.Path)
+	moved, err := dst.Create(beads.CreateOptions{
+		Title:       issue.Title,
+		Type:        issue.Type,
+		Priority:    issue.Priority,
+		Description: movedHookDescription(issue, sourceRig),
+		Actor:       source,
+	})
+	if err != nil {
+		return fmt.Errorf("creating %s in rig %s: %w", issue.ID, targetRig, err)
+	}
+	status := beads.StatusHooked
+	if err := dst.Update(moved.ID, beads.UpdateOptions{Status: &status, Assignee: &target}); err != nil {
+		return fmt.Errorf("hooking %s to %s: %w", moved.ID, target, err)
+	}
+
+	reason := fmt.Sprintf("Moved to %s as %s:%s", target, targetRig, moved.ID)
+	if err := beads.New(srcRig.Path).CloseWithReason(reason, issue.ID); err != nil {
+		return fmt.Errorf("%s is hooked to %s, but closing the original %s failed: %w", moved.ID, target, issue.ID, err)
+	}
+	updateAgentHookBead(target, moved.ID, dstRig.Path, filepath.Join(townRoot, ".beads"))
+
+	fmt.Printf("%s Moved %s from %s to %s as %s\n", style.Success.Render("✓"), issue.ID, source, target, moved.ID)
+	return nil
+}
+
+// movedHookDescription is the description for the copy of a hooked bead
+// moved in from rig fromRig: the original's, recording where it came from
+// in meta.moved_from, and without picked_up_at so the copy is waiting.
+func movedHookDescription(issue *beads.Issue, fromRig string) string {
+	fields := beads.ParseAttachmentFields(issue)
+	if fields == nil {
+		fields = &beads.AttachmentFields{}
+	}
+	fields.PickedUpAt = ""
+	if fields.Metadata == nil {
+		fields.Metadata = make(map[string]string)
+	}
+	fields.Metadata["moved_from"] = fromRig + ":" + issue.ID
+	return beads.SetAttachmentFields(issue, fields)
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/wisp"
+)
+
+var hookPinCmd = &cobra.Command{
+	Use:   "pin [agent]",
+	Short: "Protect an agent's hook from stale-hook cleanup",
+	Long: `Pin the work on an agent's hook so it is never auto-unhooked.
+
+The deacon unhooks beads that have sat on a dead agent's hook for too long
+('gt deacon stale-hooks'). Pin hooks that are deliberately long-lived, such
+as handoffs waiting on an external dependency; routine hooks stay subject
+to cleanup. Pinned hooks show "(pinned)" in 'gt hook show'.
+
+With no argument, pins your own hook. Use 'gt hook unpin' to restore the
+normal lifecycle.
+
+Examples:
+  gt hook pin                        # Pin my hook
+  gt hook pin gastown/crew/max       # Pin max's hook`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runHookPin(args, true)
+	},
+}
+
+var hookUnpinCmd = &cobra.Command{
+	Use:   "unpin [agent]",
+	Short: "Return a pinned hook to normal stale-hook cleanup",
+	Long: `Unpin the work on an agent's hook (see 'gt hook pin').
+
+With no argument, unpins your own hook.
+
+Examples:
+  gt hook unpin
+  gt hook unpin gastown/crew/max`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runHookPin(args, false)
+	},
+}
+
+func init() {
+	hookPinCmd.Flags().StringVar(&hookBeadsDir, "beads-dir", "", "Use this .beads directory instead of the discovered one")
+	hookUnpinCmd.Flags().StringVar(&hookBeadsDir, "beads-dir", "", "Use this .beads directory instead of the discovered one")
+	hookCmd.AddCommand(hookPinCmd)
+	hookCmd.AddCommand(hookUnpinCmd)
+}
+
+// runHookPin pins or unpins the bead on the target agent's hook.
+func runHookPin(args []string, pin bool) error {
+	var target string
+	if len(args) > 0 {
+		if err := wisp.ValidateIdentity(args[0]); err != nil {
+			return err
+		}
+		target = wisp.NormalizeIdentity(args[0])
+	} else {
+		agentID, _, _, err := resolveSelfTarget()
+		if err != nil {
+			return fmt.Errorf("auto-detecting agent (use explicit argument): %w", err)
+		}
+		target = agentID
+	}
+
+	hooked, b, err := agentHookedBeads(target)
+	if err != nil {
+		return err
+	}
+	if len(hooked) == 0 {
+		return fmt.Errorf("%s: %w", target, wisp.ErrNoHook)
+	}
+	bead := hooked[0]
+
+	verb, state := "pin", "pinned"
+	if !pin {
+		verb, state = "unpin", "unpinned"
+	}
+	if wisp.HookPinned(bead) == pin {
+		fmt.Printf("%s %s on %s's hook is already %s\n", style.Dim.Render("○"), bead.ID, target, state)
+		return nil
+	}
+	if globalDryRun {
+		fmt.Printf("Would %s %s on %s's hook\n", verb, bead.ID, target)
+		return nil
+	}
+	if err := wisp.SetHookPinned(b, bead, pin); err != nil {
+		return err
+	}
+	fmt.Printf("%s %s is %s on %s's hook\n", style.Bold.Render("✓"), bead.ID, state, target)
+	return nil
+}
@@ -0,0 +1,296 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var hookStatsJSON bool
+
+var hookStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarize hook activity across the town",
+	Long: `Summarize pending hooks and hook activity for throughput visibility.
+
+Pending hooks are beads with status=hooked in the town and every routed
+rig database. For each rig (taken from the assignee's identity; town-level
+agents count as "town") the summary shows how many hooks are waiting and
+their average and oldest age, measured from the bead's last update. It
+also lists the agents with the largest pending queues, who created the
+pending work (the bead's created_by), and event counts (sling, hook,
+unhook, handoff, done) from the activity log (~/gt/.events.jsonl).
+
+Examples:
+  gt hook stats          # Summary tables
+  gt hook stats --json   # Machine-readable`,
+	Args: cobra.NoArgs,
+	RunE: runHookStats,
+}
+
+func init() {
+	hookStatsCmd.Flags().BoolVar(&hookStatsJSON, "json", false, "Output as JSON")
+	hookCmd.AddCommand(hookStatsCmd)
+}
+
+// hookStatsEventTypes are the activity log events counted by gt hook stats.
+var hookStatsEventTypes = []string{events.TypeSling, events.TypeHook, events.TypeUnhook, events.TypeHandoff, events.TypeDone}
+
+// hookStatsTop is how many agents and creators the text output lists.
+const hookStatsTop = 5
+
+// HookStats is the summary printed by gt hook stats.
+type HookStats struct {
+	Pending   int            `json:"pending"`
+	Rigs      []HookRigStats `json:"rigs"`
+	Assignees []HookCount    `json:"assignees"` // pending hooks per agent, largest first
+	Creators  []HookCount    `json:"creators"`  // pending hooks per created_by, largest first
+	History   map[string]int `json:"history"`   // activity log event counts by type
+}
+
+// HookRigStats summarizes the pending hooks of one rig.
+type HookRigStats struct {
+	Rig           string `json:"rig"`
+	Pending       int    `json:"pending"`
+	AvgAgeSeconds int64  `json:"avg_age_seconds"`
+	MaxAgeSeconds int64  `json:"max_age_seconds"`
+}
+
+// HookCount is a name with a count of pending hooks.
+type HookCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// hookStatsRig returns the rig an assignee belongs to, or "town" for
+// town-level agents (mayor, deacon, dogs).
+func hookStatsRig(assignee string) string {
+	first, _, ok := strings.Cut(strings.Trim(assignee, "/"), "/")
+	if !ok || first == "mayor" || first == "deacon" {
+		return "town"
+	}
+	return first
+}
+
+// computeHookStats aggregates pending hooked beads and activity log events.
+func computeHookStats(hooked []*beads.Issue, evs []events.Event, now time.Time) HookStats {
+	stats := HookStats{Pending: len(hooked), History: make(map[string]int)}
+
+	type rigAcc struct {
+		count  int
+		total  time.Duration
+		maxAge time.Duration
+		aged   int
+	}
+	rigs := make(map[string]*rigAcc)
+	assignees := make(map[string]int)
+	creators := make(map[string]int)
+
+	for _, issue := range hooked {
+		rig := hookStatsRig(issue.Assignee)
+		acc := rigs[rig]
+		if acc == nil {
+			acc = &rigAcc{}
+			rigs[rig] = acc
+		}
+		acc.count++
+		if age, ok := hookAge(issue, now); ok {
+			acc.total += age
+			acc.maxAge = max(acc.maxAge, age)
+			acc.aged++
+		}
+
+		assignees[orUnknown(issue.Assignee)]++
+		creators[orUnknown(issue.CreatedBy)]++
+	}
+
+	for rig, acc := range rigs {
+		rs := HookRigStats{Rig: rig, Pending: acc.count, MaxAgeSeconds: int64(acc.maxAge.Seconds())}
+		if acc.aged > 0 {
+			rs.AvgAgeSeconds = int64((acc.total / time.Duration(acc.aged)).Seconds())
+		}
+		stats.Rigs = append(stats.Rigs, rs)
+	}
+	sort.Slice(stats.Rigs, func(i, j int) bool {
+		if stats.Rigs[i].Pending != stats.Rigs[j].Pending {
+			return stats.Rigs[i].Pending > stats.Rigs[j].Pending
+		}
+		return stats.Rigs[i].Rig < stats.Rigs[j].Rig
+	})
+	stats.Assignees = sortedHookCounts(assignees)
+	stats.Creators = sortedHookCounts(creators)
+
+	for _, typ := range hookStatsEventTypes {
+		stats.History[typ] = 0
+	}
+	for _, e := range evs {
+		if _, ok := stats.History[e.Type]; ok {
+			stats.History[e.Type]++
+		}
+	}
+	return stats
+}
+
+// hookAge is how long a hooked bead has waited, from its last update
+// (falling back to creation).
+func hookAge(issue *beads.Issue, now time.Time) (time.Duration, bool) {
+	for _, ts := range []string{issue.UpdatedAt, issue.CreatedAt} {
+		if t, err := time.Parse(time.RFC3339, ts); err == nil {
+			return max(now.Sub(t), 0), true
+		}
+	}
+	return 0, false
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "(unknown)"
+	}
+	return s
+}
+
+func sortedHookCounts(m map[string]int) []HookCount {
+	counts := make([]HookCount, 0, len(m))
+	for name, n := range m {
+		counts = append(counts, HookCount{Name: name, Count: n})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Name < counts[j].Name
+	})
+	return counts
+}
+
+// collectHookedBeads lists hooked beads from the town database and every
+// routed rig database, skipping databases that can't be read.
+func collectHookedBeads(townRoot string) []*beads.Issue {
+	townBeadsDir := filepath.Join(townRoot, ".beads")
+	dirs := []string{townBeadsDir}
+	if routes, err := beads.LoadRoutes(townBeadsDir); err == nil {
+		for _, route := range routes {
+			dirs = append(dirs, filepath.Join(townRoot, route.Path))
+		}
+	}
+
+	seenDirs := make(map[string]bool)
+	seenIDs := make(map[string]bool)
+	var hooked []*beads.Issue
+	for _, dir := range dirs {
+		dir = filepath.Clean(dir)
+		if seenDirs[dir] {
+			continue
+		}
+		seenDirs[dir] = true
+		if _, err := os.Stat(dir); err != nil {
+			continue
+		}
+
+		issues, err := beads.New(dir).List(beads.ListOptions{
+			Status:   beads.StatusHooked,
+			Priority: -1,
+		})
+		if err != nil {
+			continue
+		}
+		for _, issue := range issues {
+			if !seenIDs[issue.ID] {
+				seenIDs[issue.ID] = true
+				hooked = append(hooked, issue)
+			}
+		}
+	}
+	return hooked
+}
+
+// readHookEvents reads every event from the activity log; computeHookStats
+// picks out the hook-related ones.
+func readHookEvents(townRoot string) ([]events.Event, error) {
+	file, err := os.Open(filepath.Join(townRoot, events.EventsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil // No events file yet
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	var evs []events.Event
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e events.Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue // Skip malformed lines
+		}
+		evs = append(evs, e)
+	}
+	return evs, scanner.Err()
+}
+
+func runHookStats(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	evs, err := readHookEvents(townRoot)
+	if err != nil {
+		return fmt.Errorf("reading activity log: %w", err)
+	}
+	stats := computeHookStats(collectHookedBeads(townRoot), evs, time.Now())
+
+	if hookStatsJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(stats)
+	}
+	printHookStats(stats)
+	return nil
+}
+
+// printHookStats renders HookStats as summary tables.
+func printHookStats(stats HookStats) {
+	fmt.Printf("%s Hook stats: %d pending\n\n", style.Bold.Render("🪝"), stats.Pending)
+
+	if len(stats.Rigs) > 0 {
+		fmt.Printf("  %-20s %8s %8s %8s\n", "RIG", "PENDING", "AVG AGE", "OLDEST")
+		for _, r := range stats.Rigs {
+			fmt.Printf("  %-20s %8d %8s %8s\n", r.Rig, r.Pending,
+				formatWorkerAge(time.Duration(r.AvgAgeSeconds)*time.Second),
+				formatWorkerAge(time.Duration(r.MaxAgeSeconds)*time.Second))
+		}
+		fmt.Println()
+		printHookCounts("Largest queues", stats.Assignees)
+		printHookCounts("Created by", stats.Creators)
+	}
+
+	fmt.Printf("  %s\n", style.Bold.Render("Activity log"))
+	for _, typ := range hookStatsEventTypes {
+		fmt.Printf("    %-20s %6d\n", typ, stats.History[typ])
+	}
+}
+
+func printHookCounts(title string, counts []HookCount) {
+	fmt.Printf("  %s\n", style.Bold.Render(title))
+	for i, c := range counts {
+		if i == hookStatsTop {
+			fmt.Printf("    %s\n", style.Dim.Render(fmt.Sprintf("... and %d more", len(counts)-hookStatsTop)))
+			break
+		}
+		fmt.Printf("    %-30s %6d\n", c.Name, c.Count)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+func TestHookStatsRig(t *testing.T) {
+	tests := map[string]string{
+		"gastown/crew/max":       "gastown",
+		"gastown/polecats/Toast": "gastown",
+		"beads/witness":          "beads",
+		"mayor":                  "town",
+		"mayor/":                 "town",
+		"deacon/dogs/alpha":      "town",
+		"":                       "town",
+	}
+	for assignee, want := range tests {
+		if got := hookStatsRig(assignee); got != want {
+			t.Errorf("hookStatsRig(%q) = %q, want %q", assignee, got, want)
+		}
+	}
+}
+
+func TestComputeHookStats(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) string { return now.Add(-d).Format(time.RFC3339) }
+
+	hooked := []*beads.Issue{
+		{ID: "gt-1", Assignee: "gastown/crew/max", CreatedBy: "mayor", UpdatedAt: ago(time.Hour)},
+		{ID: "gt-2", Assignee: "gastown/crew/max", CreatedBy: "mayor", UpdatedAt: ago(3 * time.Hour)},
+		{ID: "gt-3", Assignee: "gastown/polecats/Toast", CreatedBy: "gastown/witness", UpdatedAt: ago(2 * time.Hour)},
+		{ID: "bd-1", Assignee: "beads/crew/joe", CreatedAt: ago(30 * time.Minute)},
+		{ID: "hq-1", Assignee: "mayor"},
+	}
+	evs := []events.Event{
+		{Type: events.TypeSling}, {Type: events.TypeSling}, {Type: events.TypeDone},
+		{Type: events.TypeMail},
+	}
+
+	stats := computeHookStats(hooked, evs, now)
+
+	if stats.Pending != 5 {
+		t.Errorf("Pending = %d, want 5", stats.Pending)
+	}
+	wantRigs := []HookRigStats{
+		{Rig: "gastown", Pending: 3, AvgAgeSeconds: 7200, MaxAgeSeconds: 10800},
+		{Rig: "beads", Pending: 1, AvgAgeSeconds: 1800, MaxAgeSeconds: 1800},
+		{Rig: "town", Pending: 1},
+	}
+	if len(stats.Rigs) != len(wantRigs) {
+		t.Fatalf("Rigs = %+v, want %+v", stats.Rigs, wantRigs)
+	}
+	for i, want := range wantRigs {
+		if stats.Rigs[i] != want {
+			t.Errorf("Rigs[%d] = %+v, want %+v", i, stats.Rigs[i], want)
+		}
+	}
+
+	if top := stats.Assignees[0]; top != (HookCount{Name: "gastown/crew/max", Count: 2}) {
+		t.Errorf("top assignee = %+v, want gastown/crew/max with 2", top)
+	}
+	if top := stats.Creators[0]; top != (HookCount{Name: "(unknown)", Count: 2}) {
+		t.Errorf("top creator = %+v, want (unknown) with 2", top)
+	}
+	if stats.Creators[1] != (HookCount{Name: "mayor", Count: 2}) {
+		t.Errorf("second creator = %+v, want mayor with 2", stats.Creators[1])
+	}
+
+	if stats.History[events.TypeSling] != 2 || stats.History[events.TypeDone] != 1 || stats.History[events.TypeHandoff] != 0 {
+		t.Errorf("History = %v", stats.History)
+	}
+	if _, ok := stats.History[events.TypeMail]; ok {
+		t.Errorf("History counts unrelated event types: %v", stats.History)
+	}
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestHookBeadsDir(t *testing.T) {
+	defer func() { hookBeadsDir = "" }()
+
+	shared := filepath.Join(t.TempDir(), ".beads")
+	if err := os.MkdirAll(shared, 0755); err != nil {
+		t.Fatal(err)
+	}
+	notDir := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(notDir, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	hookBeadsDir = shared
+	_, workDir, err := hookBeads()
+	if err != nil {
+		t.Fatalf("hookBeads() error: %v", err)
+	}
+	if workDir != filepath.Dir(shared) {
+		t.Errorf("workDir = %q, want %q", workDir, filepath.Dir(shared))
+	}
+	env := hookBeadsEnv()
+	if len(env) == 0 || env[len(env)-1] != "BEADS_DIR="+shared {
+		t.Errorf("hookBeadsEnv() should end with BEADS_DIR=%s", shared)
+	}
+
+	for _, dir := range []string{filepath.Join(t.TempDir(), "missing"), notDir} {
+		hookBeadsDir = dir
+		if _, _, err := hookBeads(); err == nil || !strings.Contains(err.Error(), "--beads-dir") {
+			t.Errorf("hookBeads() with %s: error = %v, want --beads-dir error", dir, err)
+		}
+	}
+
+	hookBeadsDir = ""
+	if env := hookBeadsEnv(); env != nil {
+		t.Errorf("hookBeadsEnv() without --beads-dir = %v, want nil (inherit)", env)
+	}
+}
+
+func TestLimitHookContext(t *testing.T) {
+	tests := []struct {
+		name          string
+		msg           string
+		max           int
+		wantTruncated bool
+	}{
+		{"short", "fix the bug", 64, false},
+		{"exact", strings.Repeat("a", 64), 64, false},
+		{"ascii", strings.Repeat("a", 200), 64, true},
+		{"multibyte", strings.Repeat("é", 100), 64, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, truncated := limitHookContext(tt.msg, tt.max)
+			if truncated != tt.wantTruncated {
+				t.Fatalf("truncated = %v, want %v", truncated, tt.wantTruncated)
+			}
+			if !truncated {
+				if got != tt.msg {
+					t.Errorf("got %q, want unchanged", got)
+				}
+				return
+			}
+			if len(got) > tt.max {
+				t.Errorf("len = %d, want <= %d", len(got), tt.max)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("truncated at a non-rune boundary: %q", got)
+			}
+			kept, _, ok := strings.Cut(got, "… [truncated ")
+			if !ok {
+				t.Fatalf("missing truncation note: %q", got)
+			}
+			if want := fmt.Sprintf("… [truncated %d bytes]", len(tt.msg)-len(kept)); !strings.HasSuffix(got, want) {
+				t.Errorf("note = %q, want suffix %q", got, want)
+			}
+		})
+	}
+}
+
+func TestWaitForHook(t *testing.T) {
+	dir := t.TempDir()
+
+	// A change to the beads directory triggers a check well before the
+	// interval.
+	var calls int
+	var hooked atomic.Bool
+	find := func() (*beads.Issue, error) {
+		calls++
+		if hooked.Load() {
+			return &beads.Issue{ID: "gt-abc"}, nil
+		}
+		return nil, nil
+	}
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		hooked.Store(true)
+		_ = os.WriteFile(filepath.Join(dir, "issues.jsonl"), []byte("{}\n"), 0644)
+	}()
+	start := time.Now()
+	bead, err := waitForHook(find, []string{dir}, 10*time.Second, time.Hour)
+	if err != nil || bead == nil || bead.ID != "gt-abc" {
+		t.Fatalf("waitForHook = %v, %v; want gt-abc", bead, err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("waitForHook took %s, want it woken by the file change", elapsed)
+	}
+
+	// Nothing hooked: nil once the timeout passes, polling every interval.
+	calls = 0
+	hooked.Store(false)
+	bead, err = waitForHook(find, []string{dir}, 300*time.Millisecond, 50*time.Millisecond)
+	if err != nil || bead != nil {
+		t.Fatalf("waitForHook = %v, %v; want nil after timeout", bead, err)
+	}
+	if calls < 3 {
+		t.Errorf("find called %d times, want polling every interval", calls)
+	}
+}
+
+func TestMovedHookDescription(t *testing.T) {
+	issue := &beads.Issue{
+		ID:          "gt-abc",
+		Description: "dispatched_by: mayor\npicked_up_at: 2026-01-02T03:04:05Z\nmeta.effort: small\n\nFix the widget.",
+	}
+	desc := movedHookDescription(issue, "gastown")
+
+	fields := beads.ParseAttachmentFields(&beads.Issue{Description: desc})
+	if fields == nil {
+		t.Fatalf("no attachment fields in %q", desc)
+	}
+	if fields.Metadata["moved_from"] != "gastown:gt-abc" {
+		t.Errorf("moved_from = %q, want gastown:gt-abc", fields.Metadata["moved_from"])
+	}
+	if fields.PickedUpAt != "" {
+		t.Errorf("picked_up_at = %q, want it cleared", fields.PickedUpAt)
+	}
+	if fields.DispatchedBy != "mayor" || fields.Metadata["effort"] != "small" {
+		t.Errorf("fields = %+v, want dispatched_by and meta.effort kept", fields)
+	}
+	if !strings.HasSuffix(desc, "Fix the widget.") {
+		t.Errorf("description = %q, want the body kept", desc)
+	}
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/wisp"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// HookWatchTimeoutExit is the exit status of 'gt hook watch' when the
+// timeout passes with nothing hooked (the same status as timeout(1)).
+const HookWatchTimeoutExit = 124
+
+// hookWatchStatInterval is how often 'gt hook watch' checks the beads
+// directories for changes, which is much cheaper than querying bd.
+const hookWatchStatInterval = 500 * time.Millisecond
+
+var (
+	hookWatchTimeout  time.Duration
+	hookWatchInterval time.Duration
+	hookWatchBurn     bool
+)
+
+// hookWatchCmd blocks until work is hooked for an agent
+var hookWatchCmd = &cobra.Command{
+	Use:   "watch [agent]",
+	Short: "Wait until work is on an agent's hook",
+	Long: `Block until a bead is hooked for an agent, then print it.
+
+With no argument, watches your own hook (auto-detected from context). If
+work is already hooked it is printed immediately.
+
+The hook is re-checked whenever the beads database files change, and at
+least every --interval regardless, so changes made through a beads daemon
+or server are picked up too.
+
+With --burn, the bead is taken off the hook once printed: its status moves
+from hooked to in_progress, still assigned to the agent, so the next watch
+waits for new work.
+
+Exit status is 0 when work was found and 124 when --timeout passed first.
+
+Examples:
+  gt hook watch                        # Wait for my next hook
+  gt hook watch --timeout 10m --burn   # Take the next hook, give up after 10m
+  gt hook watch gastown/polecats/nux --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runHookWatch,
+}
+
+func init() {
+	hookWatchCmd.Flags().DurationVar(&hookWatchTimeout, "timeout", 0, "Give up after this long (0 waits forever)")
+	hookWatchCmd.Flags().DurationVar(&hookWatchInterval, "interval", 10*time.Second, "Re-check the hook at least this often")
+	hookWatchCmd.Flags().BoolVar(&hookWatchBurn, "burn", false, "Take the bead off the hook (hooked -> in_progress) once found")
+	hookWatchCmd.Flags().StringVar(&hookBeadsDir, "beads-dir", "", "Use this .beads directory instead of the discovered one")
+	hookWatchCmd.Flags().BoolVar(&moleculeJSON, "json", false, "Output as JSON")
+	hookCmd.AddCommand(hookWatchCmd)
+}
+
+func runHookWatch(cmd *cobra.Command, args []string) error {
+	var target string
+	if len(args) > 0 {
+		if err := wisp.ValidateIdentity(args[0]); err != nil {
+			return err
+		}
+		target = wisp.NormalizeIdentity(args[0])
+	} else {
+		agentID, _, _, err := resolveSelfTarget()
+		if err != nil {
+			return fmt.Errorf("auto-detecting agent (use explicit argument): %w", err)
+		}
+		target = agentID
+	}
+	if hookWatchInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	_, workDir, err := hookBeads()
+	if err != nil {
+		return err
+	}
+	dirs := []string{beads.ResolveBeadsDir(workDir)}
+	if hookBeadsDir == "" {
+		if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
+			dirs = append(dirs, filepath.Join(townRoot, ".beads"))
+		}
+	}
+
+	var db *beads.Beads
+	find := func() (*beads.Issue, error) {
+		hooked, b, err := agentHookedBeads(target)
+		if err != nil || len(hooked) == 0 {
+			return nil, err
+		}
+		db = b
+		return hooked[0], nil
+	}
+	bead, err := waitForHook(find, dirs, hookWatchTimeout, hookWatchInterval)
+	if err != nil {
+		return err
+	}
+	if bead == nil {
+		fmt.Fprintf(os.Stderr, "%s Nothing hooked for %s after %s\n", style.Dim.Render("ℹ"), target, hookWatchTimeout)
+		return NewSilentExit(HookWatchTimeoutExit)
+	}
+
+	if hookWatchBurn {
+		status := "in_progress"
+		if err := db.Update(bead.ID, beads.UpdateOptions{Status: &status}); err != nil {
+			return fmt.Errorf("taking %s off the hook: %w", bead.ID, err)
+		}
+		bead.Status = status
+	}
+
+	if moleculeJSON {
+		info := struct {
+			Agent  string `json:"agent"`
+			BeadID string `json:"bead_id"`
+			Title  string `json:"title"`
+			Status string `json:"status"`
+			Burned bool   `json:"burned,omitempty"`
+		}{target, bead.ID, bead.Title, bead.Status, hookWatchBurn}
+		return json.NewEncoder(os.Stdout).Encode(info)
+	}
+	fmt.Printf("%s: %s '%s' [%s]\n", target, bead.ID, bead.Title, bead.Status)
+	return nil
+}
+
+// waitForHook calls find until it returns a bead, or timeout passes (0
+// waits forever), in which case the bead is nil. find runs first
+// immediately, then whenever a file in dirs changes and at least every
+// interval, as a change need not touch dirs (bd may write through a
+// daemon or server).
+func waitForHook(find func() (*beads.Issue, error), dirs []string, timeout, interval time.Duration) (*beads.Issue, error) {
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+
+	stamp := beadsDirStamp(dirs)
+	lastFind := time.Now()
+	if bead, err := find(); bead != nil || err != nil {
+		return bead, err
+	}
+
+	tick := hookWatchStatInterval
+	if interval < tick {
+		tick = interval
+	}
+	for {
+		if !deadline.IsZero() && !time.Now().Before(deadline) {
+			return nil, nil
+		}
+		time.Sleep(tick)
+
+		current := beadsDirStamp(dirs)
+		if current == stamp && time.Since(lastFind) < interval {
+			continue
+		}
+		stamp, lastFind = current, time.Now()
+		if bead, err := find(); bead != nil || err != nil {
+			return bead, err
+		}
+	}
+}
+
+// beadsDirStamp summarizes the files directly in dirs (names, sizes and
+// modification times) so that any write to a beads database changes it.
+func beadsDirStamp(dirs []string) string {
+	var stamp string
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			info, err := e.Info()
+			if err != nil || info.IsDir() {
+				continue
+			}
+			stamp += fmt.Sprintf("%s/%s:%d:%d;", dir, e.Name(), info.Size(), info.ModTime().UnixNano())
+		}
+	}
+	return stamp
+}
@@ -88,7 +88,7 @@ func runHooksList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	fmt.Printf("\n%s Hook Registry\n", style.Bold.Render("📋"))
+	fmt.Printf("\n📋 %s\n", style.Header.Render("Hook Registry"))
 	fmt.Printf("Source: %s\n\n", style.Dim.Render(filepath.Join(townRoot, "hooks", "registry.toml")))
 
 	// Group by event type
@@ -129,7 +129,7 @@ func runHooksList(cmd *cobra.Command, args []string) error {
 			continue
 		}
 
-		fmt.Printf("%s %s\n", style.Bold.Render("▸"), event)
+		fmt.Printf("%s %s\n", style.Bold.Render("▸"), style.Header.Render(event))
 
 		for _, h := range hooks {
 			count++
@@ -26,7 +26,7 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 		}
 		townRoot, err := workspace.FindFromCwd()
 		if err != nil || townRoot == "" {
-			return fmt.Errorf("not in a Gas Town workspace")
+			return workspace.ErrNotFound
 		}
 		roleInfo, err := GetRoleWithContext(cwd, townRoot)
 		if err != nil {
@@ -121,6 +121,7 @@ type MoleculeStatusInfo struct {
 	AttachedMolecule string                `json:"attached_molecule,omitempty"`
 	AttachedAt       string                `json:"attached_at,omitempty"`
 	AttachedArgs     string                `json:"attached_args,omitempty"`
+	Metadata         map[string]string     `json:"metadata,omitempty"`
 	IsWisp           bool                  `json:"is_wisp"`
 	Progress         *MoleculeProgressInfo `json:"progress,omitempty"`
 	NextAction       string                `json:"next_action,omitempty"`
@@ -315,7 +316,7 @@ func runMoleculeStatus(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("finding workspace: %w", err)
 	}
 	if townRoot == "" {
-		return fmt.Errorf("not in a Gas Town workspace")
+		return workspace.ErrNotFound
 	}
 
 	// Determine target agent
@@ -394,6 +395,7 @@ func runMoleculeStatus(cmd *cobra.Command, args []string) error {
 			status.AttachedMolecule = attachment.AttachedMolecule
 			status.AttachedAt = attachment.AttachedAt
 			status.AttachedArgs = attachment.AttachedArgs
+			status.Metadata = attachment.Metadata
 
 			// Check if it's a wisp
 			status.IsWisp = strings.Contains(hookBead.Description, "wisp: true") ||
@@ -450,6 +452,7 @@ func runMoleculeStatus(cmd *cobra.Command, args []string) error {
 				status.AttachedMolecule = attachment.AttachedMolecule
 				status.AttachedAt = attachment.AttachedAt
 				status.AttachedArgs = attachment.AttachedArgs
+				status.Metadata = attachment.Metadata
 
 				// Check if it's a wisp
 				status.IsWisp = strings.Contains(hookedBeads[0].Description, "wisp: true") ||
@@ -699,6 +702,9 @@ func outputMoleculeStatus(status MoleculeStatusInfo) error {
 		if status.AttachedArgs != "" {
 			fmt.Printf("   %s %s\n", style.Bold.Render("Args:"), status.AttachedArgs)
 		}
+		for _, kv := range formatSlingMeta(status.Metadata) {
+			fmt.Printf("   %s %s\n", style.Bold.Render("Meta:"), kv)
+		}
 	} else {
 		fmt.Printf("%s\n", style.Dim.Render("No molecule attached (hooked bead still triggers autonomous work)"))
 	}
@@ -749,7 +755,7 @@ func runMoleculeCurrent(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("finding workspace: %w", err)
 	}
 	if townRoot == "" {
-		return fmt.Errorf("not in a Gas Town workspace")
+		return workspace.ErrNotFound
 	}
 
 	// Determine target agent identity
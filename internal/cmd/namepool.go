@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -231,35 +232,31 @@ func runNamepoolAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("not in a rig directory")
 	}
 
-	// Load existing rig settings to get current theme and custom names
+	// Append to the custom names in settings/config.json (the source of truth
+	// for config) as a locked read-modify-write
 	settingsPath := filepath.Join(rigPath, "settings", "config.json")
-	settings, err := config.LoadRigSettings(settingsPath)
-	if err != nil {
-		if os.IsNotExist(err) || strings.Contains(err.Error(), "not found") {
-			settings = config.NewRigSettings()
-		} else {
-			return fmt.Errorf("loading settings: %w", err)
+	errAlreadyInPool := errors.New("already in pool")
+	err := config.UpdateRigSettings(settingsPath, func(settings *config.RigSettings) error {
+		// Initialize namepool config if needed
+		if settings.Namepool == nil {
+			settings.Namepool = config.DefaultNamepoolConfig()
 		}
-	}
-
-	// Initialize namepool config if needed
-	if settings.Namepool == nil {
-		settings.Namepool = config.DefaultNamepoolConfig()
-	}
 
-	// Check if name already exists
-	for _, n := range settings.Namepool.Names {
-		if n == name {
-			fmt.Printf("Name '%s' already in pool\n", name)
-			return nil
+		// Check if name already exists
+		for _, n := range settings.Namepool.Names {
+			if n == name {
+				return errAlreadyInPool
+			}
 		}
-	}
-
-	// Append new name to existing custom names
-	settings.Namepool.Names = append(settings.Namepool.Names, name)
 
-	// Save to settings/config.json (the source of truth for config)
-	if err := config.SaveRigSettings(settingsPath, settings); err != nil {
+		settings.Namepool.Names = append(settings.Namepool.Names, name)
+		return nil
+	})
+	if errors.Is(err, errAlreadyInPool) {
+		fmt.Printf("Name '%s' already in pool\n", name)
+		return nil
+	}
+	if err != nil {
 		return fmt.Errorf("saving settings: %w", err)
 	}
 
@@ -325,26 +322,15 @@ func detectCurrentRigWithPath() (string, string) {
 func saveRigNamepoolConfig(rigPath, theme string, customNames []string) error {
 	settingsPath := filepath.Join(rigPath, "settings", "config.json")
 
-	// Load existing settings or create new
-	var settings *config.RigSettings
-	settings, err := config.LoadRigSettings(settingsPath)
-	if err != nil {
-		// Create new settings if not found
-		if os.IsNotExist(err) || strings.Contains(err.Error(), "not found") {
-			settings = config.NewRigSettings()
-		} else {
-			return fmt.Errorf("loading settings: %w", err)
+	// Set namepool as a locked read-modify-write (creates the file if needed)
+	err := config.UpdateRigSettings(settingsPath, func(settings *config.RigSettings) error {
+		settings.Namepool = &config.NamepoolConfig{
+			Style: theme,
+			Names: customNames,
 		}
-	}
-
-	// Set namepool
-	settings.Namepool = &config.NamepoolConfig{
-		Style: theme,
-		Names: customNames,
-	}
-
-	// Save (creates directory if needed)
-	if err := config.SaveRigSettings(settingsPath, settings); err != nil {
+		return nil
+	})
+	if err != nil {
 		return fmt.Errorf("saving settings: %w", err)
 	}
 
@@ -15,6 +15,7 @@ import (
 	"github.com/steveyegge/gastown/internal/lock"
 	"github.com/steveyegge/gastown/internal/state"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/wisp"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -114,7 +115,7 @@ func runPrime(cmd *cobra.Command, args []string) error {
 		if !state.IsEnabled() {
 			return nil // Silent exit - not in workspace and not enabled
 		}
-		return fmt.Errorf("not in a Gas Town workspace")
+		return workspace.ErrNotFound
 	}
 
 	// Handle hook mode: read session ID from stdin and persist it
@@ -401,35 +402,31 @@ func checkSlungWork(ctx RoleContext) bool {
 		return false
 	}
 
-	// Check for hooked beads (work on the agent's hook)
-	b := beads.New(ctx.WorkDir)
-	hookedBeads, err := b.List(beads.ListOptions{
-		Status:   beads.StatusHooked,
-		Assignee: agentID,
-		Priority: -1,
-	})
+	// Check for hooked beads (work on the agent's hook). In-progress work
+	// interrupted before completion also counts - the hook should persist.
+	// Crew with an empty hook claim the next bead slung to their role.
+	hookedBead, err := wisp.PickUpHook(ctx.WorkDir, agentID)
 	if err != nil {
+		// Work that waits on other beads stays hooked until they are done
+		if errors.Is(err, wisp.ErrHookBlocked) || errors.Is(err, wisp.ErrDependencyCycle) {
+			fmt.Printf("%s %v; not starting it yet.\n\n", style.Dim.Render("⏸"), err)
+		}
 		return false
 	}
+	// A malformed hook is still the agent's work; flag it rather than drop it
+	if err := wisp.ValidateHook(hookedBead); err != nil {
+		fmt.Fprintf(os.Stderr, "%s Warning: %v\n", style.Dim.Render("⚠"), err)
+	}
 
-	// If no hooked beads found, also check in_progress beads assigned to this agent.
-	// This handles the case where work was claimed (status changed to in_progress)
-	// but the session was interrupted before completion. The hook should persist.
-	if len(hookedBeads) == 0 {
-		inProgressBeads, err := b.List(beads.ListOptions{
-			Status:   "in_progress",
-			Assignee: agentID,
-			Priority: -1,
-		})
-		if err != nil || len(inProgressBeads) == 0 {
-			return false
+	// Record the pickup, so a session that restarts mid-work is told it is
+	// resuming rather than starting over
+	resumed := wisp.HookInProgress(hookedBead)
+	if !resumed && !primeDryRun {
+		if _, err := wisp.MarkPickedUp(ctx.WorkDir, hookedBead); err != nil {
+			fmt.Fprintf(os.Stderr, "%s Warning: %v\n", style.Dim.Render("⚠"), err)
 		}
-		hookedBeads = inProgressBeads
 	}
 
-	// Use the first hooked bead (agents typically have one)
-	hookedBead := hookedBeads[0]
-
 	// Build the role announcement string
 	roleAnnounce := buildRoleAnnouncement(ctx)
 
@@ -477,6 +474,14 @@ func checkSlungWork(ctx RoleContext) bool {
 	fmt.Printf("%s\n\n", style.Bold.Render("## Hooked Work"))
 	fmt.Printf("  Bead ID: %s\n", style.Bold.Render(hookedBead.ID))
 	fmt.Printf("  Title: %s\n", hookedBead.Title)
+	if resumed {
+		pickedUp := "earlier"
+		if attachment != nil && attachment.PickedUpAt != "" {
+			pickedUp = "at " + attachment.PickedUpAt
+		}
+		fmt.Printf("  %s picked up %s and not finished. Check git status and log for work already done.\n",
+			style.Bold.Render("Resuming:"), pickedUp)
+	}
 	if hookedBead.Description != "" {
 		// Show first few lines of description
 		lines := strings.Split(hookedBead.Description, "\n")
@@ -490,6 +495,20 @@ func checkSlungWork(ctx RoleContext) bool {
 			fmt.Printf("    %s\n", line)
 		}
 	}
+	if attachment != nil && len(attachment.Metadata) > 0 {
+		fmt.Println("  Metadata:")
+		for _, kv := range formatSlingMeta(attachment.Metadata) {
+			fmt.Printf("    %s\n", kv)
+		}
+	}
+	if snap, ok := wisp.HookEnv(hookedBead); ok {
+		if cmds := snap.ShellCommands(ctx.WorkDir); len(cmds) > 0 {
+			fmt.Println("  Resume context (captured when slung; run before continuing):")
+			for _, c := range cmds {
+				fmt.Printf("    %s\n", c)
+			}
+		}
+	}
 	fmt.Println()
 
 	// If molecule attached, show molecule context prominently INSTEAD of bd show
@@ -379,6 +379,13 @@ func outputAttachmentStatus(ctx RoleContext) {
 		fmt.Printf("%s\n", style.Bold.Render("📋 ARGS (use these to guide execution):"))
 		fmt.Printf("  %s\n", attachment.AttachedArgs)
 	}
+	if len(attachment.Metadata) > 0 {
+		fmt.Println()
+		fmt.Printf("%s\n", style.Bold.Render("🏷️  METADATA:"))
+		for _, kv := range formatSlingMeta(attachment.Metadata) {
+			fmt.Printf("  %s\n", kv)
+		}
+	}
 	fmt.Println()
 
 	// Show current step from molecule
@@ -493,7 +493,11 @@ func runRigList(cmd *cobra.Command, args []string) error {
 		}
 
 		summary := r.Summary()
-		fmt.Printf("  %s\n", style.Bold.Render(name))
+		if label := r.DisplayName(); label != name {
+			fmt.Printf("  %s %s\n", style.Bold.Render(label), style.Dim.Render("("+name+")"))
+		} else {
+			fmt.Printf("  %s\n", style.Bold.Render(name))
+		}
 		fmt.Printf("    Polecats: %d  Crew: %d\n", summary.PolecatCount, summary.CrewCount)
 
 		agents := []string{}
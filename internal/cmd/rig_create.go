@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var rigCreateTheme string
+
+var rigCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create an empty rig with default config",
+	Long: `Create a new, empty rig in the current town.
+
+This makes the rig directory with a default config.json and registers the
+rig in mayor/rigs.json. No repository is cloned; use 'gt rig add' to create
+a rig from a git repository instead.
+
+The rig's theme is assigned from its name unless --theme picks one, which
+is saved to settings/config.json. Fails if the rig or its directory
+already exists.
+
+Examples:
+  gt rig create sandbox
+  gt rig create sandbox --theme forest`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRigCreate,
+}
+
+func init() {
+	rigCreateCmd.Flags().StringVar(&rigCreateTheme, "theme", "", "Theme to save for the rig (default: assigned from the rig name)")
+	rigCmd.AddCommand(rigCreateCmd)
+}
+
+func runRigCreate(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	rigsPath := filepath.Join(townRoot, "mayor", "rigs.json")
+	rigsConfig, err := config.LoadRigsConfig(rigsPath)
+	if err != nil {
+		rigsConfig = &config.RigsConfig{
+			Version: 1,
+			Rigs:    make(map[string]config.RigEntry),
+		}
+	}
+	mgr := rig.NewManager(townRoot, rigsConfig, git.NewGit(townRoot))
+	if mgr.RigExists(name) {
+		return fmt.Errorf("rig '%s' already exists", name)
+	}
+
+	// Resolve the theme before anything is written
+	themeName := rigCreateTheme
+	if themeName != "" {
+		if themeName, err = matchThemeName(name, themeName); err != nil {
+			return err
+		}
+		if theme := resolveRigThemeByName(name, themeName); theme != nil {
+			if err := theme.Validate(); err != nil {
+				return fmt.Errorf("cannot use theme '%s': %w", themeName, err)
+			}
+		}
+	}
+
+	if globalDryRun {
+		fmt.Printf("Would create rig %s in %s\n", name, filepath.Join(townRoot, name))
+		if themeName != "" {
+			fmt.Printf("  Theme: %s\n", themeName)
+		}
+		return nil
+	}
+
+	newRig, err := mgr.CreateRig(name)
+	if err != nil {
+		return fmt.Errorf("creating rig: %w", err)
+	}
+	if err := config.SaveRigsConfig(rigsPath, rigsConfig); err != nil {
+		return fmt.Errorf("saving rigs config: %w", err)
+	}
+	if themeName != "" {
+		if err := saveRigTheme(name, themeName); err != nil {
+			return fmt.Errorf("saving theme config: %w", err)
+		}
+	}
+
+	theme := getThemeForRig(name)
+	fmt.Printf("%s Rig %s created\n", style.Success.Render("✓"), style.Bold.Render(name))
+	fmt.Printf("  Path: %s\n", newRig.Path)
+	if newRig.Config != nil {
+		fmt.Printf("  Prefix: %s\n", newRig.Config.Prefix)
+	}
+	if themeName != "" {
+		fmt.Printf("  Theme: %s (%s)\n", theme.Name, theme.Style())
+	} else {
+		fmt.Printf("  Theme: %s (%s, assigned from rig name)\n", theme.Name, theme.Style())
+	}
+
+	return nil
+}
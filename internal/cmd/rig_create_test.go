@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestRigCreate(t *testing.T) {
+	townRoot, _ := setupTestRigForSettings(t)
+
+	rigCreateTheme = "forest"
+	defer func() { rigCreateTheme = "" }()
+
+	var err error
+	out := captureStdout(t, func() {
+		err = runRigCreate(rigCreateCmd, []string{"sandbox"})
+	})
+	if err != nil {
+		t.Fatalf("runRigCreate: %v", err)
+	}
+	if !strings.Contains(out, "Theme: forest") {
+		t.Errorf("output missing theme:\n%s", out)
+	}
+
+	rigsConfig, err := config.LoadRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"))
+	if err != nil {
+		t.Fatalf("LoadRigsConfig: %v", err)
+	}
+	if _, ok := rigsConfig.Rigs["sandbox"]; !ok {
+		t.Error("sandbox not registered in rigs.json")
+	}
+	if _, ok := rigsConfig.Rigs["testrig"]; !ok {
+		t.Error("existing rig testrig lost from rigs.json")
+	}
+	if got := loadRigTheme("sandbox"); got != "forest" {
+		t.Errorf("saved theme = %q, want forest", got)
+	}
+
+	if err := runRigCreate(rigCreateCmd, []string{"testrig"}); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("creating existing rig = %v, want already exists", err)
+	}
+}
+
+func TestRigCreateUnknownTheme(t *testing.T) {
+	townRoot, _ := setupTestRigForSettings(t)
+
+	rigCreateTheme = "no-such-theme"
+	defer func() { rigCreateTheme = "" }()
+
+	if err := runRigCreate(rigCreateCmd, []string{"sandbox"}); err == nil {
+		t.Fatal("runRigCreate with unknown theme succeeded, want error")
+	}
+	if _, err := config.LoadRigConfig(filepath.Join(townRoot, "sandbox", "config.json")); err == nil {
+		t.Error("rig was created despite the unknown theme")
+	}
+}
@@ -132,27 +132,20 @@ func runRigSettingsSet(cmd *cobra.Command, args []string) error {
 
 	settingsPath := filepath.Join(r.Path, "settings", "config.json")
 
-	// Load existing settings or create new
-	settings, err := config.LoadRigSettings(settingsPath)
-	if err != nil {
-		if errors.Is(err, config.ErrNotFound) {
-			// Create new settings with scaffold
-			settings = config.NewRigSettings()
-		} else {
-			return fmt.Errorf("loading settings: %w", err)
-		}
-	}
-
 	// Parse the value
 	value := parseValue(valueStr)
 
-	// Set the value using dot notation
-	if err := setNestedValue(settings, keyPath, value); err != nil {
-		return fmt.Errorf("setting %s: %w", keyPath, err)
+	// Set the value using dot notation as a locked read-modify-write
+	// (a missing file starts from the settings scaffold)
+	var setErr error
+	err = config.UpdateRigSettings(settingsPath, func(settings *config.RigSettings) error {
+		setErr = setNestedValue(settings, keyPath, value)
+		return setErr
+	})
+	if setErr != nil {
+		return fmt.Errorf("setting %s: %w", keyPath, setErr)
 	}
-
-	// Save the settings
-	if err := config.SaveRigSettings(settingsPath, settings); err != nil {
+	if err != nil {
 		return fmt.Errorf("saving settings: %w", err)
 	}
 
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var rigThemeCmd = &cobra.Command{
+	Use:   "theme <rig> [name]",
+	Short: "View or set the tmux theme for a rig",
+	Long: `View or set the tmux theme for an explicitly named rig.
+
+This is the same as 'gt theme [name] --rig <rig>', but the rig is a
+required positional argument so scripts can never theme the wrong rig
+through ambient cwd or GT_RIG detection.
+
+Examples:
+  gt rig theme gastown          # Show gastown's theme
+  gt rig theme gastown forest   # Set gastown's theme to 'forest'
+  gt rig theme gastown forest --apply  # Set and apply to running sessions`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runRigTheme,
+}
+
+func init() {
+	rigThemeCmd.Flags().BoolVar(&themeSetApplyFlag, "apply", false, "Also apply the new theme to the rig's running sessions")
+	rigCmd.AddCommand(rigThemeCmd)
+}
+
+func runRigTheme(cmd *cobra.Command, args []string) error {
+	rigName := args[0]
+
+	if _, _, err := getRig(rigName); err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		showRigTheme(rigName)
+		return nil
+	}
+
+	return setRigTheme(rigName, args[1])
+}
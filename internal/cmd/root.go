@@ -16,6 +16,17 @@ import (
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
+// globalDryRun is the root-level --dry-run flag. Commands that define their
+// own local --dry-run (hook, sling, crew stop, ...) shadow it, so for those
+// the local flag is authoritative and behaves exactly as before. Commands
+// without one (e.g. theme set/apply) consult globalDryRun directly.
+var globalDryRun bool
+
+// globalJSONErrors is the root-level --json-errors flag: failures are
+// reported on stderr as {"error": ..., "code": ...} with a per-code exit
+// status (see ErrorCode) instead of cobra's "Error: ..." line.
+var globalJSONErrors bool
+
 var rootCmd = &cobra.Command{
 	Use:     "gt", // Updated in init() based on GT_COMMAND
 	Short:   "Gas Town - Multi-agent workspace manager",
@@ -62,6 +73,7 @@ var beadsExemptCommands = map[string]bool{
 	"tap":        true,
 	"dnd":        true,
 	"krc":        true, // KRC doesn't require beads
+	"validate":   true, // gt theme validate runs outside a town (CI)
 }
 
 // Commands exempt from the town root branch warning.
@@ -88,8 +100,15 @@ func persistentPreRun(cmd *cobra.Command, args []string) error {
 		os.Exit(1)
 	}
 
+	// With --json-errors, Execute reports the failure instead of cobra
+	if globalJSONErrors {
+		cmd.Root().SilenceErrors = true
+		cmd.SilenceUsage = true
+	}
+
 	// Initialize CLI theme (dark/light mode support)
 	initCLITheme()
+	initThemeSeed()
 
 	// Get the root command name being run
 	cmdName := cmd.Name()
@@ -234,6 +253,9 @@ func Execute() int {
 		if code, ok := IsSilentExit(err); ok {
 			return code
 		}
+		if globalJSONErrors {
+			return writeJSONError(os.Stderr, err)
+		}
 		// Other errors already printed by cobra
 		return 1
 	}
@@ -272,6 +294,10 @@ func init() {
 
 	// Global flags can be added here
 	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file")
+	rootCmd.PersistentFlags().BoolVar(&globalDryRun, "dry-run", false,
+		"Show what mutating commands would change without changing it")
+	rootCmd.PersistentFlags().BoolVar(&globalJSONErrors, "json-errors", false,
+		"Report failures on stderr as JSON with a stable code and exit status")
 }
 
 // buildCommandPath walks the command hierarchy to build the full command path.
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/wisp"
+)
+
+var sessionInfoJSON bool
+
+var sessionInfoCmd = &cobra.Command{
+	Use:   "info <worker>",
+	Short: "Show a worker's session, theme and hook together",
+	Long: `Show the full state of one worker: its tmux session (running and
+attached), the session's current theme, and the work on its hook.
+
+The worker is a crew member or polecat in the current rig, or <rig>/<worker>
+for another rig. Crew is assumed when <rig>/crew/<worker> exists, otherwise
+the worker is treated as a polecat.
+
+The command also works when the session isn't running (only the hook is
+shown) or when nothing is hooked (only the session state is shown).
+
+Examples:
+  gt session info Toast
+  gt session info gastown/max --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSessionInfo,
+}
+
+func init() {
+	sessionInfoCmd.Flags().BoolVar(&sessionInfoJSON, "json", false, "Output as JSON")
+	sessionCmd.AddCommand(sessionInfoCmd)
+}
+
+// SessionInfoReport is the combined session, theme and hook state of a worker.
+type SessionInfoReport struct {
+	Rig      string `json:"rig"`
+	Worker   string `json:"worker"`
+	Role     string `json:"role"` // "crew" or "polecat"
+	Identity string `json:"identity"`
+	Session  string `json:"session"`
+	Running  bool   `json:"running"`
+	Attached bool   `json:"attached"`
+
+	// Theme is the session's current status bar colors (running sessions only).
+	Theme *SessionInfoTheme `json:"theme,omitempty"`
+
+	// Hook is the work on the worker's hook, if any.
+	Hook      *SessionInfoHook `json:"hook,omitempty"`
+	HookError string           `json:"hook_error,omitempty"`
+}
+
+// SessionInfoTheme describes a session's current and expected theme.
+type SessionInfoTheme struct {
+	Style    string `json:"style"`    // current status-style, or "tmux default" if unset
+	Expected string `json:"expected"` // theme gt theme apply would set
+	Applied  bool   `json:"applied"`  // current colors match the expected theme
+}
+
+// SessionInfoHook describes the bead on a worker's hook.
+type SessionInfoHook struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+}
+
+// sessionWorker classifies a worker in a rig as crew or polecat and returns
+// its session name and hook identity.
+func sessionWorker(rigPath, rigName, name string) (role, sessionName, identity string) {
+	if info, err := os.Stat(filepath.Join(rigPath, "crew", name)); err == nil && info.IsDir() {
+		return "crew", session.CrewSessionName(rigName, name), rigName + "/crew/" + name
+	}
+	return "polecat", session.PolecatSessionName(rigName, name), rigName + "/polecats/" + name
+}
+
+func runSessionInfo(cmd *cobra.Command, args []string) error {
+	rigName, worker, err := parseAddress(args[0])
+	if err != nil {
+		return err
+	}
+	_, r, err := getRig(rigName)
+	if err != nil {
+		return err
+	}
+
+	role, sessionName, identity := sessionWorker(r.Path, rigName, worker)
+	report := SessionInfoReport{
+		Rig:      rigName,
+		Worker:   worker,
+		Role:     role,
+		Identity: identity,
+		Session:  sessionName,
+	}
+
+	t := tmux.NewTmux()
+	if info, err := t.GetSessionInfo(sessionName); err == nil {
+		report.Running = true
+		report.Attached = info.Attached
+
+		if current, err := t.CurrentTheme(sessionName); err == nil {
+			expected := getThemeForWorker(rigName, worker, role)
+			currentStyle := current.Style()
+			if current.BG == "" && current.FG == "" {
+				currentStyle = "tmux default"
+			}
+			report.Theme = &SessionInfoTheme{
+				Style:    currentStyle,
+				Expected: expected.Name,
+				Applied:  current.Equal(expected),
+			}
+		}
+	}
+
+	hook, err := wisp.AgentHook(r.Path, identity)
+	switch {
+	case err == nil:
+		report.Hook = &SessionInfoHook{ID: hook.ID, Title: hook.Title, Status: hook.Status}
+	case !errors.Is(err, wisp.ErrNoHook):
+		report.HookError = err.Error()
+	}
+
+	if sessionInfoJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	printSessionInfo(report)
+	return nil
+}
+
+// printSessionInfo renders a SessionInfoReport for humans.
+func printSessionInfo(report SessionInfoReport) {
+	fmt.Printf("%s %s (%s)\n\n", style.Bold.Render("📺"), report.Identity, report.Role)
+	fmt.Printf("  Session: %s\n", report.Session)
+
+	if !report.Running {
+		fmt.Printf("  State:   %s\n", style.Dim.Render("○ not running"))
+	} else {
+		attached := "detached"
+		if report.Attached {
+			attached = "attached"
+		}
+		fmt.Printf("  State:   %s (%s)\n", style.Bold.Render("● running"), attached)
+
+		if report.Theme != nil {
+			note := "matches " + report.Theme.Expected
+			if !report.Theme.Applied {
+				note = "expected " + report.Theme.Expected + "; run 'gt theme apply'"
+			}
+			fmt.Printf("  Theme:   %s %s\n", report.Theme.Style, style.Dim.Render("("+note+")"))
+		}
+	}
+
+	switch {
+	case report.Hook != nil:
+		fmt.Printf("  Hook:    %s '%s' [%s]\n", report.Hook.ID, report.Hook.Title, report.Hook.Status)
+	case report.HookError != "":
+		fmt.Printf("  Hook:    %s\n", style.Dim.Render("(unavailable: "+report.HookError+")"))
+	default:
+		fmt.Printf("  Hook:    %s\n", style.Dim.Render("(empty)"))
+	}
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSessionWorker(t *testing.T) {
+	rigPath := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(rigPath, "crew", "max"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                          string
+		wantRole, wantSess, wantIdent string
+	}{
+		{"max", "crew", "gt-gastown-crew-max", "gastown/crew/max"},
+		{"Toast", "polecat", "gt-gastown-Toast", "gastown/polecats/Toast"},
+	}
+	for _, tt := range tests {
+		role, sess, ident := sessionWorker(rigPath, "gastown", tt.name)
+		if role != tt.wantRole || sess != tt.wantSess || ident != tt.wantIdent {
+			t.Errorf("sessionWorker(%q) = %q, %q, %q; want %q, %q, %q",
+				tt.name, role, sess, ident, tt.wantRole, tt.wantSess, tt.wantIdent)
+		}
+	}
+}
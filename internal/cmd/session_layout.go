@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+var sessionLayoutFlag string
+
+var sessionLayoutCmd = &cobra.Command{
+	Use:   "layout [worker]",
+	Short: "Arrange a session's panes with the rig's preferred layout",
+	Long: `Apply the rig's preferred tmux window layout to a worker's session.
+
+The layout is theme.layout in the rig's settings/config.json, for example:
+
+  "theme": {"name": "forest", "layout": "main-vertical"}
+
+It is either a tmux preset (even-horizontal, even-vertical, main-horizontal,
+main-vertical, tiled) or a custom layout string as printed by
+'tmux list-windows -F "#{window_layout}"', which only applies to a window
+with the same number of panes. Use --layout to apply a different one.
+
+The worker is a crew member or polecat in the current rig, or <rig>/<worker>
+for another rig. With no argument, the current tmux session is arranged.
+
+Examples:
+  gt session layout Toast
+  gt session layout gastown/max --layout tiled
+  gt session layout                # the session you're in`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSessionLayout,
+}
+
+func init() {
+	sessionLayoutCmd.Flags().StringVar(&sessionLayoutFlag, "layout", "", "Layout to apply instead of the rig's theme.layout")
+	sessionCmd.AddCommand(sessionLayoutCmd)
+}
+
+func runSessionLayout(cmd *cobra.Command, args []string) error {
+	var rigName, sessionName string
+	if len(args) > 0 {
+		var worker string
+		var err error
+		if rigName, worker, err = parseAddress(args[0]); err != nil {
+			return err
+		}
+		_, r, err := getRig(rigName)
+		if err != nil {
+			return err
+		}
+		_, sessionName, _ = sessionWorker(r.Path, rigName, worker)
+	} else {
+		sess, err := getCurrentTmuxSession()
+		if err != nil {
+			return fmt.Errorf("not in a tmux session (pass a worker): %w", err)
+		}
+		sessionName = sess
+		rigName, _, _, _ = parseThemeSession(sess)
+	}
+
+	layout := sessionLayoutFlag
+	if layout == "" {
+		if tc := loadRigThemeConfig(rigName); tc != nil {
+			layout = tc.Layout
+		}
+	}
+	if layout == "" {
+		if rigName == "" {
+			return fmt.Errorf("session %s isn't in a rig; pass --layout", sessionName)
+		}
+		return fmt.Errorf("rig '%s' has no theme.layout in settings/config.json; pass --layout", rigName)
+	}
+	if err := config.ValidateLayout(layout); err != nil {
+		return err
+	}
+
+	t := tmux.NewTmux()
+	running, err := t.HasSession(sessionName)
+	if err != nil {
+		return fmt.Errorf("checking session: %w", err)
+	}
+	if !running {
+		return fmt.Errorf("session %s is not running", sessionName)
+	}
+
+	if globalDryRun {
+		fmt.Printf("Would apply layout %s to %s\n", layout, sessionName)
+		return nil
+	}
+	if err := t.SelectLayout(sessionName, layout); err != nil {
+		return fmt.Errorf("applying layout to %s: %w", sessionName, err)
+	}
+	fmt.Printf("%s Applied layout %s to %s\n", style.Success.Render("✓"), layout, sessionName)
+	return nil
+}
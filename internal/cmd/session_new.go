@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+var sessionNewRole string
+
+var sessionNewCmd = &cobra.Command{
+	Use:   "new <worker>",
+	Short: "Create a named, themed session for a worker",
+	Long: `Create a tmux session for a worker in one step.
+
+The session is named by the Gas Town convention (gt-<rig>-<worker> for
+polecats, gt-<rig>-crew-<name> for crew), starts a shell in the worker's
+directory (the rig root if the worker has none yet), has GT_RIG and
+GT_WORKER plus the usual agent environment set, and gets the rig's theme
+for the role, exactly as 'gt theme apply' would set it.
+
+The worker is a name in the current rig, or <rig>/<worker> for another rig.
+Without --role, crew is assumed when <rig>/crew/<worker> exists, otherwise
+polecat. No agent is launched ('gt session start' does that for polecats).
+
+The command refuses to create a session whose name is already taken, and
+polecat names that would produce another role's session name (witness,
+refinery, crew-*).
+
+Examples:
+  gt session new Toast
+  gt session new gastown/max --role crew`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSessionNew,
+}
+
+func init() {
+	sessionNewCmd.Flags().StringVar(&sessionNewRole, "role", "", "Worker role: crew or polecat (default: detect)")
+	sessionCmd.AddCommand(sessionNewCmd)
+}
+
+// sessionNewName returns the session name for a new worker session, or an
+// error if the role is unknown or the name would impersonate another role's
+// session (gt-<rig>-witness, gt-<rig>-refinery, gt-<rig>-crew-<name>).
+func sessionNewName(rigName, worker, role string) (string, error) {
+	if strings.ContainsAny(worker, "/:. ") {
+		return "", fmt.Errorf("invalid worker name %q", worker)
+	}
+	switch role {
+	case "crew":
+		return session.CrewSessionName(rigName, worker), nil
+	case "polecat":
+		if worker == "witness" || worker == "refinery" || strings.HasPrefix(worker, "crew-") {
+			return "", fmt.Errorf("polecat name %q is reserved: its session name belongs to another role", worker)
+		}
+		return session.PolecatSessionName(rigName, worker), nil
+	}
+	return "", fmt.Errorf("invalid role %q (want crew or polecat)", role)
+}
+
+// sessionNewWorkDir is the worker's directory, or the rig root if it
+// doesn't exist yet.
+func sessionNewWorkDir(rigPath, worker, role string) string {
+	dir := filepath.Join(rigPath, "polecats", worker)
+	if role == "crew" {
+		dir = filepath.Join(rigPath, "crew", worker)
+	}
+	if info, err := os.Stat(dir); err == nil && info.IsDir() {
+		return dir
+	}
+	return rigPath
+}
+
+func runSessionNew(cmd *cobra.Command, args []string) error {
+	rigName, worker, err := parseAddress(args[0])
+	if err != nil {
+		return err
+	}
+	townRoot, r, err := getRig(rigName)
+	if err != nil {
+		return err
+	}
+
+	role := sessionNewRole
+	if role == "" {
+		role, _, _ = sessionWorker(r.Path, rigName, worker)
+	}
+	sessionName, err := sessionNewName(rigName, worker, role)
+	if err != nil {
+		return err
+	}
+
+	t := themeTmux()
+	exists, err := t.HasSession(sessionName)
+	if err != nil {
+		return fmt.Errorf("checking session: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("session %s already exists (attach with: tmux attach -t %s)", sessionName, sessionName)
+	}
+
+	workDir := sessionNewWorkDir(r.Path, worker, role)
+	theme := getThemeForWorker(rigName, worker, role).ForCaps(tmux.DetectColorCaps())
+	if globalDryRun {
+		fmt.Printf("Would create %s in %s with %s theme\n", sessionName, workDir, theme.Name)
+		return nil
+	}
+
+	// The shell gets the environment from its startup command; setting it on
+	// the session as well covers panes and windows opened later.
+	env := config.AgentEnv(config.AgentEnvConfig{
+		Role:      role,
+		Rig:       rigName,
+		AgentName: worker,
+		TownRoot:  townRoot,
+	})
+	env["GT_RIG"] = rigName
+	env["GT_WORKER"] = worker
+	shellCmd := config.BuildStartupCommandWithEnv(env, `exec "${SHELL:-sh}"`, "")
+	if err := t.NewSessionWithCommand(sessionName, workDir, shellCmd); err != nil {
+		return fmt.Errorf("creating session: %w", err)
+	}
+	for k, v := range env {
+		_ = t.SetEnvironment(sessionName, k, v) // non-fatal: the shell already has it
+	}
+
+	// Theming failure is a warning: the session itself is usable
+	if err := applyThemeToSession(t, sessionName, rigName, worker, role, theme); err != nil {
+		fmt.Fprintf(os.Stderr, "%s Warning: theme %s %v\n", style.Dim.Render("⚠"), theme.Name, err)
+	}
+
+	fmt.Printf("%s Created %s (%s theme). Attach with: %s\n",
+		style.Bold.Render("✓"), sessionName, theme.Name,
+		style.Dim.Render("tmux attach -t "+sessionName))
+	return nil
+}
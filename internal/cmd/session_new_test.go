@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSessionNewName(t *testing.T) {
+	tests := []struct {
+		worker, role string
+		want         string
+		wantErr      bool
+	}{
+		{"Toast", "polecat", "gt-gastown-Toast", false},
+		{"max", "crew", "gt-gastown-crew-max", false},
+		{"witness", "polecat", "", true},
+		{"refinery", "polecat", "", true},
+		{"crew-max", "polecat", "", true},
+		{"witness", "crew", "gt-gastown-crew-witness", false},
+		{"a.b", "polecat", "", true},
+		{"Toast", "mayor", "", true},
+	}
+	for _, tt := range tests {
+		got, err := sessionNewName("gastown", tt.worker, tt.role)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("sessionNewName(%q, %q) = %q, %v; want %q (err=%v)", tt.worker, tt.role, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestSessionNewWorkDir(t *testing.T) {
+	rigPath := t.TempDir()
+	crewDir := filepath.Join(rigPath, "crew", "max")
+	if err := os.MkdirAll(crewDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := sessionNewWorkDir(rigPath, "max", "crew"); got != crewDir {
+		t.Errorf("crew workdir = %q, want %q", got, crewDir)
+	}
+	if got := sessionNewWorkDir(rigPath, "Toast", "polecat"); got != rigPath {
+		t.Errorf("missing polecat workdir = %q, want rig root %q", got, rigPath)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/wisp"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -40,6 +41,7 @@ Auto-Convoy:
 Target Resolution:
   gt sling gt-abc                       # Self (current agent)
   gt sling gt-abc crew                  # Crew worker in current rig
+  gt sling gt-abc gastown/crew          # First available crew member in rig
   gt sling gp-abc greenplace               # Auto-spawn polecat in rig
   gt sling gt-abc greenplace/Toast         # Specific polecat
   gt sling gt-abc mayor                 # Mayor
@@ -51,6 +53,10 @@ Spawning Options (when target is a rig):
   gt sling gp-abc greenplace --force                # Ignore unread mail
   gt sling gp-abc greenplace --account work         # Use specific Claude account
 
+Context From a Bead:
+  gt sling gt-abc crew --from gt-abc    # Subject/context from gt-abc's title/description
+  gt sling gt-abc crew --from gt-epic   # ...or from a related bead (e.g. the epic)
+
 Natural Language Args:
   gt sling gt-abc --args "patch release"
   gt sling code-review --args "focus on security"
@@ -58,6 +64,30 @@ Natural Language Args:
 The --args string is stored in the bead and shown via gt prime. Since the
 executor is an LLM, it interprets these instructions naturally.
 
+Metadata:
+  gt sling gt-abc gastown --meta priority=high --meta effort=2h
+
+Each --meta key=value pair is stored in the bead and shown to the agent via
+gt prime and gt hook status. Keys must be non-empty and unique.
+
+Ordering:
+  gt sling gt-b crew --after gt-a       # gt-b waits until gt-a is done
+
+--after (repeatable, or comma-separated) records beads that must be closed
+before this one is started. The bead is hooked right away, but the agent
+doesn't pick it up until its dependencies are done; on a role hook, ready
+beads are claimed first. Dependencies that would form a cycle are refused.
+
+Resume Context:
+  gt sling gt-abc --capture-env         # Record cwd and context env vars
+
+--capture-env stores the current directory and a fixed list of context
+variables (BEADS_DIR, GOFLAGS, GT_AGENT_MODE, GT_THEME, NODE_ENV,
+VIRTUAL_ENV) in the bead. gt prime shows the agent the cd and export
+commands that restore them, so a restarted session resumes where the work
+was slung from. Identity and anything that could hold a secret are never
+captured. Off by default.
+
 Formula Slinging:
   gt sling mol-release mayor/           # Cook + wisp + attach + nudge
   gt sling towers-of-hanoi --var disks=3
@@ -85,10 +115,14 @@ Batch Slinging:
 var (
 	slingSubject     string
 	slingMessage     string
+	slingFromBead    string // --from flag: bead to pull subject/context from
 	slingDryRun      bool
 	slingOnTarget    string   // --on flag: target bead when slinging a formula
 	slingVars        []string // --var flag: formula variables (key=value)
 	slingArgs        string   // --args flag: natural language instructions for executor
+	slingMeta        []string // --meta flag: key/value hints for the agent (key=value)
+	slingAfter       []string // --after flag: beads that must be done before this one starts
+	slingCaptureEnv  bool     // --capture-env: record cwd and context env vars for resume
 	slingHookRawBead bool     // --hook-raw-bead: hook raw bead without default formula (expert mode)
 
 	// Flags migrated for polecat spawning (used by sling for work assignment)
@@ -104,10 +138,14 @@ var (
 func init() {
 	slingCmd.Flags().StringVarP(&slingSubject, "subject", "s", "", "Context subject for the work")
 	slingCmd.Flags().StringVarP(&slingMessage, "message", "m", "", "Context message for the work")
+	slingCmd.Flags().StringVar(&slingFromBead, "from", "", "Pull subject/context from this bead's title and description")
 	slingCmd.Flags().BoolVarP(&slingDryRun, "dry-run", "n", false, "Show what would be done")
 	slingCmd.Flags().StringVar(&slingOnTarget, "on", "", "Apply formula to existing bead (implies wisp scaffolding)")
 	slingCmd.Flags().StringArrayVar(&slingVars, "var", nil, "Formula variable (key=value), can be repeated")
 	slingCmd.Flags().StringVarP(&slingArgs, "args", "a", "", "Natural language instructions for the executor (e.g., 'patch release')")
+	slingCmd.Flags().StringArrayVar(&slingMeta, "meta", nil, "Metadata for the agent (key=value), can be repeated")
+	slingCmd.Flags().StringArrayVar(&slingAfter, "after", nil, "Bead that must be done before this work starts, can be repeated")
+	slingCmd.Flags().BoolVar(&slingCaptureEnv, "capture-env", false, "Record the current directory and context env vars for resume")
 
 	// Flags for polecat spawning (when target is a rig)
 	slingCmd.Flags().BoolVar(&slingCreate, "create", false, "Create polecat if it doesn't exist")
@@ -136,6 +174,21 @@ func runSling(cmd *cobra.Command, args []string) error {
 	}
 	townBeadsDir := filepath.Join(townRoot, ".beads")
 
+	// Validate --meta before anything is hooked
+	metadata, err := parseSlingMeta(slingMeta)
+	if err != nil {
+		return err
+	}
+	after, err := parseSlingAfter(slingAfter)
+	if err != nil {
+		return err
+	}
+
+	// --from: fill subject/context from a bead before any dispatch path uses them
+	if slingFromBead != "" {
+		applySlingContextFromBead(slingFromBead)
+	}
+
 	// Normalize target arguments: trim trailing slashes from target to handle tab-completion
 	// artifacts like "gt sling sl-123 slingshot/" → "gt sling sl-123 slingshot"
 	// This makes sling more forgiving without breaking existing functionality.
@@ -150,7 +203,10 @@ func runSling(cmd *cobra.Command, args []string) error {
 	if len(args) > 2 {
 		lastArg := args[len(args)-1]
 		if rigName, isRig := IsRigName(lastArg); isRig {
-			return runBatchSling(args[:len(args)-1], rigName, townBeadsDir)
+			if len(after) > 0 || slingCaptureEnv {
+				return fmt.Errorf("--after and --capture-env apply to a single bead; sling the beads one at a time")
+			}
+			return runBatchSling(args[:len(args)-1], rigName, townBeadsDir, metadata)
 		}
 	}
 
@@ -182,7 +238,10 @@ func runSling(cmd *cobra.Command, args []string) error {
 			// Not a verified bead - try as standalone formula
 			if err := verifyFormulaExists(firstArg); err == nil {
 				// Standalone formula mode: gt sling <formula> [target]
-				return runSlingFormula(args)
+				if len(after) > 0 || slingCaptureEnv {
+					return fmt.Errorf("--after and --capture-env apply to an existing bead, not a standalone formula (use --on)")
+				}
+				return runSlingFormula(args, metadata)
 			}
 			// Not a formula either - check if it looks like a bead ID (routing issue workaround).
 			// Accept it and let the actual bd update fail later if the bead doesn't exist.
@@ -204,6 +263,7 @@ func runSling(cmd *cobra.Command, args []string) error {
 	var delayedDogInfo *DogDispatchInfo     // For delayed dog session start after hook is set
 	var newPolecatInfo *SpawnedPolecatInfo  // Spawned polecat info (session started after bead setup)
 	var isSelfSling bool                    // True if slinging to self (skip nudge - agent already knows)
+	var isRoleSling bool                    // True if slinging to a role hook (no single agent to nudge)
 
 	if len(args) > 1 {
 		target := args[1]
@@ -243,6 +303,17 @@ func runSling(cmd *cobra.Command, args []string) error {
 				delayedDogInfo = dispatchInfo // Store for later session start
 				fmt.Printf("Dispatched to dog %s (session start delayed)\n", dispatchInfo.DogName)
 			}
+		} else if roleRig, isRole := wisp.IsRoleHookIdentity(target); isRole {
+			// Role target (rig/crew): park on the role hook until the first
+			// crew member to run gt prime claims it
+			if _, isRig := IsRigName(roleRig); !isRig {
+				return fmt.Errorf("resolving target: unknown rig %q", roleRig)
+			}
+			targetAgent = roleRig + "/crew"
+			isRoleSling = true
+			if slingDryRun {
+				fmt.Printf("Would queue for the first available crew member in rig '%s'\n", roleRig)
+			}
 		} else if rigName, isRig := IsRigName(target); isRig {
 			// Check if target is a rig name (auto-spawn polecat)
 			if slingDryRun {
@@ -449,6 +520,15 @@ func runSling(cmd *cobra.Command, args []string) error {
 		if slingArgs != "" {
 			fmt.Printf("  args (in nudge): %s\n", slingArgs)
 		}
+		for _, kv := range formatSlingMeta(metadata) {
+			fmt.Printf("  meta: %s\n", kv)
+		}
+		if len(after) > 0 {
+			fmt.Printf("  after: %s\n", strings.Join(after, ", "))
+		}
+		if slingCaptureEnv {
+			fmt.Printf("  capture env: cwd and %s\n", strings.Join(wisp.CapturedEnvVars, ", "))
+		}
 		fmt.Printf("Would inject start prompt to pane: %s\n", targetPane)
 		return nil
 	}
@@ -488,6 +568,16 @@ func runSling(cmd *cobra.Command, args []string) error {
 	// We retry with exponential backoff and verify the hook actually stuck.
 	// See: https://github.com/steveyegge/gastown/issues/148
 	hookDir := beads.ResolveHookDir(townRoot, beadID, hookWorkDir)
+
+	// Record dependencies before hooking, so the agent never sees the bead
+	// without them and a cycle is refused while nothing is hooked yet
+	if len(after) > 0 {
+		if err := storeDependsOnInBead(hookDir, beadID, after); err != nil {
+			return err
+		}
+		fmt.Printf("%s Waits on %s before starting\n", style.Bold.Render("✓"), strings.Join(after, ", "))
+	}
+
 	const maxRetries = 3
 	skipVerify := os.Getenv("GT_TEST_SKIP_HOOK_VERIFY") != "" // For tests with stub bd
 	var lastErr error
@@ -550,7 +640,8 @@ func runSling(cmd *cobra.Command, args []string) error {
 	// Skip if hook was already set atomically during polecat spawn - avoids "agent bead not found"
 	// error when polecat redirect setup fails (GH #gt-mzyk5: agent bead created in rig beads
 	// but updateAgentHookBead looks in polecat's local beads if redirect is missing).
-	if !hookSetAtomically {
+	// Role hooks have no agent bead; the claiming crew member's is set then.
+	if !hookSetAtomically && !isRoleSling {
 		updateAgentHookBead(targetAgent, beadID, hookWorkDir, townBeadsDir)
 	}
 
@@ -579,6 +670,24 @@ func runSling(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Store metadata in bead (shown to the agent via gt prime)
+	if len(metadata) > 0 {
+		if err := storeMetadataInBead(beadID, metadata); err != nil {
+			fmt.Printf("%s Could not store metadata in bead: %v\n", style.Dim.Render("Warning:"), err)
+		} else {
+			fmt.Printf("%s Metadata stored in bead (%d keys)\n", style.Bold.Render("✓"), len(metadata))
+		}
+	}
+
+	// Store the resume context (cwd, context env vars) in bead
+	if slingCaptureEnv {
+		if snap, err := storeCapturedEnvInBead(hookDir, beadID); err != nil {
+			fmt.Printf("%s Could not store environment in bead: %v\n", style.Dim.Render("Warning:"), err)
+		} else {
+			fmt.Printf("%s Environment captured (%s, %d var(s))\n", style.Bold.Render("✓"), snap.Cwd, len(snap.Env))
+		}
+	}
+
 	// Record the attached molecule in the BASE bead's description.
 	// This field points to the wisp (compound root) and enables:
 	// - gt hook/gt prime: follow attached_molecule to show molecule steps
@@ -621,6 +730,8 @@ func runSling(cmd *cobra.Command, args []string) error {
 	} else if isSelfSling {
 		// Self-sling: agent already knows about the work (just slung it)
 		fmt.Printf("%s Self-sling: work hooked, will process on next turn\n", style.Dim.Render("○"))
+	} else if isRoleSling {
+		fmt.Printf("%s Queued on %s: the first crew member to run gt prime picks it up\n", style.Dim.Render("○"), targetAgent)
 	} else if targetPane == "" {
 		fmt.Printf("%s No pane to nudge (agent will discover work via gt prime)\n", style.Dim.Render("○"))
 	} else {
@@ -13,7 +13,7 @@ import (
 
 // runBatchSling handles slinging multiple beads to a rig.
 // Each bead gets its own freshly spawned polecat.
-func runBatchSling(beadIDs []string, rigName string, townBeadsDir string) error {
+func runBatchSling(beadIDs []string, rigName string, townBeadsDir string, metadata map[string]string) error {
 	// Validate all beads exist before spawning any polecats
 	for _, beadID := range beadIDs {
 		if err := verifyBeadExists(beadID); err != nil {
@@ -156,6 +156,13 @@ func runBatchSling(beadIDs []string, rigName string, townBeadsDir string) error
 			}
 		}
 
+		// Store metadata if provided
+		if len(metadata) > 0 {
+			if err := storeMetadataInBead(beadID, metadata); err != nil {
+				fmt.Printf("  %s Could not store metadata: %v\n", style.Dim.Render("Warning:"), err)
+			}
+		}
+
 		// Nudge the polecat
 		if spawnInfo.Pane != "" {
 			if err := injectStartPrompt(spawnInfo.Pane, beadID, slingSubject, slingArgs); err != nil {
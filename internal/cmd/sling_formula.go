@@ -72,7 +72,7 @@ func verifyFormulaExists(formulaName string) error {
 
 // runSlingFormula handles standalone formula slinging.
 // Flow: cook → wisp → attach to hook → nudge
-func runSlingFormula(args []string) error {
+func runSlingFormula(args []string, metadata map[string]string) error {
 	formulaName := args[0]
 
 	// Get town root early - needed for BEADS_DIR when running bd commands
@@ -264,6 +264,13 @@ func runSlingFormula(args []string) error {
 		}
 	}
 
+	// Store metadata in wisp bead if provided
+	if len(metadata) > 0 {
+		if err := storeMetadataInBead(wispRootID, metadata); err != nil {
+			fmt.Printf("%s Could not store metadata in bead: %v\n", style.Dim.Render("Warning:"), err)
+		}
+	}
+
 	// Record the attached molecule after other description updates to avoid overwrite.
 	if attachedMoleculeID != "" {
 		if err := storeAttachedMoleculeInBead(wispRootID, attachedMoleculeID); err != nil {
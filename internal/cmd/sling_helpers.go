@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/wisp"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -64,9 +67,47 @@ func resolveBeadDirFromRigsJSON(townRoot, prefix string) string {
 
 // beadInfo holds status and assignee for a bead.
 type beadInfo struct {
-	Title    string `json:"title"`
-	Status   string `json:"status"`
-	Assignee string `json:"assignee"`
+	Title       string `json:"title"`
+	Status      string `json:"status"`
+	Assignee    string `json:"assignee"`
+	Description string `json:"description"`
+}
+
+// maxBeadSummaryLen caps the description summary pulled in by sling --from.
+const maxBeadSummaryLen = 280
+
+// beadSummary condenses a bead description to its first paragraph on one line,
+// truncated to maxBeadSummaryLen runes.
+func beadSummary(description string) string {
+	para := strings.TrimSpace(description)
+	if idx := strings.Index(para, "\n\n"); idx >= 0 {
+		para = para[:idx]
+	}
+	para = strings.Join(strings.Fields(para), " ")
+
+	runes := []rune(para)
+	if len(runes) > maxBeadSummaryLen {
+		para = strings.TrimSpace(string(runes[:maxBeadSummaryLen-1])) + "…"
+	}
+	return para
+}
+
+// applySlingContextFromBead fills the sling subject and context message from
+// a bead's title and description summary (sling --from). Explicit --subject
+// and --message values win. If the bead can't be read, a warning is printed
+// and the work is slung with whatever context was given.
+func applySlingContextFromBead(beadID string) {
+	info, err := getBeadInfo(beadID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s --from %s: %v; slinging without bead context\n", style.Warning.Render("⚠"), beadID, err)
+		return
+	}
+	if slingSubject == "" {
+		slingSubject = info.Title
+	}
+	if slingMessage == "" {
+		slingMessage = beadSummary(info.Description)
+	}
 }
 
 // verifyBeadExists checks that the bead exists using bd show.
@@ -317,6 +358,151 @@ func storeNoMergeInBead(beadID string, noMerge bool) error {
 	return nil
 }
 
+// parseSlingMeta parses repeated --meta key=value flags. Keys must be
+// non-empty, unique, and free of whitespace and ':' (they are stored as
+// "meta.<key>: value" description lines); values must be non-empty and fit
+// on one line.
+func parseSlingMeta(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	meta := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("invalid --meta %q (expected key=value)", pair)
+		case key == "":
+			return nil, fmt.Errorf("invalid --meta %q: empty key", pair)
+		case strings.ContainsAny(key, ": \t\n"):
+			return nil, fmt.Errorf("invalid --meta key %q: must not contain ':' or whitespace", key)
+		case value == "":
+			return nil, fmt.Errorf("invalid --meta %q: empty value", key)
+		case strings.ContainsAny(value, "\r\n"):
+			return nil, fmt.Errorf("invalid --meta %q: value must be a single line", key)
+		}
+		if _, dup := meta[key]; dup {
+			return nil, fmt.Errorf("duplicate --meta key %q", key)
+		}
+		meta[key] = value
+	}
+	return meta, nil
+}
+
+// formatSlingMeta returns metadata as sorted "key=value" strings for display.
+func formatSlingMeta(meta map[string]string) []string {
+	out := make([]string, 0, len(meta))
+	for k, v := range meta {
+		out = append(out, k+"="+v)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// parseSlingAfter parses --after values into dependency bead IDs. Each
+// value may itself be a comma-separated list; duplicates are dropped.
+func parseSlingAfter(values []string) ([]string, error) {
+	var deps []string
+	seen := make(map[string]bool)
+	for _, value := range values {
+		for _, id := range strings.Split(value, ",") {
+			id = strings.TrimSpace(id)
+			if id == "" {
+				return nil, fmt.Errorf("invalid --after %q: empty bead ID", value)
+			}
+			if strings.ContainsAny(id, " \t\n") {
+				return nil, fmt.Errorf("invalid --after bead ID %q", id)
+			}
+			if !seen[id] {
+				seen[id] = true
+				deps = append(deps, id)
+			}
+		}
+	}
+	return deps, nil
+}
+
+// storeDependsOnInBead records the beads beadID waits on, refusing
+// dependencies that don't exist or would form a cycle.
+func storeDependsOnInBead(hookDir, beadID string, deps []string) error {
+	b := beads.New(hookDir)
+	issue, err := b.Show(beadID)
+	if err != nil {
+		return fmt.Errorf("fetching bead: %w", err)
+	}
+	if err := wisp.SetHookDependencies(b, issue, deps); err != nil {
+		return fmt.Errorf("recording --after: %w", err)
+	}
+	return nil
+}
+
+// storeCapturedEnvInBead records the current directory and context env
+// vars in the bead (see wisp.CaptureEnv), returning what was captured.
+func storeCapturedEnvInBead(hookDir, beadID string) (wisp.EnvSnapshot, error) {
+	snap, err := wisp.CaptureEnv()
+	if err != nil {
+		return snap, err
+	}
+	b := beads.New(hookDir)
+	issue, err := b.Show(beadID)
+	if err != nil {
+		return snap, fmt.Errorf("fetching bead: %w", err)
+	}
+	return snap, wisp.SetHookEnv(b, issue, snap)
+}
+
+// storeMetadataInBead merges --meta key/value pairs into the bead's
+// attachment fields so the agent sees them on pickup (gt prime, gt hook status).
+func storeMetadataInBead(beadID string, meta map[string]string) error {
+	if len(meta) == 0 {
+		return nil
+	}
+
+	// Get the bead to preserve existing description content
+	showCmd := exec.Command("bd", "show", beadID, "--json")
+	out, err := showCmd.Output()
+	if err != nil {
+		return fmt.Errorf("fetching bead: %w", err)
+	}
+
+	// Parse the bead
+	var issues []beads.Issue
+	if err := json.Unmarshal(out, &issues); err != nil {
+		return fmt.Errorf("parsing bead: %w", err)
+	}
+	if len(issues) == 0 {
+		return fmt.Errorf("bead not found")
+	}
+	issue := &issues[0]
+
+	// Get or create attachment fields
+	fields := beads.ParseAttachmentFields(issue)
+	if fields == nil {
+		fields = &beads.AttachmentFields{}
+	}
+
+	// Merge the metadata (new values win over previously stored keys)
+	if fields.Metadata == nil {
+		fields.Metadata = make(map[string]string, len(meta))
+	}
+	for k, v := range meta {
+		fields.Metadata[k] = v
+	}
+
+	// Update the description
+	newDesc := beads.SetAttachmentFields(issue, fields)
+
+	// Update the bead
+	updateCmd := exec.Command("bd", "update", beadID, "--description="+newDesc)
+	updateCmd.Stderr = os.Stderr
+	if err := updateCmd.Run(); err != nil {
+		return fmt.Errorf("updating bead description: %w", err)
+	}
+
+	return nil
+}
+
 // injectStartPrompt sends a prompt to the target pane to start working.
 // Uses the reliable nudge pattern: literal mode + 500ms debounce + separate Enter.
 func injectStartPrompt(pane, beadID, subject, args string) error {
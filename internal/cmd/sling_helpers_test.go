@@ -97,3 +97,34 @@ func TestNudgeRefineryNoOpWithoutLog(t *testing.T) {
 	// Should not panic even though no tmux session exists
 	nudgeRefinery("nonexistent-rig", "test message")
 }
+
+func TestBeadSummary(t *testing.T) {
+	long := strings.Repeat("word ", 100)
+
+	tests := []struct {
+		name        string
+		description string
+		want        string
+	}{
+		{"empty", "", ""},
+		{"single line", "Fix the widget bug", "Fix the widget bug"},
+		{"first paragraph only", "Fix the widget.\nIt crashes.\n\nDetails follow here.", "Fix the widget. It crashes."},
+		{"leading whitespace", "\n\n  Fix it  \n", "Fix it"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := beadSummary(tt.description); got != tt.want {
+				t.Errorf("beadSummary(%q) = %q, want %q", tt.description, got, tt.want)
+			}
+		})
+	}
+
+	got := beadSummary(long)
+	if n := len([]rune(got)); n > maxBeadSummaryLen {
+		t.Errorf("beadSummary(long) has %d runes, want <= %d", n, maxBeadSummaryLen)
+	}
+	if !strings.HasSuffix(got, "…") {
+		t.Errorf("beadSummary(long) = %q, want ellipsis suffix", got)
+	}
+}
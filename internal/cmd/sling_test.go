@@ -1142,3 +1142,78 @@ exit /b 0
 		t.Errorf("--no-merge flag not stored in bead description\nLog:\n%s", string(logBytes))
 	}
 }
+
+func TestParseSlingMeta(t *testing.T) {
+	tests := []struct {
+		name    string
+		pairs   []string
+		want    map[string]string
+		wantErr string
+	}{
+		{name: "none", pairs: nil, want: nil},
+		{name: "pairs", pairs: []string{"priority=high", " effort = 2h ", "note=a=b"},
+			want: map[string]string{"priority": "high", "effort": "2h", "note": "a=b"}},
+		{name: "missing equals", pairs: []string{"priority"}, wantErr: "expected key=value"},
+		{name: "empty key", pairs: []string{"=high"}, wantErr: "empty key"},
+		{name: "empty value", pairs: []string{"priority="}, wantErr: "empty value"},
+		{name: "colon in key", pairs: []string{"a:b=c"}, wantErr: "must not contain"},
+		{name: "space in key", pairs: []string{"a b=c"}, wantErr: "must not contain"},
+		{name: "multiline value", pairs: []string{"note=a\nb"}, wantErr: "single line"},
+		{name: "duplicate key", pairs: []string{"priority=high", "priority=low"}, wantErr: "duplicate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSlingMeta(tt.pairs)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseSlingMeta(%q) error = %v, want %q", tt.pairs, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSlingMeta(%q): %v", tt.pairs, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseSlingMeta(%q) = %v, want %v", tt.pairs, got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("meta[%q] = %q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestParseSlingAfter(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    []string
+		wantErr string
+	}{
+		{name: "none", values: nil, want: nil},
+		{name: "repeated and comma-separated", values: []string{"gt-a", "gt-b, gt-c", "gt-a"}, want: []string{"gt-a", "gt-b", "gt-c"}},
+		{name: "empty item", values: []string{"gt-a,"}, wantErr: "empty bead ID"},
+		{name: "space in ID", values: []string{"gt a"}, wantErr: "invalid --after bead ID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSlingAfter(tt.values)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseSlingAfter(%q) error = %v, want %q", tt.values, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSlingAfter(%q): %v", tt.values, err)
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("parseSlingAfter(%q) = %v, want %v", tt.values, got, tt.want)
+			}
+		})
+	}
+}
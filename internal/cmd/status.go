@@ -41,7 +41,13 @@ var statusCmd = &cobra.Command{
 Shows town name, registered rigs, active polecats, and witness status.
 
 Use --fast to skip mail lookups for faster execution.
-Use --watch to continuously refresh status at regular intervals.`,
+Use --watch to continuously refresh status at regular intervals.
+
+Use --render to print the current session's status-left segment instead:
+its identity in its theme colors, as the tmux format string 'gt theme
+apply' sets (e.g. #[bg=#1e3a5f,fg=#e0e0e0]😺 gastown/Toast #[default]),
+for embedding gt's status in another bar. --session renders another
+session. The right side is 'gt status-line --session=<name>'.`,
 	RunE: runStatus,
 }
 
@@ -131,6 +137,9 @@ type StatusSum struct {
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
+	if statusRender {
+		return runStatusRender()
+	}
 	if statusWatch {
 		return runStatusWatch(cmd, args)
 	}
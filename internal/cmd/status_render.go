@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+var (
+	statusRender        bool
+	statusRenderSession string
+)
+
+func init() {
+	statusCmd.Flags().BoolVar(&statusRender, "render", false, "Print this session's themed status-left segment as a tmux format string")
+	statusCmd.Flags().StringVar(&statusRenderSession, "session", "", "With --render, the tmux session to render (default: the current one)")
+	statusCmd.MarkFlagsMutuallyExclusive("render", "watch")
+	statusCmd.MarkFlagsMutuallyExclusive("render", "json")
+}
+
+// runStatusRender prints the status-left segment 'gt theme apply' gives the
+// session (tmux.BuildStatus), in the colors it would apply, so it can be
+// embedded in another bar or prompt that understands tmux formats.
+func runStatusRender() error {
+	sess := statusRenderSession
+	if sess == "" {
+		var err error
+		if sess, err = currentTmuxSession(); err != nil {
+			return err
+		}
+	}
+	rig, worker, role, ok := parseThemeSession(sess)
+	if !ok {
+		return fmt.Errorf("%s is not a Gas Town agent session", sess)
+	}
+
+	theme := sessionTheme(rig, worker, role).ForCaps(tmux.DetectColorCaps())
+	fmt.Println(tmux.BuildStatus(rigDisplayName(rig), worker, role, theme))
+	return nil
+}
+
+// currentTmuxSession returns the name of the tmux session gt runs in.
+func currentTmuxSession() (string, error) {
+	if os.Getenv("TMUX") == "" {
+		return "", fmt.Errorf("not in a tmux session (use --session)")
+	}
+	out, err := exec.Command("tmux", "display-message", "-p", "#{session_name}").Output()
+	if err != nil {
+		return "", fmt.Errorf("getting current tmux session: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
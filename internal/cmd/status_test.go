@@ -158,3 +158,23 @@ func TestRunStatusWatch_RejectsJSONCombo(t *testing.T) {
 		t.Errorf("error %q should mention 'cannot be used together'", err.Error())
 	}
 }
+
+func TestRunStatusRender(t *testing.T) {
+	oldSession := statusRenderSession
+	defer func() { statusRenderSession = oldSession }()
+
+	statusRenderSession = "gt-gastown-Toast"
+	var err error
+	out := captureStdout(t, func() { err = runStatusRender() })
+	if err != nil {
+		t.Fatalf("runStatusRender: %v", err)
+	}
+	if !strings.HasPrefix(out, "#[bg=") || !strings.Contains(out, "gastown/Toast #[default]") {
+		t.Errorf("output = %q, want the themed status-left for gastown/Toast", out)
+	}
+
+	statusRenderSession = "scratch"
+	if err := runStatusRender(); err == nil {
+		t.Error("runStatusRender accepted a non-agent session")
+	}
+}
@@ -1,11 +1,14 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
@@ -94,14 +97,24 @@ func runWorkerStatusLine(t *tmux.Tmux, session, rigName, polecat, crew, issue st
 	}
 
 	// Get pane's working directory to find workspace
-	var townRoot string
+	var townRoot, paneDir string
 	if session != "" {
-		paneDir, err := t.GetPaneWorkDir(session)
-		if err == nil && paneDir != "" {
+		dir, err := t.GetPaneWorkDir(session)
+		if err == nil && dir != "" {
+			paneDir = dir
 			townRoot, _ = workspace.Find(paneDir)
 		}
 	}
 
+	// Dim the status bar of idle workers (opt-in via theme.idle_dim_minutes)
+	if session != "" && townRoot != "" && rigName != "" && (polecat != "" || crew != "") {
+		role, worker := "polecat", polecat
+		if crew != "" {
+			role, worker = "crew", crew
+		}
+		applyIdleDim(t, townRoot, session, rigName, worker, role)
+	}
+
 	// Build status parts
 	var parts []string
 
@@ -136,6 +149,13 @@ func runWorkerStatusLine(t *tmux.Tmux, session, rigName, polecat, crew, issue st
 		parts = append(parts, icon)
 	}
 
+	// Working tree state (opt-in via theme.git_status)
+	if paneDir != "" && townRoot != "" && gitStatusEnabled(townRoot, rigName) {
+		if segment := gitStatusSegment(paneDir); segment != "" {
+			parts = append(parts, segment)
+		}
+	}
+
 	// Mail preview - only show if hook is empty
 	if hookedWork == "" && identity != "" && townRoot != "" {
 		unread, subject := getMailPreviewWithRoot(identity, 45, townRoot)
@@ -776,3 +796,48 @@ func getCurrentWork(t *tmux.Tmux, session string, maxLen int) string {
 	}
 	return display
 }
+
+// gitStatusTimeout bounds the git run behind the status line's git
+// segment, so a slow repository can't stall the status refresh.
+const gitStatusTimeout = 2 * time.Second
+
+// gitStatusEnabled reports whether worker status lines in rigName show
+// their git state: theme.git_status in the rig's settings or the town's
+// mayor/config.json.
+func gitStatusEnabled(townRoot, rigName string) bool {
+	if rigName != "" {
+		settings, err := config.LoadRigSettings(filepath.Join(config.RigDir(townRoot, rigName), "settings", "config.json"))
+		if err == nil && settings.Theme != nil && settings.Theme.GitStatus {
+			return true
+		}
+	}
+	mayorCfg, err := config.LoadMayorConfig(filepath.Join(townRoot, "mayor", "config.json"))
+	return err == nil && mayorCfg.Theme != nil && mayorCfg.Theme.GitStatus
+}
+
+// gitStatusSegment returns the status line segment for the git working
+// tree at dir: "✓" when clean, or "±N" for N changed paths, drawn in the
+// status bar's colors reversed so a dirty tree stands out in any theme.
+// It is empty when dir isn't in a work tree or git doesn't answer in time.
+func gitStatusSegment(dir string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), gitStatusTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "-C", dir, "status", "--porcelain")
+	// Don't take the index lock: the worker may be running git itself
+	cmd.Env = append(os.Environ(), "GIT_OPTIONAL_LOCKS=0")
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	changed := 0
+	for _, line := range strings.Split(string(out), "\n") {
+		if line != "" {
+			changed++
+		}
+	}
+	if changed == 0 {
+		return "✓"
+	}
+	return fmt.Sprintf("#[reverse] ±%d #[noreverse]", changed)
+}
@@ -1,6 +1,13 @@
 package cmd
 
-import "testing"
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
 
 func TestCategorizeSessionRig(t *testing.T) {
 	tests := []struct {
@@ -84,3 +91,56 @@ func TestCategorizeSessionType(t *testing.T) {
 		})
 	}
 }
+
+func TestGitStatusSegment(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	if got := gitStatusSegment(dir); got != "" {
+		t.Errorf("gitStatusSegment(not a repo) = %q, want empty", got)
+	}
+
+	if out, err := exec.Command("git", "init", "-q", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	if got := gitStatusSegment(dir); got != "✓" {
+		t.Errorf("gitStatusSegment(clean) = %q, want ✓", got)
+	}
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := gitStatusSegment(dir); got != "#[reverse] ±2 #[noreverse]" {
+		t.Errorf("gitStatusSegment(dirty) = %q, want ±2 reversed", got)
+	}
+}
+
+func TestGitStatusEnabled(t *testing.T) {
+	townRoot, _ := setupTestRigForSettings(t)
+	if gitStatusEnabled(townRoot, "testrig") {
+		t.Error("git status enabled with no config; want opt-in")
+	}
+
+	settings := config.NewRigSettings()
+	settings.Theme = &config.ThemeConfig{GitStatus: true}
+	if err := config.SaveRigSettings(filepath.Join(townRoot, "testrig", "settings", "config.json"), settings); err != nil {
+		t.Fatalf("SaveRigSettings: %v", err)
+	}
+	if !gitStatusEnabled(townRoot, "testrig") {
+		t.Error("rig theme.git_status not honored")
+	}
+	if gitStatusEnabled(townRoot, "otherrig") {
+		t.Error("rig setting leaked to another rig")
+	}
+
+	mayorCfg := config.NewMayorConfig()
+	mayorCfg.Theme = &config.TownThemeConfig{GitStatus: true}
+	if err := config.SaveMayorConfig(filepath.Join(townRoot, "mayor", "config.json"), mayorCfg); err != nil {
+		t.Fatalf("SaveMayorConfig: %v", err)
+	}
+	if !gitStatusEnabled(townRoot, "otherrig") {
+		t.Error("town theme.git_status not honored")
+	}
+}
@@ -1,25 +1,63 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/muesli/termenv"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/state"
+	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
+	"golang.org/x/term"
 )
 
 var (
 	themeListFlag     bool
 	themeApplyFlag    bool
 	themeApplyAllFlag bool
+	themeRigFlag      string
+	themeTimeoutFlag  time.Duration
+	themeSetApplyFlag bool
+	themeWorkerFlag   string
+	themeWindowsFlag  bool
+	themeTitlesFlag   bool
+	themeGradientFlag bool
+	themeCopyModeFlag bool
+	themeDimInactive  bool
+	themeAtomicFlag   bool
+	themeStrictFlag   bool
+	themeForceFlag    bool
+	themeOnlyMissing  bool
+	themeResetFlag    bool
+	themeQuietFlag    bool
+	themeVerboseFlag  bool
+	themeGroupByFlag  string
+
+	themeIncludeMayor  bool
+	themeExcludeMayor  bool
+	themeIncludeDeacon bool
+	themeExcludeDeacon bool
 )
 
+// unknownRigName stands in for the rig when detection fails.
+const unknownRigName = "unknown"
+
+// themeOverrideEnv forces a tmux theme for the current process, ahead of
+// rig config and the hash-based default. (GT_THEME is the CLI color mode.)
+const themeOverrideEnv = "GT_TMUX_THEME"
+
 // Valid CLI theme modes
 var validCLIThemes = []string{"auto", "dark", "light"}
 
@@ -30,13 +68,79 @@ var themeCmd = &cobra.Command{
 	Long: `Manage tmux status bar themes for Gas Town sessions.
 
 Without arguments, shows the current theme assignment.
-With a name argument, sets the theme for this rig.
+With a name argument, sets the theme for this rig. A unique prefix is
+enough ('gt theme for' selects forest); an ambiguous one lists the
+matching themes and fails.
 
 Examples:
   gt theme              # Show current theme
   gt theme --list       # List available themes
+  gt theme --list --group-by temperature  # ...in warm/cool/neutral sections
   gt theme forest       # Set theme to 'forest'
-  gt theme apply        # Apply theme to all running sessions in this rig`,
+  gt theme apply        # Apply theme to all running sessions in this rig
+  gt theme history      # Show who changed this rig's theme
+  gt theme diff-applied # List sessions whose colors drifted from their theme
+  gt theme focus gastown # Dim every rig but gastown (gt theme unfocus to restore)
+  gt theme adjust ocean --lighten 10 --name ocean-light  # Derive a custom theme
+  gt theme forest --rig gastown  # Set theme for an explicit rig
+  gt theme forest --apply        # Set theme and apply it to running sessions
+  gt theme plum --worker max     # Give one worker their own theme
+  gt theme --pick                # Choose a theme interactively
+
+The target rig is detected from GT_RIG, the tmux session name, or the cwd
+(the nearest enclosing rig, so nested layouts such as group/rig-a work when
+registered with a "path" in mayor/rigs.json; sessions still use the rig name).
+Scripts should pass --rig (or use 'gt rig theme <rig>') so the target is
+never inferred from ambient context.
+
+Use --pick to browse the themes in a terminal UI: moving the cursor
+previews the rig's status bar in each theme, enter saves the theme and
+applies it to the rig's running sessions, and q cancels. It needs an
+interactive terminal.
+
+Rigs without a configured theme get one from a hash of the rig name. To
+reshuffle these defaults, set theme.seed in mayor/config.json (or
+GT_THEME_SEED) to any string: every default assignment changes, stays
+stable for that seed, and comes back when the seed is removed. Rigs with
+a configured theme keep it.
+
+Theme names are resolved along a search path, each layer overriding the
+ones before it by name:
+  1. built-in themes
+  2. $XDG_CONFIG_HOME/gastown/themes/*.json (~/.config/gastown/themes)
+     for personal themes
+  3. <town>/.gastown/themes/*.json for themes shared by the town
+  4. theme.custom in mayor/config.json (written by 'gt theme adjust')
+  5. the rig's theme.theme_defs, for that rig only
+Files within a directory are read in name order, so a later file wins over
+an earlier one. Each file maps names to colors, as checked by 'gt theme
+validate'. 'gt theme --list' shows where every theme comes from.
+
+Set GT_TMUX_THEME to a theme name to try a look without writing config
+(e.g. for a demo): it overrides the rig's configured or hash-based theme
+for that command only, and 'gt theme' reports it as an override.
+  GT_TMUX_THEME=plum gt theme apply
+
+Set "idle_dim_minutes" in the rig's settings/config.json theme block to
+dim crew and polecat status bars after that many minutes without tmux
+activity; the status-line refresh restores the theme once they're active.
+
+Set "git_status": true in a rig's theme block (or in mayor/config.json for
+every rig) to show each worker's git working tree on its status bar: ✓
+when clean, or ±N changed paths in the theme's colors reversed when
+dirty. It runs git status on every refresh, bounded to 2s.
+
+Use --worker to give one crew member or polecat its own theme, e.g.
+'gt theme plum --worker max'. It is saved to theme.workers in the rig's
+settings and wins over role and rig themes: a worker's sessions use its
+worker theme, then the rig's role theme (role_themes, the town's
+role_defaults, built-in role defaults), then the rig theme, then the
+hash-based default. Without a name, --worker shows the worker's theme.
+
+With the global --dry-run flag, setting a theme prints the rig, the
+previous and new theme names, and the settings file that would be
+written; nothing is saved and no history entry is recorded. Combined
+with --apply it also lists the sessions 'gt theme apply' would touch.`,
 	RunE: runTheme,
 }
 
@@ -45,8 +149,149 @@ var themeApplyCmd = &cobra.Command{
 	Short: "Apply theme to running sessions",
 	Long: `Apply theme to running Gas Town sessions.
 
-By default, only applies to sessions in the current rig.
-Use --all to apply to sessions across all rigs.`,
+By default, only applies to sessions in the current rig (including its
+witness and refinery). Use --rig to target a specific rig, or --all to
+apply across all rigs. Town-level sessions (Mayor, Deacon) are only
+themed with --all. The command must run inside a Gas Town workspace, and
+when no rig can be detected --rig or --all is required rather than
+theming every gt session.
+
+Use --include-mayor or --exclude-mayor (and --include-deacon or
+--exclude-deacon) to override that for the town-level sessions: add the
+Mayor to a single-rig apply, or restyle every rig with --all while
+leaving the Mayor's distinct look alone. These also apply to --reset.
+
+Use --only-missing to theme just the sessions whose status bar doesn't
+already have the expected colors (e.g. newly started workers), leaving
+sessions that are already themed - and any manual tweaks - untouched.
+
+Use --watch to keep running in the foreground until interrupted: every
+--interval (default 5s) the sessions in scope are listed again and any
+without their theme yet are themed, with --only-missing's rules, logging
+a timestamped line for each. Failures and customized sessions are logged
+once, not on every tick. The post-apply hook runs after each tick that
+themed something.
+
+Sessions whose status colors look set by hand - set, but matching no theme
+gt could have applied (built-in, special, town custom or rig-local, including
+color-downgraded and idle-dimmed variants) - are not overwritten: they are
+skipped and listed at the end. Use --force to theme them anyway.
+
+To style sessions on another tmux server (e.g. a socket forwarded over
+SSH), set GT_TMUX_SOCKET or theme.tmux_socket in mayor/config.json to a
+socket path (tmux -S) or socket name (tmux -L). The server is checked
+up front and the apply fails if it can't be reached.
+
+With --all, the summary ends with a table of applied, skipped (already
+themed or manually customized) and failed sessions per rig, plus a total.
+Use --json to print just that summary as JSON on stdout, for any scope:
+{"rigs": [{"rig": "gastown", "applied": 3, "skipped": 1, "failed": 0}, ...],
+"total": {...}}, with "rig": "" for the Mayor and Deacon.
+
+Per-session successes and the summary go to stdout; failures, rollback
+progress and other diagnostics go to stderr, so stdout can be parsed.
+When stderr is a terminal, a progress line ([12/47] gt-acme-joe) shows
+the session being themed; it is erased before the summary. Use --quiet
+to drop the progress line and per-session successes.
+
+Each session's message line and command prompt (message-style and
+message-command-style) are themed along with its status bar, as are the
+indicators tmux would otherwise leave in its defaults: the clock-mode
+clock (clock-mode-colour) and the tabs of windows with activity or a bell
+(window-status-activity-style, window-status-bell-style). --reset clears
+them too.
+
+Each tmux command gives up after 5s (--timeout, or GT_TMUX_TIMEOUT for
+every gt command), so a wedged server can't hang the apply: a session
+that times out is reported as failed and the rest are still themed.
+
+Detached sessions are themed the same as attached ones: all styling is
+stored as tmux session options and shows as soon as a client attaches.
+
+Theme colors must be colour0-colour255, #rrggbb or a named tmux color
+(red, brightblue, default, ...). A session whose theme has an invalid
+color is reported with the offending field and skipped; 'gt theme --list'
+flags such themes too.
+
+On legacy terminals (TERM=linux, vt100, ansi, ...) theme colors are mapped
+to the nearest of the eight ANSI colors. Set GT_TMUX_COLORS to 8, 256
+(nearest colourN) or truecolor (colors as-is) to override the detection.
+
+Status bars re-run their dynamic status every 5 seconds. Set
+theme.refresh_interval (seconds) in mayor/config.json for the town, or in
+a rig's settings to override it for that rig, e.g. faster for busy rigs,
+slower for idle ones.
+
+The left side of the status bar is sized to fit the session's identity
+(at least 25 cells), so long rig and worker names aren't clipped. To cap
+either side on narrow terminals, set theme.status_left_length or
+theme.status_right_length (cells) in the rig's settings.
+
+A theme may prefer a status line position (status_position: "top" or
+"bottom" in a custom theme definition), and a rig can set
+theme.status_position in its settings to override it. When neither is
+set, status-position is left as it is.
+
+On wide terminals a theme can add a centered status segment between the
+window list and the right side (status_center in a custom theme
+definition, or theme.status_center in a rig's settings): "rig" for the
+rig's display name, "worker", "role", or "identity" for the full agent
+identity. This needs tmux 3.0 or newer; older tmux keeps the two-part
+status bar.
+
+Use --windows to also theme window tabs (window-status-style and
+window-status-current-style) in every window of each session.
+
+Use --titles to also name windows and the terminal title after the agent,
+as the status bar shows it (e.g. "😺 gastown/Toast"): set-titles-string
+for terminal tab titles and automatic-rename-format for window names.
+The outer terminal shows the title through OSC 0/2 once tmux has
+set-titles on, which --titles also turns on. Set theme.terminal_title to
+true in a rig's settings (or in mayor/config.json for every rig) to do
+this on every apply and when sessions start, without the flag.
+
+Use --gradient for per-pane accents: pane border status lines are turned
+on, and each one shows its pane's index and title on the theme's accent, full
+strength for the first pane and dimming toward the last (at most 8 shades;
+later panes share the dimmest). Only #rrggbb accents can be dimmed.
+
+Use --copy-mode to also style copy-mode (mode-style) in every window, so
+selections and the position indicator use the theme's accent instead of
+tmux's default yellow.
+
+Use --dim-inactive to dim inactive panes: window-style gets a dark shade of
+the theme's background (a fixed dark grey for non-#rrggbb backgrounds) and
+window-active-style the terminal default, so the active pane stands out.
+Needs tmux 2.1 or later; --reset undoes it.
+
+Use --atomic to make the apply all-or-nothing: each session's status-bar
+options are snapshotted first, and if more than half of the sessions fail
+(or any session fails, with --strict) every session already changed is
+restored and the command exits non-zero. Options set by --windows,
+--titles, --gradient, --copy-mode and --dim-inactive are not part of the
+snapshot.
+
+Use --reset to undo gt's theming instead: every status-bar, message,
+window tab, title, copy-mode, pane style and pane border option gt sets is unset on the matching
+sessions, returning them to the tmux defaults. Each session reports the options it cleared.
+
+To run a script after an apply, set theme.post_apply_hook in
+mayor/config.json to a shell command. It runs once, after the apply loop,
+when at least one session was themed (not with --dry-run or --reset, and
+not after an --atomic rollback), with arguments $1 = rig and $2 = theme
+and this environment:
+  GT_THEME_APPLY_RIG       rig applied to ("" with --all)
+  GT_THEME_APPLY_THEME     the rig's theme name ("" with --all)
+  GT_THEME_APPLY_SESSIONS  space-separated sessions that were themed
+  GT_THEME_APPLY_COUNT     number of sessions themed
+  GT_THEME_APPLY_FAILED    number of sessions that failed
+The hook runs from the town root with a 30s timeout. Its output is shown
+with --verbose; a failing hook is reported as a warning and does not fail
+the apply.
+
+With the global --dry-run flag, each matching session is listed with the
+theme it would receive (or, with --reset, the options it would clear);
+tmux is not modified.`,
 	RunE: runThemeApply,
 }
 
@@ -79,163 +324,885 @@ func init() {
 	themeCmd.AddCommand(themeApplyCmd)
 	themeCmd.AddCommand(themeCLICmd)
 	themeCmd.Flags().BoolVarP(&themeListFlag, "list", "l", false, "List available themes")
+	themeCmd.Flags().StringVar(&themeGroupByFlag, "group-by", "", "With --list, group themes by source, tone (dark/light) or temperature (warm/cool)")
+	themeCmd.Flags().StringVar(&themeRigFlag, "rig", "", "Target rig (default: detected from environment)")
+	themeCmd.Flags().BoolVar(&themeSetApplyFlag, "apply", false, "Also apply the new theme to the rig's running sessions")
+	themeCmd.Flags().StringVar(&themeWorkerFlag, "worker", "", "Show or set the theme of one crew member or polecat in the rig")
 	themeApplyCmd.Flags().BoolVarP(&themeApplyAllFlag, "all", "a", false, "Apply to all rigs, not just current")
+	themeApplyCmd.Flags().StringVar(&themeRigFlag, "rig", "", "Target rig (default: detected from environment)")
+	themeApplyCmd.Flags().BoolVar(&themeWindowsFlag, "windows", false, "Also theme window tabs in each session")
+	themeApplyCmd.Flags().BoolVar(&themeTitlesFlag, "titles", false, "Also set window names and the terminal title from the agent identity")
+	themeApplyCmd.Flags().BoolVar(&themeGradientFlag, "gradient", false, "Also give panes an accent gradient on their border status lines")
+	themeApplyCmd.Flags().BoolVar(&themeCopyModeFlag, "copy-mode", false, "Also color copy-mode selections with the theme's accent")
+	themeApplyCmd.Flags().BoolVar(&themeDimInactive, "dim-inactive", false, "Also dim inactive panes with a shade of the theme's background")
+	themeApplyCmd.Flags().BoolVar(&themeOnlyMissing, "only-missing", false, "Skip sessions that already have the expected theme")
+	themeApplyCmd.Flags().BoolVar(&themeAtomicFlag, "atomic", false, "Roll back all sessions if too many fail to apply")
+	themeApplyCmd.Flags().BoolVar(&themeStrictFlag, "strict", false, "With --atomic, roll back on any failure")
+	themeApplyCmd.Flags().BoolVar(&themeForceFlag, "force", false, "Also overwrite sessions whose colors look manually customized")
+	themeApplyCmd.Flags().BoolVar(&themeResetFlag, "reset", false, "Clear gt's theme options, restoring tmux defaults")
+	themeApplyCmd.Flags().BoolVarP(&themeQuietFlag, "quiet", "q", false, "Only show failures and the summary")
+	themeApplyCmd.Flags().BoolVarP(&themeVerboseFlag, "verbose", "v", false, "Show the post-apply hook's output")
+	themeApplyCmd.Flags().DurationVar(&themeTimeoutFlag, "timeout", 0, "Give up on each tmux command after this long (default 5s, or GT_TMUX_TIMEOUT)")
+	themeApplyCmd.Flags().BoolVar(&themeIncludeMayor, "include-mayor", false, "Also theme the Mayor session, even without --all")
+	themeApplyCmd.Flags().BoolVar(&themeExcludeMayor, "exclude-mayor", false, "Leave the Mayor session alone, even with --all")
+	themeApplyCmd.Flags().BoolVar(&themeIncludeDeacon, "include-deacon", false, "Also theme the Deacon session, even without --all")
+	themeApplyCmd.Flags().BoolVar(&themeExcludeDeacon, "exclude-deacon", false, "Leave the Deacon session alone, even with --all")
+	themeApplyCmd.MarkFlagsMutuallyExclusive("all", "rig")
+	themeApplyCmd.MarkFlagsMutuallyExclusive("include-mayor", "exclude-mayor")
+	themeApplyCmd.MarkFlagsMutuallyExclusive("include-deacon", "exclude-deacon")
+	themeApplyCmd.MarkFlagsMutuallyExclusive("reset", "windows")
+	themeApplyCmd.MarkFlagsMutuallyExclusive("reset", "titles")
+	themeApplyCmd.MarkFlagsMutuallyExclusive("reset", "gradient")
+	themeApplyCmd.MarkFlagsMutuallyExclusive("reset", "copy-mode")
+	themeApplyCmd.MarkFlagsMutuallyExclusive("reset", "dim-inactive")
+	themeApplyCmd.MarkFlagsMutuallyExclusive("reset", "only-missing")
+	themeApplyCmd.MarkFlagsMutuallyExclusive("reset", "atomic")
 }
 
 func runTheme(cmd *cobra.Command, args []string) error {
 	// List mode
 	if themeListFlag {
-		fmt.Println("Available themes:")
-		for _, name := range tmux.ListThemeNames() {
-			theme := tmux.GetThemeByName(name)
-			fmt.Printf("  %-10s  %s\n", name, theme.Style())
-		}
-		// Also show Mayor theme
-		mayor := tmux.MayorTheme()
-		fmt.Printf("  %-10s  %s (Mayor only)\n", mayor.Name, mayor.Style())
-		return nil
+		return listThemes(themeGroupByFlag)
+	}
+	if themeGroupByFlag != "" {
+		return fmt.Errorf("--group-by requires --list")
 	}
 
-	// Determine current rig
-	rigName := detectCurrentRig()
+	// Determine target rig
+	rigName, err := resolveThemeRig()
+	if err != nil {
+		return err
+	}
 	if rigName == "" {
-		rigName = "unknown"
+		rigName = unknownRigName
+	}
+
+	if themePickFlag {
+		if len(args) > 0 {
+			return fmt.Errorf("--pick doesn't take a theme name")
+		}
+		return runThemePicker(rigName)
+	}
+
+	if themeWorkerFlag != "" {
+		if rigName == unknownRigName {
+			return fmt.Errorf("could not detect rig (pass --rig)")
+		}
+		if len(args) == 0 {
+			showWorkerTheme(rigName, themeWorkerFlag)
+			return nil
+		}
+		return setWorkerTheme(rigName, themeWorkerFlag, args[0])
 	}
 
 	// Show current theme assignment
 	if len(args) == 0 {
-		theme := getThemeForRig(rigName)
-		fmt.Printf("Rig: %s\n", rigName)
-		fmt.Printf("Theme: %s (%s)\n", theme.Name, theme.Style())
-		// Show if it's configured vs default
-		if configured := loadRigTheme(rigName); configured != "" {
-			fmt.Printf("(configured in settings/config.json)\n")
-		} else {
-			fmt.Printf("(default, based on rig name hash)\n")
-		}
+		showRigTheme(rigName)
 		return nil
 	}
 
-	// Set theme
-	themeName := args[0]
-	theme := tmux.GetThemeByName(themeName)
-	if theme == nil {
-		return fmt.Errorf("unknown theme: %s (use --list to see available themes)", themeName)
+	return setRigTheme(rigName, args[0])
+}
+
+// setRigTheme validates a palette theme name and saves it to the rig's settings.
+// With --apply, the rig's running sessions are re-themed once the save succeeds.
+func setRigTheme(rigName, themeName string) error {
+	themeName, err := matchThemeName(rigName, themeName)
+	if err != nil {
+		return err
+	}
+	if theme := resolveRigThemeByName(rigName, themeName); theme != nil {
+		if err := theme.Validate(); err != nil {
+			return fmt.Errorf("cannot use theme '%s': %w", themeName, err)
+		}
+	}
+
+	if findTheme(loadRigThemeDefs(rigName), themeName) != nil && resolveThemeByName(themeName) != nil {
+		fmt.Fprintf(os.Stderr, "%s Note: rig %s defines its own '%s' theme, which overrides the town/built-in one\n",
+			style.Dim.Render("ℹ"), rigName, themeName)
 	}
 
-	// Save to rig config
+	// Save to rig config (a failure aborts before anything is applied)
 	if err := saveRigTheme(rigName, themeName); err != nil {
 		return fmt.Errorf("saving theme config: %w", err)
 	}
 
-	fmt.Printf("Theme '%s' saved for rig '%s'\n", themeName, rigName)
-	fmt.Println("Run 'gt theme apply' to apply to running sessions")
+	if !globalDryRun {
+		fmt.Printf("Theme '%s' saved for rig '%s'\n", themeName, rigName)
+	}
+	if !themeSetApplyFlag {
+		if globalDryRun {
+			return nil
+		}
+		fmt.Printf("Run 'gt theme apply --rig %s' to apply to running sessions\n", rigName)
+		return nil
+	}
+
+	fmt.Println()
+	return applyThemeToSessions(rigName, false)
+}
 
-	return nil
+// setWorkerTheme saves a theme override for one worker in a rig
+// (theme.workers), which wins over the rig's role and rig themes. With
+// --apply, the rig's running sessions are re-themed once the save succeeds.
+func setWorkerTheme(rigName, worker, themeName string) error {
+	themeName, err := matchThemeName(rigName, themeName)
+	if err != nil {
+		return err
+	}
+	if theme := resolveRigThemeByName(rigName, themeName); theme != nil {
+		if err := theme.Validate(); err != nil {
+			return fmt.Errorf("cannot use theme '%s': %w", themeName, err)
+		}
+	}
+
+	_, r, err := getRig(rigName)
+	if err != nil {
+		return err
+	}
+	settingsPath := config.RigSettingsPath(r.Path)
+	if globalDryRun {
+		fmt.Printf("Would set theme for worker %s/%s: %s\n", rigName, worker, themeName)
+		fmt.Printf("  (would write %s)\n", settingsPath)
+		return nil
+	}
+
+	err = config.UpdateRigSettings(settingsPath, func(settings *config.RigSettings) error {
+		if settings.Theme == nil {
+			settings.Theme = &config.ThemeConfig{}
+		}
+		if settings.Theme.Workers == nil {
+			settings.Theme.Workers = make(map[string]string)
+		}
+		settings.Theme.Workers[worker] = themeName
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("saving theme config: %w", err)
+	}
+
+	fmt.Printf("Theme '%s' saved for worker '%s/%s'\n", themeName, rigName, worker)
+	if !themeSetApplyFlag {
+		fmt.Printf("Run 'gt theme apply --rig %s' to apply to running sessions\n", rigName)
+		return nil
+	}
+	fmt.Println()
+	return applyThemeToSessions(rigName, false)
+}
+
+// showWorkerTheme prints the theme a worker's sessions get and whether it
+// is the worker's own override.
+func showWorkerTheme(rigName, worker string) {
+	_, r, _ := getRig(rigName)
+	role := "polecat"
+	if r != nil {
+		role, _, _ = sessionWorker(r.Path, rigName, worker)
+	}
+	theme := getThemeForWorker(rigName, worker, role)
+	fmt.Printf("Worker: %s/%s\n", rigName, worker)
+	fmt.Printf("Theme: %s (%s)\n", theme.Name, theme.Style())
+	if tc := loadRigThemeConfig(rigName); tc != nil && tc.Workers[worker] != "" {
+		fmt.Printf("(worker override in settings/config.json)\n")
+	} else {
+		fmt.Printf("(from the rig's %s theme; no worker override)\n", role)
+	}
+}
+
+// showRigTheme prints the effective theme for a rig and where it came from.
+func showRigTheme(rigName string) {
+	theme := getThemeForRig(rigName)
+	if label := rigDisplayName(rigName); label != rigName {
+		fmt.Printf("Rig: %s (%s)\n", label, rigName)
+	} else {
+		fmt.Printf("Rig: %s\n", rigName)
+	}
+	fmt.Printf("Theme: %s (%s)\n", theme.Name, theme.Style())
+	// Show if it's overridden, configured or default
+	if themeOverride(rigName) != nil {
+		fmt.Printf("(from %s override; not saved)\n", themeOverrideEnv)
+	} else if rigName == unknownRigName {
+		fmt.Printf("(rig not detected; run 'gt whoami' to see why, or pass --rig)\n")
+	} else if configured := loadRigTheme(rigName); configured != "" {
+		fmt.Printf("(configured in settings/config.json)\n")
+	} else {
+		fmt.Printf("(default, based on rig name hash)\n")
+	}
+	if tc := loadTownThemeConfig(); tc != nil && tc.Focus != "" {
+		fmt.Printf("Focus: %s (other rigs dimmed; 'gt theme unfocus' to restore)\n", tc.Focus)
+	}
+}
+
+// resolveThemeRig returns the rig targeted by a theme command.
+// An explicit --rig always wins and must name a known rig; otherwise the
+// rig is detected from the environment (may be empty).
+func resolveThemeRig() (string, error) {
+	if themeRigFlag != "" {
+		if _, _, err := getRig(themeRigFlag); err != nil {
+			return "", err
+		}
+		return themeRigFlag, nil
+	}
+	return detectCurrentRig(), nil
 }
 
 func runThemeApply(cmd *cobra.Command, args []string) error {
-	t := tmux.NewTmux()
+	if themeStrictFlag && !themeAtomicFlag {
+		return fmt.Errorf("--strict requires --atomic")
+	}
+
+	// Determine target rig
+	rigName, err := resolveThemeRig()
+	if err != nil {
+		return err
+	}
+	townRoot, _ := workspace.FindFromCwd()
+	if err := checkThemeApplyScope(townRoot, rigName, themeApplyAllFlag); err != nil {
+		return err
+	}
+
+	if themeResetFlag {
+		return resetThemeOnSessions(rigName, themeApplyAllFlag)
+	}
+	if themeWatchFlag {
+		return watchThemeApply(rigName, themeApplyAllFlag)
+	}
+	return applyThemeToSessions(rigName, themeApplyAllFlag)
+}
+
+// checkThemeApplyScope refuses an apply outside a town, or one whose rig
+// couldn't be detected without an explicit --all, so running gt theme apply
+// from a random directory doesn't re-theme every session on the server.
+func checkThemeApplyScope(townRoot, rigName string, all bool) error {
+	if townRoot == "" {
+		return fmt.Errorf("not in a Gas Town workspace (run from inside your town)")
+	}
+	if rigName == "" && !all {
+		return fmt.Errorf("could not detect rig; pass --rig <name> or --all to theme every rig (run 'gt whoami' to see why)")
+	}
+	return nil
+}
+
+// resetThemeOnSessions clears gt's theme options from every running Gas
+// Town session in scope for rigName (all sessions when all is set). It is
+// the inverse of applyThemeToSessions.
+func resetThemeOnSessions(rigName string, all bool) error {
+	t := themeTmux()
+	if t.Socket() != "" {
+		if err := t.CheckServer(); err != nil {
+			return err
+		}
+	}
 
-	// Get all sessions
 	sessions, err := t.ListSessions()
 	if err != nil {
 		return fmt.Errorf("listing sessions: %w", err)
 	}
 
-	// Determine current rig
-	rigName := detectCurrentRig()
+	var targets []string
+	for _, sess := range sessions {
+		if rig, _, role, ok := parseThemeSession(sess); ok && themeSessionInScope(rig, role, rigName, all) {
+			targets = append(targets, sess)
+		}
+	}
 
-	// Get session names for comparison
-	mayorSession := session.MayorSessionName()
-	deaconSession := session.DeaconSessionName()
+	progress := newThemeProgress(len(targets))
+	reset, failed := 0, 0
+	for i, sess := range targets {
+		progress.step(i+1, sess)
 
-	// Apply to matching sessions
-	applied := 0
-	for _, sess := range sessions {
-		if !strings.HasPrefix(sess, "gt-") {
+		var opts []string
+		if globalDryRun {
+			opts, err = t.ThemedOptions(sess)
+		} else {
+			opts, err = t.ResetTheme(sess)
+		}
+		progress.clear()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "  %s: reset failed (%v)\n", sess, err)
+			failed++
+			continue
+		}
+		if len(opts) > 0 {
+			reset++
+		}
+		if themeQuietFlag {
 			continue
 		}
+		switch {
+		case len(opts) == 0:
+			fmt.Printf("  %s: nothing to reset\n", sess)
+		case globalDryRun:
+			fmt.Printf("  %s: would reset %s\n", sess, strings.Join(opts, ", "))
+		default:
+			fmt.Printf("  %s: reset %s\n", sess, strings.Join(opts, ", "))
+		}
+	}
 
-		// Determine theme and identity for this session
-		var theme tmux.Theme
-		var rig, worker, role string
-
-		if sess == mayorSession {
-			theme = tmux.MayorTheme()
-			worker = "Mayor"
-			role = "coordinator"
-		} else if sess == deaconSession {
-			theme = tmux.DeaconTheme()
-			worker = "Deacon"
-			role = "health-check"
-		} else if strings.HasSuffix(sess, "-witness") && strings.HasPrefix(sess, "gt-") {
-			// Witness sessions: gt-<rig>-witness
-			rig = strings.TrimPrefix(strings.TrimSuffix(sess, "-witness"), "gt-")
-			theme = getThemeForRole(rig, "witness")
-			worker = "witness"
-			role = "witness"
-		} else {
-			// Parse session name: gt-<rig>-<worker> or gt-<rig>-crew-<name>
-			parts := strings.SplitN(sess, "-", 3)
-			if len(parts) < 3 {
+	if len(targets) == 0 {
+		fmt.Println("No matching sessions found")
+		return nil
+	}
+	if globalDryRun {
+		fmt.Printf("\nWould reset theme on %d session(s)\n", reset)
+	} else {
+		fmt.Printf("\nReset theme on %d session(s)\n", reset)
+	}
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "%d session(s) failed\n", failed)
+	}
+	return nil
+}
+
+// applyThemeToSessions themes every running Gas Town session in scope for
+// rigName (all sessions when all is set). Per-session failures are reported
+// and skipped rather than aborting the whole run.
+func applyThemeToSessions(rigName string, all bool) error {
+	t := themeTmux()
+	if t.Socket() != "" {
+		if err := t.CheckServer(); err != nil {
+			return err
+		}
+	}
+
+	// Get all sessions
+	sessions, err := t.ListSessions()
+	if err != nil {
+		return fmt.Errorf("listing sessions: %w", err)
+	}
+
+	// Skip sessions outside the targeted rig (unless --all flag)
+	var targets []string
+	for _, sess := range sessions {
+		if rig, _, role, ok := parseThemeSession(sess); ok && themeSessionInScope(rig, role, rigName, all) {
+			targets = append(targets, sess)
+		}
+	}
+
+	// Apply to matching sessions, with colors the terminal can show
+	caps := tmux.DetectColorCaps()
+	progress := newThemeProgress(len(targets))
+	applied, failed, skipped, timedOut := 0, 0, 0, 0
+	tally := make(themeApplyTally)
+	quiet := themeQuietFlag || themeApplyJSON
+	snapshots := make(map[string]tmux.StatusSnapshot) // --atomic: sessions touched so far
+	var touched, appliedSessions, customized []string
+	for i, sess := range targets {
+		rig, worker, role, _ := parseThemeSession(sess)
+		result := tally.rig(rig)
+		progress.step(i+1, sess)
+
+		theme := sessionTheme(rig, worker, role).ForCaps(caps)
+
+		if cur, err := t.CurrentTheme(sess); err == nil {
+			if themeOnlyMissing && cur.Equal(theme) {
+				skipped++
+				result.Skipped++
 				continue
 			}
-			rig = parts[1]
-
-			// Skip if not matching current rig (unless --all flag)
-			if !themeApplyAllFlag && rigName != "" && rig != rigName {
+			if !themeForceFlag && themeLooksCustomized(cur, rig, theme) {
+				progress.clear()
+				if !themeOnlyMissing {
+					fmt.Fprintf(os.Stderr, "  %s: looks manually customized (%s); skipping\n", sess, cur.Style())
+				}
+				customized = append(customized, sess)
+				result.Skipped++
 				continue
 			}
+		}
 
-			workerPart := parts[2]
-			if strings.HasPrefix(workerPart, "crew-") {
-				worker = strings.TrimPrefix(workerPart, "crew-")
-				role = "crew"
-			} else if workerPart == "refinery" {
-				worker = "refinery"
-				role = "refinery"
-			} else {
-				worker = workerPart
-				role = "polecat"
+		if globalDryRun {
+			progress.clear()
+			if !quiet {
+				fmt.Printf("  %s: would apply %s theme\n", sess, theme.Name)
 			}
+			applied++
+			result.Applied++
+			continue
+		}
 
-			// Use role-based theme resolution
-			theme = getThemeForRole(rig, role)
+		if themeAtomicFlag {
+			snap, err := t.SnapshotStatus(sess)
+			if err != nil {
+				progress.clear()
+				fmt.Fprintf(os.Stderr, "  %s: failed to snapshot (%v)\n", sess, err)
+				failed++
+				result.Failed++
+				continue
+			}
+			snapshots[sess] = snap
+			touched = append(touched, sess)
 		}
 
-		// Apply theme and status format
-		if err := t.ApplyTheme(sess, theme); err != nil {
-			fmt.Printf("  %s: failed (%v)\n", sess, err)
+		err := applyThemeToSession(t, sess, rig, worker, role, theme)
+		progress.clear()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "  %s: %v\n", sess, err)
+			failed++
+			result.Failed++
+			if errors.Is(err, tmux.ErrTmuxTimeout) {
+				timedOut++
+			}
 			continue
 		}
-		if err := t.SetStatusFormat(sess, rig, worker, role); err != nil {
-			fmt.Printf("  %s: failed to set format (%v)\n", sess, err)
-			continue
+
+		if !quiet {
+			fmt.Printf("  %s: applied %s theme\n", sess, theme.Name)
 		}
-		if err := t.SetDynamicStatus(sess); err != nil {
-			fmt.Printf("  %s: failed to set dynamic status (%v)\n", sess, err)
-			continue
+		applied++
+		result.Applied++
+		appliedSessions = append(appliedSessions, sess)
+	}
+	progress.clear()
+
+	if themeAtomicFlag && themeApplyShouldRollback(applied, failed, themeStrictFlag) {
+		fmt.Fprintf(os.Stderr, "\n%s %d of %d session(s) failed; rolling back\n",
+			style.Warning.Render("⚠"), failed, applied+failed)
+		restored := 0
+		for _, sess := range touched {
+			if err := t.RestoreStatus(sess, snapshots[sess]); err != nil {
+				fmt.Fprintf(os.Stderr, "  %s: rollback failed (%v)\n", sess, err)
+				continue
+			}
+			restored++
 		}
+		if restored == len(touched) {
+			fmt.Fprintf(os.Stderr, "Restored %d session(s) to their previous theme; nothing was changed\n", restored)
+		} else {
+			fmt.Fprintf(os.Stderr, "Restored %d of %d session(s); %d may be left partially themed\n",
+				restored, len(touched), len(touched)-restored)
+		}
+		return fmt.Errorf("theme apply aborted: %d of %d session(s) failed", failed, applied+failed)
+	}
 
-		fmt.Printf("  %s: applied %s theme\n", sess, theme.Name)
-		applied++
+	if themeApplyJSON {
+		if !globalDryRun && applied > 0 {
+			runThemePostApplyHook(rigName, all, appliedSessions, failed)
+		}
+		return printThemeApplyJSON(tally.summary())
 	}
 
-	if applied == 0 {
+	if applied == 0 && failed == 0 && skipped == 0 && len(customized) == 0 {
 		fmt.Println("No matching sessions found")
+	} else if applied == 0 && failed == 0 && len(customized) == 0 {
+		fmt.Printf("All %d matching session(s) already themed\n", skipped)
+		return nil
+	} else if globalDryRun {
+		fmt.Printf("\nWould apply theme to %d session(s)\n", applied)
 	} else {
 		fmt.Printf("\nApplied theme to %d session(s)\n", applied)
+		if failed > 0 {
+			fmt.Fprintf(os.Stderr, "%d session(s) failed\n", failed)
+		}
+		if timedOut > 0 {
+			fmt.Fprintf(os.Stderr, "%d of them timed out; the tmux server may be wedged (see --timeout)\n", timedOut)
+		}
+	}
+	if skipped > 0 {
+		fmt.Printf("Skipped %d session(s) already themed\n", skipped)
+	}
+	if len(customized) > 0 {
+		fmt.Fprintf(os.Stderr, "%s Skipped %d session(s) that look manually customized (use --force to overwrite): %s\n",
+			style.Warning.Render("⚠"), len(customized), strings.Join(customized, ", "))
+	}
+	if all && len(targets) > 0 {
+		fmt.Println()
+		writeThemeApplySummary(os.Stdout, tally.summary())
+	}
+
+	if !globalDryRun && applied > 0 {
+		runThemePostApplyHook(rigName, all, appliedSessions, failed)
+	}
+
+	return nil
+}
+
+// themeListEntry is one line of 'gt theme --list'.
+type themeListEntry struct {
+	theme  tmux.Theme
+	source string // "built-in", "user", "town", "custom", "rig <name>" or "special"
+	note   string // parenthesized suffix, without the parentheses ("" for none)
+}
+
+// themeGroupModes are the accepted --group-by values.
+var themeGroupModes = []string{"source", "tone", "temperature"}
+
+// themeListEntries gathers every theme visible from the current rig in
+// --list order: built-ins, special (Mayor, Deacon, unknown-rig), town
+// custom themes, then rig-local theme_defs.
+func themeListEntries() []themeListEntry {
+	custom := loadSourcedCustomThemes()
+	overrides := make(map[string]string) // built-in name -> search path layer overriding it
+	for _, theme := range custom {
+		if tmux.GetThemeByName(theme.Name) != nil {
+			overrides[theme.Name] = themeSourceLayer(theme.Source)
+		}
+	}
+
+	var entries []themeListEntry
+	for _, name := range tmux.ListThemeNames() {
+		e := themeListEntry{theme: *tmux.GetThemeByName(name), source: "built-in"}
+		if layer, ok := overrides[name]; ok {
+			e.note = "overridden by the " + layer + " theme"
+		}
+		entries = append(entries, e)
+	}
+	entries = append(entries,
+		themeListEntry{theme: tmux.MayorTheme(), source: "special", note: "Mayor only"},
+		themeListEntry{theme: tmux.DeaconTheme(), source: "special", note: "Deacon only"},
+		themeListEntry{theme: unknownRigTheme(), source: "special", note: "rig not detected"},
+	)
+	townRoot, _ := workspace.FindFromCwd()
+	for _, theme := range custom {
+		layer := themeSourceLayer(theme.Source)
+		note := "custom"
+		if layer != "custom" {
+			note = "from " + displayThemeSource(theme.Source, townRoot)
+		}
+		entries = append(entries, themeListEntry{theme: theme.Theme, source: layer, note: note})
+	}
+	if rigName := detectCurrentRig(); rigName != "" {
+		for _, theme := range loadRigThemeDefs(rigName) {
+			note := "rig " + rigName + " only"
+			if resolveThemeByName(theme.Name) != nil {
+				note += ", overrides the town/built-in theme"
+			}
+			entries = append(entries, themeListEntry{theme: theme, source: "rig " + rigName, note: note})
+		}
 	}
+	return entries
+}
 
+// themeGroup returns the section an entry belongs to under a --group-by
+// mode. Special themes always get their own section.
+func themeGroup(e themeListEntry, mode string) string {
+	if e.source == "special" || mode == "source" {
+		return e.source
+	}
+	var group string
+	if mode == "tone" {
+		group = e.theme.Tone()
+	} else {
+		group = e.theme.Temperature()
+	}
+	if group == "" {
+		return "other"
+	}
+	return group
+}
+
+// listThemes prints the available themes, flat or (with groupBy) in
+// labeled sections ordered by first appearance with special themes last.
+func listThemes(groupBy string) error {
+	if groupBy != "" && !slices.Contains(themeGroupModes, groupBy) {
+		return fmt.Errorf("invalid --group-by %q: want one of %s", groupBy, strings.Join(themeGroupModes, ", "))
+	}
+
+	entries := themeListEntries()
+	printEntry := func(e themeListEntry) {
+		line := fmt.Sprintf("  %-10s  %s", e.theme.Name, style.Dim.Render(e.theme.Style()))
+		if e.note != "" {
+			line += " " + style.Dim.Render("("+e.note+")")
+		}
+		fmt.Println(line + invalidThemeNote(e.theme))
+	}
+
+	if groupBy == "" {
+		fmt.Println(style.Header.Render("Available themes:"))
+		for _, e := range entries {
+			printEntry(e)
+		}
+		return nil
+	}
+
+	var order []string
+	groups := make(map[string][]themeListEntry)
+	for _, e := range entries {
+		g := themeGroup(e, groupBy)
+		if _, seen := groups[g]; !seen && g != "special" {
+			order = append(order, g)
+		}
+		groups[g] = append(groups[g], e)
+	}
+	order = append(order, "special")
+
+	fmt.Println(style.Header.Render("Available themes by " + groupBy + ":"))
+	for _, g := range order {
+		fmt.Printf("\n%s\n", style.Bold.Render(g+":"))
+		for _, e := range groups[g] {
+			printEntry(e)
+		}
+	}
 	return nil
 }
 
+// invalidThemeNote returns a --list suffix flagging a theme whose colors
+// tmux would reject, or "" for a valid theme.
+func invalidThemeNote(theme tmux.Theme) string {
+	if err := theme.Validate(); err != nil {
+		return " " + style.Warning.Render("⚠ "+err.Error())
+	}
+	return ""
+}
+
+// themeProgress draws a "[n/total] session" progress line on stderr while
+// sessions are themed. It is a no-op under --quiet or when stderr is not a
+// terminal, so piped and scripted output is unaffected.
+type themeProgress struct {
+	w       io.Writer
+	total   int
+	enabled bool
+	drawn   bool
+}
+
+func newThemeProgress(total int) *themeProgress {
+	return &themeProgress{
+		w:       os.Stderr,
+		total:   total,
+		enabled: !themeQuietFlag && term.IsTerminal(int(os.Stderr.Fd())),
+	}
+}
+
+// step replaces the progress line with session n of total.
+func (p *themeProgress) step(n int, sess string) {
+	if !p.enabled {
+		return
+	}
+	fmt.Fprintf(p.w, "\r\033[K[%d/%d] %s", n, p.total, sess)
+	p.drawn = true
+}
+
+// clear erases the progress line so other output starts on a clean line.
+func (p *themeProgress) clear() {
+	if !p.drawn {
+		return
+	}
+	fmt.Fprint(p.w, "\r\033[K")
+	p.drawn = false
+}
+
+// themeAtomicFailureThreshold is the failure rate above which
+// 'gt theme apply --atomic' rolls back every session it touched.
+const themeAtomicFailureThreshold = 0.5
+
+// themeApplyShouldRollback reports whether an --atomic apply should be
+// undone: on any failure in strict mode, otherwise when more than
+// themeAtomicFailureThreshold of the attempted sessions failed.
+func themeApplyShouldRollback(applied, failed int, strict bool) bool {
+	if failed == 0 {
+		return false
+	}
+	if strict {
+		return true
+	}
+	return float64(failed)/float64(applied+failed) > themeAtomicFailureThreshold
+}
+
+// themeLooksCustomized reports whether a session's current status colors
+// look set by hand: set, but not the expected theme or any other theme gt
+// could have applied for rig, as-is, downgraded for a 256- or 8-color
+// terminal (maybe not this one), or dimmed for idleness.
+func themeLooksCustomized(cur tmux.Theme, rig string, expected tmux.Theme) bool {
+	if cur.BG == "" && cur.FG == "" {
+		return false
+	}
+	_, ok := matchAppliedTheme(cur, appliableThemes(rig, expected))
+	return !ok
+}
+
+// appliableThemes lists every theme gt could have applied to a session in
+// rig: expected first, then the built-in, special, town custom and
+// rig-local themes.
+func appliableThemes(rig string, expected tmux.Theme) []tmux.Theme {
+	known := append([]tmux.Theme{expected}, tmux.Palette()...)
+	known = append(known, tmux.MayorTheme(), tmux.DeaconTheme(), unknownRigTheme())
+	known = append(known, loadCustomThemes()...)
+	if rig != "" {
+		known = append(known, loadRigThemeDefs(rig)...)
+	}
+	return known
+}
+
+// matchAppliedTheme returns the first of themes whose colors cur has, as-is,
+// downgraded for a 256- or 8-color terminal, or dimmed for idleness.
+func matchAppliedTheme(cur tmux.Theme, themes []tmux.Theme) (tmux.Theme, bool) {
+	for _, theme := range themes {
+		for _, variant := range []tmux.Theme{theme, theme.ForCaps(tmux.Colors256), theme.ForCaps(tmux.Colors8)} {
+			if cur.Equal(variant) || cur.Equal(variant.Dimmed()) {
+				return theme, true
+			}
+		}
+	}
+	return tmux.Theme{}, false
+}
+
+// applyThemeToSession applies the theme and status format to one session.
+// The core styling goes out in a single tmux invocation (tmux.ApplyAll);
+// the status template, indicators and window tabs follow separately. A theme
+// with colors tmux would reject is refused before tmux is touched.
+func applyThemeToSession(t *tmux.Tmux, sess, rig, worker, role string, theme tmux.Theme) error {
+	tc := loadRigThemeConfig(rig)
+	if tc != nil && tc.StatusPosition != "" {
+		theme.StatusPosition = tc.StatusPosition
+	}
+	if tc != nil && tc.StatusCenter != "" {
+		theme.StatusCenter = tc.StatusCenter
+	}
+	if err := theme.Validate(); err != nil {
+		return fmt.Errorf("not applied (%v)", err)
+	}
+	label := rigDisplayName(rig)
+	if err := t.ApplyAll(sess, theme, label, worker, role); err != nil {
+		return fmt.Errorf("failed (%w)", err)
+	}
+	if tc != nil && tc.StatusLeft != "" {
+		if err := t.SetStatusTemplate(sess, tc.StatusLeft); err != nil {
+			return fmt.Errorf("failed to set status template (%v)", err)
+		}
+	}
+	if interval := themeRefreshInterval(tc, loadTownThemeConfig()); interval > 0 {
+		if err := t.SetStatusInterval(sess, interval); err != nil {
+			return fmt.Errorf("failed to set refresh interval (%w)", err)
+		}
+	}
+	if tc != nil {
+		if err := t.SetStatusLengths(sess, tc.StatusLeftLength, tc.StatusRightLength); err != nil {
+			return fmt.Errorf("failed to set status lengths (%w)", err)
+		}
+	}
+	if err := t.SetIndicatorStyle(sess, theme); err != nil {
+		return fmt.Errorf("failed to set indicator style (%w)", err)
+	}
+	if themeWindowsFlag {
+		if err := t.SetWindowStatusStyle(sess, theme); err != nil {
+			return fmt.Errorf("failed to set window style (%v)", err)
+		}
+	}
+	if themeTitlesFlag || terminalTitleEnabled(tc, loadTownThemeConfig()) {
+		if err := t.SetPaneTitleFormat(sess, label, worker, role); err != nil {
+			return fmt.Errorf("failed to set titles (%w)", err)
+		}
+	}
+	if themeGradientFlag {
+		if err := t.SetPaneAccents(sess, theme); err != nil {
+			return fmt.Errorf("failed to set pane accents (%w)", err)
+		}
+	}
+	if themeCopyModeFlag {
+		if err := t.SetModeStyle(sess, theme); err != nil {
+			return fmt.Errorf("failed to set copy-mode style (%w)", err)
+		}
+	}
+	if themeDimInactive {
+		if err := t.SetWindowStyles(sess, theme); err != nil {
+			return fmt.Errorf("failed to set pane styles (%w)", err)
+		}
+	}
+	return nil
+}
+
+// themeRefreshInterval returns the configured status bar refresh interval
+// in seconds: the rig's theme.refresh_interval, then the town's. 0 means
+// neither is set and the default from SetDynamicStatus stays.
+func themeRefreshInterval(rig *config.ThemeConfig, town *config.TownThemeConfig) int {
+	if rig != nil && rig.RefreshInterval > 0 {
+		return rig.RefreshInterval
+	}
+	if town != nil && town.RefreshInterval > 0 {
+		return town.RefreshInterval
+	}
+	return 0
+}
+
+// rigDisplayName returns the name rigName is shown as in status bars and
+// titles (config.RigDisplayName); the rig name itself outside a town.
+func rigDisplayName(rigName string) string {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return rigName
+	}
+	return config.RigDisplayName(townRoot, rigName)
+}
+
+// terminalTitleEnabled reports whether window names and the terminal title
+// are set from the agent identity on every apply: theme.terminal_title in
+// the rig's settings or the town's.
+func terminalTitleEnabled(rig *config.ThemeConfig, town *config.TownThemeConfig) bool {
+	return rig != nil && rig.TerminalTitle || town != nil && town.TerminalTitle
+}
+
+// parseThemeSession extracts the identity used for theming from a session name.
+// Town-level sessions (Mayor, Deacon) return an empty rig. Returns ok=false for
+// sessions that are not Gas Town agent sessions.
+func parseThemeSession(sess string) (rig, worker, role string, ok bool) {
+	switch sess {
+	case session.MayorSessionName():
+		return "", "Mayor", "coordinator", true
+	case session.DeaconSessionName():
+		return "", "Deacon", "health-check", true
+	}
+
+	if !strings.HasPrefix(sess, session.Prefix) {
+		return "", "", "", false
+	}
+
+	// Witness sessions: gt-<rig>-witness
+	if strings.HasSuffix(sess, "-witness") {
+		rig = strings.TrimPrefix(strings.TrimSuffix(sess, "-witness"), session.Prefix)
+		return rig, "witness", "witness", rig != ""
+	}
+
+	// Parse session name: gt-<rig>-<worker> or gt-<rig>-crew-<name>
+	parts := strings.SplitN(sess, "-", 3)
+	if len(parts) < 3 {
+		return "", "", "", false
+	}
+	rig = parts[1]
+
+	workerPart := parts[2]
+	if strings.HasPrefix(workerPart, "crew-") {
+		return rig, strings.TrimPrefix(workerPart, "crew-"), "crew", true
+	} else if workerPart == "refinery" {
+		return rig, "refinery", "refinery", true
+	}
+	return rig, workerPart, "polecat", true
+}
+
+// themeApplyInScope reports whether a session belonging to sessRig should be
+// themed when targetRig is the detected or --rig target. All sessions are in
+// scope with --all or when no rig could be determined; otherwise only that
+// rig's sessions are, so town-level sessions (empty sessRig) are skipped too.
+func themeApplyInScope(sessRig, targetRig string, all bool) bool {
+	if all || targetRig == "" {
+		return true
+	}
+	return sessRig == targetRig
+}
+
+// themeSessionInScope is themeApplyInScope for a session with the given
+// role, letting --include/--exclude-mayor and --include/--exclude-deacon
+// override the scope of the town-level sessions.
+func themeSessionInScope(sessRig, role, targetRig string, all bool) bool {
+	switch {
+	case role == "coordinator" && themeExcludeMayor, role == "health-check" && themeExcludeDeacon:
+		return false
+	case role == "coordinator" && themeIncludeMayor, role == "health-check" && themeIncludeDeacon:
+		return true
+	}
+	return themeApplyInScope(sessRig, targetRig, all)
+}
+
 // detectCurrentRig determines the rig from environment or cwd.
 func detectCurrentRig() string {
+	rig, _ := detectCurrentRigWithReason()
+	return rig
+}
+
+// detectCurrentRigWithReason is detectCurrentRig that also explains how
+// the rig was found, or why it couldn't be (for 'gt whoami').
+func detectCurrentRigWithReason() (rig, reason string) {
 	// Try environment first (GT_RIG is set in tmux sessions)
 	if rig := os.Getenv("GT_RIG"); rig != "" {
-		return rig
+		return rig, "GT_RIG"
 	}
 
 	// Try to extract from tmux session name
@@ -243,43 +1210,59 @@ func detectCurrentRig() string {
 		// Extract rig from session name: gt-<rig>-...
 		parts := strings.SplitN(session, "-", 3)
 		if len(parts) >= 2 && parts[0] == "gt" && parts[1] != "mayor" && parts[1] != "deacon" {
-			return parts[1]
+			return parts[1], "session name " + session
 		}
 	}
 
 	// Try to detect from actual cwd path
 	cwd, err := os.Getwd()
 	if err != nil {
-		return ""
+		return "", "cannot read cwd: " + err.Error()
 	}
 
 	// Find town root to extract rig name
 	townRoot, err := workspace.FindFromCwd()
 	if err != nil || townRoot == "" {
-		return ""
+		return "", "GT_RIG unset and cwd not inside a Gas Town workspace"
+	}
+
+	// Nearest enclosing rig (handles nested layouts like group/rig-a)
+	if rig := config.FindRigFromDir(townRoot, cwd); rig != "" {
+		return rig, "rig config.json above cwd"
 	}
 
 	// Get path relative to town root
 	rel, err := filepath.Rel(townRoot, cwd)
 	if err != nil {
-		return ""
+		return "", "cwd not under town root"
 	}
 
 	// Extract first path component (rig name)
 	// Patterns: <rig>/..., mayor/..., deacon/...
 	parts := strings.Split(rel, string(filepath.Separator))
 	if len(parts) > 0 && parts[0] != "." && parts[0] != "mayor" && parts[0] != "deacon" {
-		return parts[0]
+		return parts[0], "first path component of cwd"
 	}
 
-	return ""
+	if len(parts) > 0 && parts[0] != "." {
+		return "", "cwd is in town-level " + parts[0] + "/, not under any rig"
+	}
+	return "", "cwd is the town root, not under any rig"
 }
 
-// getThemeForRig returns the theme for a rig, checking config first.
+// getThemeForRig returns the theme for a rig. Precedence: GT_TMUX_THEME,
+// then the rig's config, then the hash-based assignment.
 func getThemeForRig(rigName string) tmux.Theme {
+	if theme := themeOverride(rigName); theme != nil {
+		return *theme
+	}
+	if rigName == unknownRigName {
+		return unknownRigTheme()
+	}
+
 	// Try to load configured theme
 	if themeName := loadRigTheme(rigName); themeName != "" {
-		if theme := tmux.GetThemeByName(themeName); theme != nil {
+		if theme := resolveRigThemeByName(rigName, themeName); theme != nil {
 			return *theme
 		}
 	}
@@ -287,6 +1270,40 @@ func getThemeForRig(rigName string) tmux.Theme {
 	return tmux.AssignTheme(rigName)
 }
 
+// sessionTheme returns the theme for a session identity as returned by
+// parseThemeSession: the Mayor and Deacon themes for the town sessions,
+// otherwise the rig's theme for the worker (getThemeForWorker). While
+// another rig is focused (gt theme focus) the theme is dimmed.
+func sessionTheme(rig, worker, role string) tmux.Theme {
+	var theme tmux.Theme
+	switch role {
+	case "coordinator":
+		theme = tmux.MayorTheme()
+	case "health-check":
+		theme = tmux.DeaconTheme()
+	default:
+		theme = getThemeForWorker(rig, worker, role)
+	}
+	if themeFocusDims(rig, loadTownThemeConfig()) {
+		theme = theme.Dimmed()
+	}
+	return theme
+}
+
+// getThemeForWorker returns the theme for one worker in a rig: the
+// worker's own override (theme.workers in the rig settings), then
+// getThemeForRole.
+func getThemeForWorker(rigName, worker, role string) tmux.Theme {
+	if tc := loadRigThemeConfig(rigName); tc != nil && worker != "" {
+		if themeName, ok := tc.Workers[worker]; ok {
+			if theme := resolveRigThemeByName(rigName, themeName); theme != nil {
+				return *theme
+			}
+		}
+	}
+	return getThemeForRole(rigName, role)
+}
+
 // getThemeForRole returns the theme for a specific role in a rig.
 // Resolution order:
 // 1. Per-rig role override (rig/settings/config.json)
@@ -298,11 +1315,11 @@ func getThemeForRole(rigName, role string) tmux.Theme {
 
 	// 1. Check per-rig role override
 	if townRoot != "" {
-		settingsPath := filepath.Join(townRoot, rigName, "settings", "config.json")
+		settingsPath := filepath.Join(config.RigDir(townRoot, rigName), "settings", "config.json")
 		if settings, err := config.LoadRigSettings(settingsPath); err == nil {
 			if settings.Theme != nil && settings.Theme.RoleThemes != nil {
 				if themeName, ok := settings.Theme.RoleThemes[role]; ok {
-					if theme := tmux.GetThemeByName(themeName); theme != nil {
+					if theme := resolveRigThemeByName(rigName, themeName); theme != nil {
 						return *theme
 					}
 				}
@@ -316,7 +1333,7 @@ func getThemeForRole(rigName, role string) tmux.Theme {
 		if mayorCfg, err := config.LoadMayorConfig(mayorConfigPath); err == nil {
 			if mayorCfg.Theme != nil && mayorCfg.Theme.RoleDefaults != nil {
 				if themeName, ok := mayorCfg.Theme.RoleDefaults[role]; ok {
-					if theme := tmux.GetThemeByName(themeName); theme != nil {
+					if theme := resolveRigThemeByName(rigName, themeName); theme != nil {
 						return *theme
 					}
 				}
@@ -327,7 +1344,7 @@ func getThemeForRole(rigName, role string) tmux.Theme {
 	// 3. Check built-in role defaults
 	builtins := config.BuiltinRoleThemes()
 	if themeName, ok := builtins[role]; ok {
-		if theme := tmux.GetThemeByName(themeName); theme != nil {
+		if theme := resolveRigThemeByName(rigName, themeName); theme != nil {
 			return *theme
 		}
 	}
@@ -336,21 +1353,314 @@ func getThemeForRole(rigName, role string) tmux.Theme {
 	return getThemeForRig(rigName)
 }
 
-// loadRigTheme loads the theme name from rig settings.
-func loadRigTheme(rigName string) string {
+// loadRigThemeConfig loads the theme section of a rig's settings.
+// Returns nil if the rig has no settings or no theme configured.
+// applyIdleDim switches a worker session between its theme and the dimmed
+// variant depending on how long the session has been idle. It runs from the
+// periodic status-line refresh and does nothing unless the rig sets
+// theme.idle_dim_minutes.
+func applyIdleDim(t *tmux.Tmux, townRoot, session, rigName, worker, role string) {
+	settings, err := config.LoadRigSettings(filepath.Join(config.RigDir(townRoot, rigName), "settings", "config.json"))
+	if err != nil || settings.Theme == nil || settings.Theme.IdleDimMinutes <= 0 {
+		return
+	}
+	last, err := t.SessionActivity(session)
+	if err != nil {
+		return
+	}
+
+	theme := getThemeForWorker(rigName, worker, role)
+	if sessionIsIdle(last, time.Now(), settings.Theme.IdleDimMinutes) {
+		theme = theme.Dimmed()
+	}
+	_ = t.ApplyTheme(session, theme) // best-effort; retried on the next refresh
+}
+
+// sessionIsIdle reports whether lastActivity is at least minutes before now.
+func sessionIsIdle(lastActivity, now time.Time, minutes int) bool {
+	return minutes > 0 && now.Sub(lastActivity) >= time.Duration(minutes)*time.Minute
+}
+
+// resolveThemeByName finds a theme among the custom themes on the search
+// path (loadCustomThemes) or, failing that, in the default palette, so a
+// themes file can redefine a built-in theme. Returns nil if not found.
+func resolveThemeByName(name string) *tmux.Theme {
+	if theme := findTheme(loadCustomThemes(), name); theme != nil {
+		return theme
+	}
+	return tmux.GetThemeByName(name)
+}
+
+// resolveRigThemeByName resolves a theme name as seen from within a rig:
+// the rig's own theme_defs first, then resolveThemeByName.
+func resolveRigThemeByName(rigName, name string) *tmux.Theme {
+	if theme := findTheme(loadRigThemeDefs(rigName), name); theme != nil {
+		return theme
+	}
+	return resolveThemeByName(name)
+}
+
+// errUnknownTheme is returned for theme names that resolve to no theme.
+var errUnknownTheme = errors.New("unknown theme")
+
+// matchThemeName resolves what the user typed to a theme name visible from
+// rigName (rig-local, custom and built-in themes; rigName may be empty).
+// An exact match wins; otherwise a unique case-insensitive prefix is
+// accepted, so "for" selects "forest". Ambiguous prefixes fail with the
+// candidates listed.
+func matchThemeName(rigName, input string) (string, error) {
+	if resolveRigThemeByName(rigName, input) != nil {
+		return input, nil
+	}
+
+	seen := make(map[string]bool)
+	var candidates []string
+	names := tmux.ListThemeNames()
+	for _, theme := range loadCustomThemes() {
+		names = append(names, theme.Name)
+	}
+	for _, theme := range loadRigThemeDefs(rigName) {
+		names = append(names, theme.Name)
+	}
+	for _, name := range names {
+		if !seen[name] && input != "" && strings.HasPrefix(strings.ToLower(name), strings.ToLower(input)) {
+			seen[name] = true
+			candidates = append(candidates, name)
+		}
+	}
+	sort.Strings(candidates)
+
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("%w: %s (use 'gt theme --list' to see available themes)", errUnknownTheme, input)
+	case 1:
+		return candidates[0], nil
+	default:
+		return "", fmt.Errorf("ambiguous theme %q matches: %s", input, strings.Join(candidates, ", "))
+	}
+}
+
+// findTheme returns the theme with the given name from themes, or nil.
+func findTheme(themes []tmux.Theme, name string) *tmux.Theme {
+	for _, theme := range themes {
+		if theme.Name == name {
+			return &theme
+		}
+	}
+	return nil
+}
+
+// loadRigThemeDefs returns the themes embedded in a rig's settings
+// (theme.theme_defs), sorted by name.
+func loadRigThemeDefs(rigName string) []tmux.Theme {
+	tc := loadRigThemeConfig(rigName)
+	if tc == nil {
+		return nil
+	}
+
+	themes := make([]tmux.Theme, 0, len(tc.ThemeDefs))
+	for name, def := range tc.ThemeDefs {
+		themes = append(themes, tmux.Theme{Name: name, BG: def.BG, FG: def.FG, StatusPosition: def.StatusPosition, StatusCenter: def.StatusCenter})
+	}
+	sort.Slice(themes, func(i, j int) bool { return themes[i].Name < themes[j].Name })
+	return themes
+}
+
+// themeOverride returns the theme named by GT_TMUX_THEME as seen from
+// rigName, or nil when it is unset. An unknown or invalid name is ignored
+// with a warning (printed once per process).
+func themeOverride(rigName string) *tmux.Theme {
+	name := strings.TrimSpace(os.Getenv(themeOverrideEnv))
+	if name == "" {
+		return nil
+	}
+	theme := resolveRigThemeByName(rigName, name)
+	if theme == nil {
+		themeOverrideWarning.Do(func() {
+			fmt.Fprintf(os.Stderr, "%s Warning: %s=%s is not a known theme; ignoring it\n",
+				style.Dim.Render("⚠"), themeOverrideEnv, name)
+		})
+		return nil
+	}
+	if err := theme.Validate(); err != nil {
+		themeOverrideWarning.Do(func() {
+			fmt.Fprintf(os.Stderr, "%s Warning: ignoring %s: %v\n", style.Dim.Render("⚠"), themeOverrideEnv, err)
+		})
+		return nil
+	}
+	return theme
+}
+
+var themeOverrideWarning sync.Once
+
+// unknownRigTheme returns the theme for an undetected rig: the town's
+// configured "unknown" theme (mayor/config.json), or tmux.UnknownTheme.
+func unknownRigTheme() tmux.Theme {
+	if tc := loadTownThemeConfig(); tc != nil && tc.Unknown != "" && tc.Unknown != unknownRigName {
+		if theme := resolveThemeByName(tc.Unknown); theme != nil {
+			return *theme
+		}
+	}
+	return tmux.UnknownTheme()
+}
+
+// loadTownThemeConfig returns the town-wide theme settings from
+// mayor/config.json, or nil if there are none.
+func loadTownThemeConfig() *config.TownThemeConfig {
 	townRoot, err := workspace.FindFromCwd()
 	if err != nil || townRoot == "" {
-		return ""
+		return nil
+	}
+	mayorCfg, err := config.LoadMayorConfig(filepath.Join(townRoot, "mayor", "config.json"))
+	if err != nil {
+		return nil
+	}
+	return mayorCfg.Theme
+}
+
+// initThemeSeed sets the seed for default theme assignment from the town's
+// theme.seed, unless GT_THEME_SEED already set it.
+func initThemeSeed() {
+	if _, ok := os.LookupEnv(tmux.ThemeSeedEnv); ok {
+		return
+	}
+	if tc := loadTownThemeConfig(); tc != nil {
+		tmux.SetThemeSeed(tc.Seed)
+	}
+}
+
+// themeTmux returns the tmux wrapper for theme operations. GT_TMUX_SOCKET
+// wins; otherwise the town's theme.tmux_socket is used, falling back to the
+// default server.
+func themeTmux() *tmux.Tmux {
+	t := tmux.NewTmux()
+	if os.Getenv(tmux.SocketEnv) == "" {
+		if tc := loadTownThemeConfig(); tc != nil && tc.TmuxSocket != "" {
+			t = tmux.NewTmuxWithSocket(tc.TmuxSocket)
+		}
+	}
+	if themeTimeoutFlag > 0 {
+		t.SetTimeout(themeTimeoutFlag)
+	}
+	return t
+}
+
+// loadCustomThemes returns the custom themes on the search path, sorted by
+// name (see loadSourcedCustomThemes).
+func loadCustomThemes() []tmux.Theme {
+	sourced := loadSourcedCustomThemes()
+	themes := make([]tmux.Theme, 0, len(sourced))
+	for _, theme := range sourced {
+		themes = append(themes, theme.Theme)
+	}
+	return themes
+}
+
+// themeSourcesWarning makes a broken themes file warn once per process.
+var themeSourcesWarning sync.Once
+
+// userThemesDir is where personal themes files live.
+func userThemesDir() string {
+	return filepath.Join(state.ConfigDir(), "themes")
+}
+
+// townThemesDir is where a town's shared themes files live.
+func townThemesDir(townRoot string) string {
+	return filepath.Join(townRoot, ".gastown", "themes")
+}
+
+// loadSourcedCustomThemes returns the themes from every layer of the theme
+// search path above the built-ins, sorted by name, each with the file it
+// came from: the user's themes files, then the town's, then theme.custom in
+// mayor/config.json, later layers winning by name. A themes file that can't
+// be loaded is skipped with a warning, leaving the other layers in place.
+func loadSourcedCustomThemes() []tmux.SourcedTheme {
+	townRoot, _ := workspace.FindFromCwd()
+	dirs := []string{userThemesDir()}
+	if townRoot != "" {
+		dirs = append(dirs, townThemesDir(townRoot))
+	}
+	var paths []string
+	for _, dir := range dirs {
+		files, _ := tmux.ThemeFiles(dir)
+		paths = append(paths, files...)
 	}
+	themes, err := tmux.LoadThemeSources(paths)
+	if err != nil {
+		themeSourcesWarning.Do(func() {
+			fmt.Fprintf(os.Stderr, "%s Warning: %v; ignoring themes files\n", style.Dim.Render("⚠"), err)
+		})
+		themes = nil
+	}
+
+	byName := make(map[string]tmux.SourcedTheme, len(themes))
+	for _, theme := range themes {
+		byName[theme.Name] = theme
+	}
+	if tc := loadTownThemeConfig(); tc != nil {
+		source := filepath.Join(townRoot, "mayor", "config.json")
+		for name, c := range tc.Custom {
+			byName[name] = tmux.SourcedTheme{
+				Theme:  tmux.Theme{Name: name, BG: c.BG, FG: c.FG, StatusPosition: c.StatusPosition, StatusCenter: c.StatusCenter},
+				Source: source,
+			}
+		}
+	}
+
+	merged := make([]tmux.SourcedTheme, 0, len(byName))
+	for _, theme := range byName {
+		merged = append(merged, theme)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Name < merged[j].Name })
+	return merged
+}
+
+// themeSourceLayer names the search path layer of a themes file, as shown
+// by 'gt theme --list': "user", "town" or "custom" (mayor/config.json).
+func themeSourceLayer(source string) string {
+	switch dir := filepath.Dir(source); {
+	case dir == userThemesDir():
+		return "user"
+	case filepath.Base(dir) == "mayor":
+		return "custom"
+	}
+	return "town"
+}
 
-	settingsPath := filepath.Join(townRoot, rigName, "settings", "config.json")
+// displayThemeSource shortens a themes file path for --list: relative to
+// the town root for town files, with ~ for the home directory otherwise.
+func displayThemeSource(source, townRoot string) string {
+	if townRoot != "" {
+		if rel, err := filepath.Rel(townRoot, source); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	if home, err := os.UserHomeDir(); err == nil && home != "" {
+		if rel, err := filepath.Rel(home, source); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.Join("~", rel)
+		}
+	}
+	return source
+}
+
+func loadRigThemeConfig(rigName string) *config.ThemeConfig {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil || townRoot == "" || rigName == "" {
+		return nil
+	}
+
+	settingsPath := filepath.Join(config.RigDir(townRoot, rigName), "settings", "config.json")
 	settings, err := config.LoadRigSettings(settingsPath)
 	if err != nil {
-		return ""
+		return nil
 	}
+	return settings.Theme
+}
 
-	if settings.Theme != nil && settings.Theme.Name != "" {
-		return settings.Theme.Name
+// loadRigTheme loads the theme name from rig settings.
+func loadRigTheme(rigName string) string {
+	if tc := loadRigThemeConfig(rigName); tc != nil {
+		return tc.Name
 	}
 	return ""
 }
@@ -362,31 +1672,47 @@ func saveRigTheme(rigName, themeName string) error {
 		return fmt.Errorf("finding workspace: %w", err)
 	}
 	if townRoot == "" {
-		return fmt.Errorf("not in a Gas Town workspace")
+		return workspace.ErrNotFound
 	}
 
-	settingsPath := filepath.Join(townRoot, rigName, "settings", "config.json")
+	settingsPath := filepath.Join(config.RigDir(townRoot, rigName), "settings", "config.json")
 
-	// Load existing settings or create new
-	var settings *config.RigSettings
-	settings, err = config.LoadRigSettings(settingsPath)
-	if err != nil {
-		// Create new settings if not found
-		if os.IsNotExist(err) || strings.Contains(err.Error(), "not found") {
-			settings = config.NewRigSettings()
-		} else {
+	if globalDryRun {
+		previous := "(default)"
+		if settings, err := config.LoadRigSettings(settingsPath); err == nil && settings.Theme != nil && settings.Theme.Name != "" {
+			previous = settings.Theme.Name
+		} else if err != nil && !errors.Is(err, config.ErrNotFound) {
 			return fmt.Errorf("loading settings: %w", err)
 		}
+		fmt.Printf("Would set theme for rig '%s': %s -> %s\n", rigName, previous, themeName)
+		fmt.Printf("  (would write %s)\n", settingsPath)
+		return nil
 	}
 
-	// Set theme
-	settings.Theme = &config.ThemeConfig{
-		Name: themeName,
+	// Set theme under the settings lock, keeping any role overrides already
+	// configured, so concurrent gt processes don't lose each other's writes
+	var previous string
+	err = config.UpdateRigSettings(settingsPath, func(settings *config.RigSettings) error {
+		if settings.Theme == nil {
+			settings.Theme = &config.ThemeConfig{}
+		}
+		previous = settings.Theme.Name
+		settings.Theme.Name = themeName
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("saving settings: %w", err)
 	}
 
-	// Save
-	if err := config.SaveRigSettings(settingsPath, settings); err != nil {
-		return fmt.Errorf("saving settings: %w", err)
+	// Record the change for 'gt theme history' (non-fatal)
+	change := config.ThemeChange{
+		Timestamp: time.Now(),
+		Previous:  previous,
+		Theme:     themeName,
+		ChangedBy: detectSender(),
+	}
+	if err := config.AppendThemeChange(config.ThemeHistoryPath(config.RigDir(townRoot, rigName)), change); err != nil {
+		fmt.Fprintf(os.Stderr, "%s Warning: failed to record theme history: %v\n", style.Dim.Render("⚠"), err)
 	}
 
 	return nil
@@ -398,7 +1724,7 @@ func runThemeCLI(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("finding workspace: %w", err)
 	}
 	if townRoot == "" {
-		return fmt.Errorf("not in a Gas Town workspace")
+		return workspace.ErrNotFound
 	}
 
 	settingsPath := config.TownSettingsPath(townRoot)
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	themeAdjustName       string
+	themeAdjustLighten    float64
+	themeAdjustDarken     float64
+	themeAdjustSaturate   float64
+	themeAdjustDesaturate float64
+)
+
+var themeAdjustCmd = &cobra.Command{
+	Use:   "adjust <base>",
+	Short: "Derive a custom theme by adjusting a base theme's colors",
+	Long: `Create a custom theme from an existing one by shifting its colors in
+HSL space, and save it to the town's custom themes (mayor/config.json).
+
+Amounts are percentage points applied to both the background and the
+foreground, clamped to 0-100. Results where the two colors end up too
+close in lightness to read (e.g. both pushed to white) are rejected.
+
+The new theme can be used like any palette theme ('gt theme <name>',
+role_themes, role_defaults). Re-running with the same --name replaces it.
+
+Examples:
+  gt theme adjust ocean --lighten 10 --name ocean-light
+  gt theme adjust forest --darken 8 --saturate 15 --name deep-forest
+  gt theme adjust ocean-light --desaturate 20 --name ocean-mist`,
+	Args: cobra.ExactArgs(1),
+	RunE: runThemeAdjust,
+}
+
+func init() {
+	themeCmd.AddCommand(themeAdjustCmd)
+	themeAdjustCmd.Flags().StringVar(&themeAdjustName, "name", "", "Name for the new theme (required)")
+	themeAdjustCmd.Flags().Float64Var(&themeAdjustLighten, "lighten", 0, "Increase lightness by this many points")
+	themeAdjustCmd.Flags().Float64Var(&themeAdjustDarken, "darken", 0, "Decrease lightness by this many points")
+	themeAdjustCmd.Flags().Float64Var(&themeAdjustSaturate, "saturate", 0, "Increase saturation by this many points")
+	themeAdjustCmd.Flags().Float64Var(&themeAdjustDesaturate, "desaturate", 0, "Decrease saturation by this many points")
+	_ = themeAdjustCmd.MarkFlagRequired("name")
+}
+
+func runThemeAdjust(cmd *cobra.Command, args []string) error {
+	baseName, err := matchThemeName("", args[0])
+	if err != nil {
+		return err
+	}
+	base := resolveThemeByName(baseName)
+	if isBuiltinThemeName(themeAdjustName) {
+		return fmt.Errorf("%q is a built-in theme name; choose another --name", themeAdjustName)
+	}
+
+	lighten := themeAdjustLighten - themeAdjustDarken
+	saturate := themeAdjustSaturate - themeAdjustDesaturate
+	if lighten == 0 && saturate == 0 {
+		return fmt.Errorf("nothing to adjust (use --lighten, --darken, --saturate or --desaturate)")
+	}
+
+	theme, err := base.Adjust(themeAdjustName, lighten, saturate)
+	if err != nil {
+		return fmt.Errorf("adjusting %s: %w", baseName, err)
+	}
+
+	if err := saveCustomTheme(theme); err != nil {
+		return fmt.Errorf("saving custom theme: %w", err)
+	}
+
+	fmt.Printf("%s Saved theme '%s' (from %s): %s\n", style.Success.Render("✓"), theme.Name, baseName, theme.Style())
+	fmt.Printf("Use 'gt theme %s' to assign it to a rig\n", theme.Name)
+	return nil
+}
+
+// isBuiltinThemeName reports whether name belongs to a built-in theme
+// (palette, mayor or unknown-rig), which custom themes may not shadow.
+func isBuiltinThemeName(name string) bool {
+	return tmux.GetThemeByName(name) != nil || name == tmux.MayorTheme().Name || name == tmux.UnknownTheme().Name
+}
+
+// saveCustomTheme adds or replaces a named theme in mayor/config.json.
+func saveCustomTheme(theme tmux.Theme) error {
+	if err := theme.Validate(); err != nil {
+		return err
+	}
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("finding workspace: %w", err)
+	}
+
+	path := filepath.Join(townRoot, "mayor", "config.json")
+	mayorCfg, err := config.LoadMayorConfig(path)
+	if err != nil {
+		if !errors.Is(err, config.ErrNotFound) {
+			return err
+		}
+		mayorCfg = config.NewMayorConfig()
+	}
+	if mayorCfg.Theme == nil {
+		mayorCfg.Theme = &config.TownThemeConfig{}
+	}
+	if mayorCfg.Theme.Custom == nil {
+		mayorCfg.Theme.Custom = make(map[string]config.CustomTheme)
+	}
+	mayorCfg.Theme.Custom[theme.Name] = config.CustomTheme{BG: theme.BG, FG: theme.FG, StatusPosition: theme.StatusPosition, StatusCenter: theme.StatusCenter}
+
+	return config.SaveMayorConfig(path, mayorCfg)
+}
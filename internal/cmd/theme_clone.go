@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var themeCloneOverwrite bool
+
+var themeCloneCmd = &cobra.Command{
+	Use:   "clone <src> <newname>",
+	Short: "Copy a theme into a new custom theme for editing",
+	Long: `Copy an existing theme (built-in or custom) into the town's custom themes
+(mayor/config.json) under a new name, as a starting point for your own.
+
+The copy can then be edited in mayor/config.json or derived further with
+'gt theme adjust'. Built-in names can't be used as the new name, and an
+existing custom theme is only replaced with --overwrite.
+
+Examples:
+  gt theme clone forest myforest
+  gt theme clone forest myforest --overwrite`,
+	Args: cobra.ExactArgs(2),
+	RunE: runThemeClone,
+}
+
+func init() {
+	themeCmd.AddCommand(themeCloneCmd)
+	themeCloneCmd.Flags().BoolVar(&themeCloneOverwrite, "overwrite", false, "Replace an existing custom theme with the same name")
+}
+
+func runThemeClone(cmd *cobra.Command, args []string) error {
+	srcName, err := matchThemeName("", args[0])
+	if err != nil {
+		return err
+	}
+	newName := args[1]
+	if isBuiltinThemeName(newName) {
+		return fmt.Errorf("%q is a built-in theme name; choose another name", newName)
+	}
+	if findTheme(loadCustomThemes(), newName) != nil && !themeCloneOverwrite {
+		return fmt.Errorf("custom theme %q already exists (use --overwrite to replace it)", newName)
+	}
+
+	theme := resolveThemeByName(srcName).WithName(newName)
+	if globalDryRun {
+		fmt.Printf("Would save theme '%s' (from %s): %s\n", theme.Name, srcName, theme.Style())
+		return nil
+	}
+	if err := saveCustomTheme(theme); err != nil {
+		return fmt.Errorf("saving custom theme: %w", err)
+	}
+
+	fmt.Printf("%s Saved theme '%s' (from %s): %s\n", style.Success.Render("✓"), theme.Name, srcName, theme.Style())
+	fmt.Printf("Edit it in mayor/config.json or with 'gt theme adjust', then 'gt theme %s' to assign it\n", theme.Name)
+	return nil
+}
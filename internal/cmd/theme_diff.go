@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var themeDiffAllFlag bool
+
+var themeDiffAppliedCmd = &cobra.Command{
+	Use:   "diff-applied",
+	Short: "Show sessions whose status colors differ from their theme",
+	Long: `Compare each running session's status colors with the theme it should have.
+
+For every Gas Town session in scope, the theme 'gt theme apply' would give
+it (from the rig's settings, worker and role overrides) is compared with
+the session's actual status-style. Sessions that don't match are listed
+with their rig, the expected theme and what the session actually shows:
+another theme's name, "not themed" for a session still on the tmux
+defaults, or the raw colors when they match no theme gt knows (changed by
+hand).
+
+A session matches when it has the expected colors as-is, downgraded for a
+256- or 8-color terminal, or dimmed for idleness, so sessions themed from
+another terminal or dimmed by the daemon aren't reported.
+
+Scope works as for 'gt theme apply': the current rig by default, --rig for
+another one, or --all for every rig plus the Mayor and Deacon. The command
+exits non-zero when any session differs, so it can gate scripts; run
+'gt theme apply' to reconcile (with --force for sessions changed by hand).
+
+Examples:
+  gt theme diff-applied
+  gt theme diff-applied --all`,
+	Args: cobra.NoArgs,
+	RunE: runThemeDiffApplied,
+}
+
+func init() {
+	themeDiffAppliedCmd.Flags().StringVar(&themeRigFlag, "rig", "", "Target rig (default: detected from environment)")
+	themeDiffAppliedCmd.Flags().BoolVar(&themeDiffAllFlag, "all", false, "Check sessions in every rig, and the Mayor and Deacon")
+	themeCmd.AddCommand(themeDiffAppliedCmd)
+}
+
+// themeDrift is a session whose status colors differ from its theme.
+type themeDrift struct {
+	rig        string
+	session    string
+	expected   string
+	actual     string
+	customized bool // actual colors match no theme gt could have applied
+}
+
+// describeThemeDrift compares a session's status colors cur with the
+// theme it should have. It returns what the session shows instead, and
+// whether that is colors set by hand, or ok=true if the session has its
+// theme (see matchAppliedTheme).
+func describeThemeDrift(cur tmux.Theme, rig string, expected tmux.Theme) (actual string, customized, ok bool) {
+	if _, ok := matchAppliedTheme(cur, []tmux.Theme{expected}); ok {
+		return "", false, true
+	}
+	if cur.BG == "" && cur.FG == "" {
+		return "not themed", false, false
+	}
+	if theme, ok := matchAppliedTheme(cur, appliableThemes(rig, expected)); ok {
+		return theme.Name, false, false
+	}
+	return cur.Style(), true, false
+}
+
+func runThemeDiffApplied(cmd *cobra.Command, args []string) error {
+	rigName, err := resolveThemeRig()
+	if err != nil {
+		return err
+	}
+	townRoot, _ := workspace.FindFromCwd()
+	if err := checkThemeApplyScope(townRoot, rigName, themeDiffAllFlag); err != nil {
+		return err
+	}
+
+	t := themeTmux()
+	if t.Socket() != "" {
+		if err := t.CheckServer(); err != nil {
+			return err
+		}
+	}
+	sessions, err := t.ListSessions()
+	if err != nil {
+		return fmt.Errorf("listing sessions: %w", err)
+	}
+
+	caps := tmux.DetectColorCaps()
+	var drifts []themeDrift
+	checked, customized := 0, false
+	for _, sess := range sessions {
+		rig, worker, role, ok := parseThemeSession(sess)
+		if !ok || !themeSessionInScope(rig, role, rigName, themeDiffAllFlag) {
+			continue
+		}
+		cur, err := t.CurrentTheme(sess)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %s: reading status colors: %v\n", style.Dim.Render("⚠"), sess, err)
+			continue
+		}
+		checked++
+
+		theme := sessionTheme(rig, worker, role).ForCaps(caps)
+		actual, byHand, ok := describeThemeDrift(cur, rig, theme)
+		if ok {
+			continue
+		}
+		customized = customized || byHand
+		drifts = append(drifts, themeDrift{
+			rig:        rig,
+			session:    sess,
+			expected:   fmt.Sprintf("%s (%s)", theme.Name, theme.Style()),
+			actual:     actual,
+			customized: byHand,
+		})
+	}
+
+	if checked == 0 {
+		fmt.Println("No matching sessions found")
+		return nil
+	}
+	if len(drifts) == 0 {
+		fmt.Printf("%s All %d session(s) have their configured theme\n", style.Success.Render("✓"), checked)
+		return nil
+	}
+
+	writeThemeDrifts(os.Stdout, drifts)
+	reconcile := "gt theme apply"
+	if themeDiffAllFlag {
+		reconcile += " --all"
+	} else if themeRigFlag != "" {
+		reconcile += " --rig " + themeRigFlag
+	}
+	if customized {
+		reconcile += " --force"
+	}
+	fmt.Printf("\nRun '%s' to reconcile\n", reconcile)
+	return fmt.Errorf("%d of %d session(s) differ from their configured theme", len(drifts), checked)
+}
+
+// writeThemeDrifts prints drifted sessions as a table.
+func writeThemeDrifts(w io.Writer, drifts []themeDrift) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RIG\tSESSION\tEXPECTED\tACTUAL")
+	for _, d := range drifts {
+		rig := d.rig
+		if rig == "" {
+			rig = "(town)"
+		}
+		actual := d.actual
+		if d.customized {
+			actual += " (customized)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", rig, d.session, d.expected, actual)
+	}
+	_ = tw.Flush()
+}
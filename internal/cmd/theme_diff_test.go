@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+func TestDescribeThemeDrift(t *testing.T) {
+	setupTestRigForSettings(t)
+
+	expected := *tmux.GetThemeByName("forest")
+	ocean := *tmux.GetThemeByName("ocean")
+	tests := []struct {
+		name           string
+		cur            tmux.Theme
+		wantActual     string
+		wantCustomized bool
+		wantOK         bool
+	}{
+		{name: "expected", cur: expected, wantOK: true},
+		{name: "idle-dimmed", cur: expected.Dimmed(), wantOK: true},
+		{name: "8-color", cur: expected.ForCaps(tmux.Colors8), wantOK: true},
+		{name: "not themed", cur: tmux.Theme{}, wantActual: "not themed"},
+		{name: "another theme", cur: ocean, wantActual: "ocean"},
+		{name: "hand-picked", cur: tmux.Theme{BG: "#123456", FG: "#fedcba"}, wantActual: "bg=#123456,fg=#fedcba", wantCustomized: true},
+	}
+	for _, tt := range tests {
+		actual, customized, ok := describeThemeDrift(tt.cur, "testrig", expected)
+		if actual != tt.wantActual || customized != tt.wantCustomized || ok != tt.wantOK {
+			t.Errorf("%s: describeThemeDrift(%s) = %q, %v, %v; want %q, %v, %v",
+				tt.name, tt.cur.Style(), actual, customized, ok, tt.wantActual, tt.wantCustomized, tt.wantOK)
+		}
+	}
+}
+
+func TestWriteThemeDrifts(t *testing.T) {
+	var buf bytes.Buffer
+	writeThemeDrifts(&buf, []themeDrift{
+		{rig: "gastown", session: "gt-gastown-max", expected: "forest (bg=#1e3a1e,fg=#e0e0e0)", actual: "ocean"},
+		{rig: "", session: "hq-mayor", expected: "mayor (bg=#3d3200,fg=#ffd700)", actual: "bg=#123456,fg=#fedcba", customized: true},
+	})
+	out := buf.String()
+	for _, want := range []string{"RIG", "gt-gastown-max", "ocean", "(town)", "bg=#123456,fg=#fedcba (customized)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("writeThemeDrifts() missing %q:\n%s", want, out)
+		}
+	}
+}
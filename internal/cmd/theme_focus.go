@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var themeFocusCmd = &cobra.Command{
+	Use:   "focus <rig>",
+	Short: "Dim every rig's sessions except one",
+	Long: `Make one rig stand out by muting every other session's theme.
+
+The focused rig's sessions keep their normal theme; every other session,
+including the Mayor and Deacon, gets the dimmed variant of its theme (the
+same one idle sessions get). The focus is saved as theme.focus in
+mayor/config.json, so later 'gt theme apply' runs (and --watch) keep it
+until 'gt theme unfocus'. Sessions started in the meantime come up with
+their normal theme until the next apply.
+
+Both commands re-apply themes to every running session (as 'gt theme apply
+--all'). Focusing another rig moves the focus. With the global --dry-run
+flag nothing is saved or applied.
+
+Examples:
+  gt theme focus gastown    # During an incident: only gastown keeps its colors
+  gt theme unfocus          # Back to normal`,
+	Args: cobra.ExactArgs(1),
+	RunE: runThemeFocus,
+}
+
+var themeUnfocusCmd = &cobra.Command{
+	Use:   "unfocus",
+	Short: "Restore every rig's theme after gt theme focus",
+	Long: `Clear the focus set by 'gt theme focus' and give every running session
+its normal theme again.
+
+With the global --dry-run flag nothing is saved or applied.`,
+	Args: cobra.NoArgs,
+	RunE: runThemeUnfocus,
+}
+
+func init() {
+	themeCmd.AddCommand(themeFocusCmd)
+	themeCmd.AddCommand(themeUnfocusCmd)
+}
+
+// themeFocusDims reports whether a session in rig is dimmed by the town's
+// focus: a rig is focused and it isn't rig. Town-level sessions (rig "")
+// are dimmed too.
+func themeFocusDims(rig string, town *config.TownThemeConfig) bool {
+	return town != nil && town.Focus != "" && town.Focus != rig
+}
+
+func runThemeFocus(cmd *cobra.Command, args []string) error {
+	rigName := args[0]
+	if _, _, err := getRig(rigName); err != nil {
+		return err
+	}
+	if tc := loadTownThemeConfig(); tc != nil && tc.Focus == rigName {
+		fmt.Printf("%s is already focused; re-applying themes\n", rigName)
+	}
+	if globalDryRun {
+		fmt.Printf("Would focus %s (saving theme.focus) and re-apply themes to all sessions\n", rigName)
+		return nil
+	}
+
+	if err := saveThemeFocus(rigName); err != nil {
+		return fmt.Errorf("saving focus: %w", err)
+	}
+	fmt.Printf("%s Focused %s; other rigs are dimmed\n", style.Success.Render("✓"), rigName)
+	return applyThemeToSessions("", true)
+}
+
+func runThemeUnfocus(cmd *cobra.Command, args []string) error {
+	tc := loadTownThemeConfig()
+	if tc == nil || tc.Focus == "" {
+		fmt.Println("No rig is focused")
+		return nil
+	}
+	if globalDryRun {
+		fmt.Printf("Would unfocus %s and re-apply themes to all sessions\n", tc.Focus)
+		return nil
+	}
+
+	if err := saveThemeFocus(""); err != nil {
+		return fmt.Errorf("clearing focus: %w", err)
+	}
+	fmt.Printf("%s Unfocused %s; restoring every rig's theme\n", style.Success.Render("✓"), tc.Focus)
+	return applyThemeToSessions("", true)
+}
+
+// saveThemeFocus sets theme.focus in mayor/config.json ("" clears it).
+func saveThemeFocus(rigName string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("finding workspace: %w", err)
+	}
+
+	path := filepath.Join(townRoot, "mayor", "config.json")
+	mayorCfg, err := config.LoadMayorConfig(path)
+	if err != nil {
+		if !errors.Is(err, config.ErrNotFound) {
+			return err
+		}
+		mayorCfg = config.NewMayorConfig()
+	}
+	if mayorCfg.Theme == nil {
+		mayorCfg.Theme = &config.TownThemeConfig{}
+	}
+	mayorCfg.Theme.Focus = rigName
+
+	return config.SaveMayorConfig(path, mayorCfg)
+}
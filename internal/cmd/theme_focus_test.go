@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestThemeFocusDims(t *testing.T) {
+	focused := &config.TownThemeConfig{Focus: "gastown"}
+	tests := []struct {
+		name string
+		rig  string
+		town *config.TownThemeConfig
+		want bool
+	}{
+		{"no town config", "beads", nil, false},
+		{"no focus", "beads", &config.TownThemeConfig{}, false},
+		{"focused rig", "gastown", focused, false},
+		{"other rig", "beads", focused, true},
+		{"town-level session", "", focused, true},
+	}
+	for _, tt := range tests {
+		if got := themeFocusDims(tt.rig, tt.town); got != tt.want {
+			t.Errorf("%s: themeFocusDims(%q) = %v, want %v", tt.name, tt.rig, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var themeHistoryLimit int
+
+var themeHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show recent theme changes for a rig",
+	Long: `Show who changed a rig's tmux theme, and when.
+
+Every 'gt theme <name>' records the previous theme, the new theme, and the
+identity that made the change in <rig>/settings/theme-history.jsonl.
+
+Examples:
+  gt theme history                # Current rig
+  gt theme history --rig gastown  # Explicit rig
+  gt theme history -n 5           # Last 5 changes only`,
+	Args: cobra.NoArgs,
+	RunE: runThemeHistory,
+}
+
+func init() {
+	themeCmd.AddCommand(themeHistoryCmd)
+	themeHistoryCmd.Flags().StringVar(&themeRigFlag, "rig", "", "Target rig (default: detected from environment)")
+	themeHistoryCmd.Flags().IntVarP(&themeHistoryLimit, "limit", "n", 20, "Maximum number of entries to show (0 = all)")
+}
+
+func runThemeHistory(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	rigName, err := resolveThemeRig()
+	if err != nil {
+		return err
+	}
+	if rigName == "" {
+		return fmt.Errorf("could not detect rig (use --rig)")
+	}
+
+	history, err := config.LoadThemeHistory(config.ThemeHistoryPath(config.RigDir(townRoot, rigName)))
+	if err != nil {
+		return err
+	}
+
+	if len(history) == 0 {
+		fmt.Printf("No theme changes recorded for rig '%s'\n", rigName)
+		return nil
+	}
+
+	if themeHistoryLimit > 0 && len(history) > themeHistoryLimit {
+		history = history[len(history)-themeHistoryLimit:]
+	}
+
+	fmt.Printf("%s %s\n", style.Bold.Render("Theme history for"), rigName)
+	for _, change := range history {
+		previous := change.Previous
+		if previous == "" {
+			previous = "(default)"
+		}
+		by := change.ChangedBy
+		if by == "" {
+			by = "unknown"
+		}
+		fmt.Printf("  %s  %s → %s  %s\n",
+			change.Timestamp.Local().Format("2006-01-02 15:04:05"),
+			previous, change.Theme, style.Dim.Render("by "+by))
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// themePostApplyHookTimeout bounds how long 'gt theme apply' waits for the
+// post-apply hook before killing it.
+const themePostApplyHookTimeout = 30 * time.Second
+
+// themePostApplyHookEnv builds the environment contract documented in
+// 'gt theme apply --help'.
+func themePostApplyHookEnv(rig, themeName string, sessions []string, failed int) []string {
+	return []string{
+		"GT_THEME_APPLY_RIG=" + rig,
+		"GT_THEME_APPLY_THEME=" + themeName,
+		"GT_THEME_APPLY_SESSIONS=" + strings.Join(sessions, " "),
+		"GT_THEME_APPLY_COUNT=" + strconv.Itoa(len(sessions)),
+		"GT_THEME_APPLY_FAILED=" + strconv.Itoa(failed),
+	}
+}
+
+// execThemePostApplyHook runs command with sh -c in dir, passing args as
+// $1.. and env on top of the current environment. It returns the hook's
+// combined output.
+func execThemePostApplyHook(command, dir string, env []string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), themePostApplyHookTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", append([]string{"-c", command, "sh"}, args...)...) //nolint:gosec // G204: hook is from trusted town config
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return out, fmt.Errorf("timed out after %s", themePostApplyHookTimeout)
+	}
+	return out, err
+}
+
+// runThemePostApplyHook runs the town's theme.post_apply_hook, if any,
+// after an apply that themed sessions. Failures are warnings: the sessions
+// are already themed, so the apply itself still succeeds.
+func runThemePostApplyHook(rigName string, all bool, sessions []string, failed int) {
+	tc := loadTownThemeConfig()
+	if tc == nil || strings.TrimSpace(tc.PostApplyHook) == "" {
+		return
+	}
+
+	rig, themeName := rigName, ""
+	if all {
+		rig = ""
+	} else if rig != "" {
+		themeName = getThemeForRig(rig).Name
+	}
+	townRoot, _ := workspace.FindFromCwd()
+
+	if themeVerboseFlag {
+		fmt.Fprintf(os.Stderr, "Running post-apply hook: %s\n", tc.PostApplyHook)
+	}
+	out, err := execThemePostApplyHook(tc.PostApplyHook, townRoot,
+		themePostApplyHookEnv(rig, themeName, sessions, failed), rig, themeName)
+	if themeVerboseFlag && len(out) > 0 {
+		for _, line := range strings.Split(strings.TrimRight(string(out), "\n"), "\n") {
+			fmt.Fprintf(os.Stderr, "  %s %s\n", style.Dim.Render("hook:"), line)
+		}
+	}
+	if err != nil {
+		hint := ""
+		if !themeVerboseFlag && len(out) > 0 {
+			hint = " (rerun with --verbose to see its output)"
+		}
+		fmt.Fprintf(os.Stderr, "%s Warning: post-apply hook failed: %v%s\n", style.Dim.Render("⚠"), err, hint)
+	}
+}
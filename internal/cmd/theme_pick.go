@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/tui/themepicker"
+	"golang.org/x/term"
+)
+
+var themePickFlag bool
+
+func init() {
+	themeCmd.Flags().BoolVar(&themePickFlag, "pick", false, "Choose the rig's theme interactively, with a status bar preview")
+	themeCmd.MarkFlagsMutuallyExclusive("pick", "list")
+	themeCmd.MarkFlagsMutuallyExclusive("pick", "worker")
+}
+
+// themePickItems returns the themes selectable for rigName: built-ins, then
+// town custom themes, then rig-local ones. A name defined in several places
+// appears once, as it resolves for the rig.
+func themePickItems(rigName string) []themepicker.Item {
+	rigDefs := loadRigThemeDefs(rigName)
+	customs := loadCustomThemes()
+
+	var names []string
+	names = append(names, tmux.ListThemeNames()...)
+	for _, theme := range customs {
+		names = append(names, theme.Name)
+	}
+	for _, theme := range rigDefs {
+		names = append(names, theme.Name)
+	}
+
+	seen := make(map[string]bool)
+	var items []themepicker.Item
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		theme := resolveRigThemeByName(rigName, name)
+		if theme == nil {
+			continue
+		}
+		source := "built-in"
+		switch {
+		case findTheme(rigDefs, name) != nil:
+			source = "rig " + rigName
+		case findTheme(customs, name) != nil:
+			source = "custom"
+		}
+		items = append(items, themepicker.Item{Theme: *theme, Source: source})
+	}
+	return items
+}
+
+// runThemePicker lets the user pick rigName's theme in a terminal UI; the
+// chosen theme is saved and applied to the rig's running sessions.
+func runThemePicker(rigName string) error {
+	if !term.IsTerminal(int(os.Stdout.Fd())) || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf("--pick needs an interactive terminal; use 'gt theme --list' and 'gt theme <name>' instead")
+	}
+	if rigName == unknownRigName {
+		return fmt.Errorf("could not detect rig (pass --rig)")
+	}
+
+	m := themepicker.New(themePickItems(rigName), rigName, getThemeForRig(rigName).Name)
+	final, err := tea.NewProgram(m).Run()
+	if err != nil {
+		return fmt.Errorf("theme picker: %w", err)
+	}
+	theme, ok := final.(themepicker.Model).Selected()
+	if !ok {
+		fmt.Println("No theme selected")
+		return nil
+	}
+
+	themeSetApplyFlag = true // picking saves and applies, like --apply
+	return setRigTheme(rigName, theme.Name)
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+var themeSpreadCmd = &cobra.Command{
+	Use:   "spread",
+	Short: "Give rigs that share a theme distinct ones",
+	Long: `Find rigs that end up with the same theme and reassign them.
+
+Rigs that don't configure a theme get one from their name, so with more
+rigs than themes (or an unlucky hash) two rigs can look identical. Spread
+finds every theme used by more than one rig, keeps it for one of them and
+saves an unused built-in theme to the others' settings/config.json.
+
+Which rig keeps a shared theme: a rig with "locked": true in its theme
+settings is never reassigned, then rigs that chose their theme explicitly
+are preferred over ones whose theme came from the hash. With more rigs
+than built-in themes some rigs will still share one; spread says so.
+
+Saved themes are not applied to running sessions; run 'gt theme apply
+--all' afterwards. With the global --dry-run flag the reassignments are
+listed without saving anything.
+
+Examples:
+  gt theme spread
+  gt --dry-run theme spread`,
+	Args: cobra.NoArgs,
+	RunE: runThemeSpread,
+}
+
+func init() {
+	themeCmd.AddCommand(themeSpreadCmd)
+}
+
+// spreadRig is a rig's current theme as seen by 'gt theme spread'.
+type spreadRig struct {
+	name       string
+	theme      string
+	configured bool // theme chosen in settings rather than assigned by hash
+	locked     bool // theme.locked: never reassigned
+}
+
+// themeSpreadPlan is the outcome of planThemeSpread.
+type themeSpreadPlan struct {
+	changes map[string]string // rig -> new theme
+	shared  [][]string        // rigs still sharing a theme afterwards, per theme
+}
+
+// planThemeSpread reassigns rigs so that no two share a theme, using names
+// from palette. Within each group sharing a theme, a locked rig keeps it
+// first, then a configured one, then the alphabetically first. Replacement
+// themes are unused ones, preferring those no rig has yet.
+func planThemeSpread(rigs []spreadRig, palette []string) themeSpreadPlan {
+	ordered := append([]spreadRig(nil), rigs...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i], ordered[j]
+		if a.locked != b.locked {
+			return a.locked
+		}
+		if a.configured != b.configured {
+			return a.configured
+		}
+		return a.name < b.name
+	})
+
+	wanted := make(map[string]bool)
+	for _, r := range rigs {
+		wanted[r.theme] = true
+	}
+	free := func(taken map[string][]string) string {
+		fallback := ""
+		for _, name := range palette {
+			if len(taken[name]) > 0 {
+				continue
+			}
+			if !wanted[name] {
+				return name
+			}
+			if fallback == "" {
+				fallback = name
+			}
+		}
+		return fallback
+	}
+
+	plan := themeSpreadPlan{changes: make(map[string]string)}
+	taken := make(map[string][]string) // theme -> rigs using it after the spread
+	for _, r := range ordered {
+		theme := r.theme
+		if len(taken[theme]) > 0 && !r.locked {
+			if alt := free(taken); alt != "" {
+				theme = alt
+				plan.changes[r.name] = alt
+			}
+		}
+		taken[theme] = append(taken[theme], r.name)
+	}
+
+	var themes []string
+	for theme, names := range taken {
+		if len(names) > 1 {
+			themes = append(themes, theme)
+		}
+	}
+	sort.Strings(themes)
+	for _, theme := range themes {
+		names := append([]string(nil), taken[theme]...)
+		sort.Strings(names)
+		plan.shared = append(plan.shared, names)
+	}
+	return plan
+}
+
+func runThemeSpread(cmd *cobra.Command, args []string) error {
+	rigs, _, err := getAllRigs()
+	if err != nil {
+		return err
+	}
+
+	var current []spreadRig
+	for _, r := range rigs {
+		sr := spreadRig{name: r.Name, theme: tmux.AssignTheme(r.Name).Name}
+		if tc := loadRigThemeConfig(r.Name); tc != nil {
+			if tc.Name != "" {
+				sr.theme, sr.configured = tc.Name, true
+			}
+			sr.locked = tc.Locked
+		}
+		current = append(current, sr)
+	}
+	sort.Slice(current, func(i, j int) bool { return current[i].name < current[j].name })
+
+	plan := planThemeSpread(current, tmux.ListThemeNames())
+	if len(plan.changes) == 0 && len(plan.shared) == 0 {
+		fmt.Printf("%s All %d rig(s) already have distinct themes\n", style.Success.Render("✓"), len(current))
+		return nil
+	}
+
+	changed := 0
+	for _, r := range current {
+		theme, ok := plan.changes[r.name]
+		if !ok {
+			continue
+		}
+		if !globalDryRun {
+			fmt.Printf("  %s: %s -> %s\n", r.name, r.theme, theme)
+		}
+		if err := saveRigTheme(r.name, theme); err != nil {
+			fmt.Fprintf(os.Stderr, "  %s: %v\n", r.name, err)
+			continue
+		}
+		changed++
+	}
+
+	for _, names := range plan.shared {
+		fmt.Fprintf(os.Stderr, "%s %s still share a theme\n", style.Dim.Render("⚠"), strings.Join(names, ", "))
+	}
+	if len(plan.shared) > 0 && len(current) > len(tmux.ListThemeNames()) {
+		fmt.Fprintf(os.Stderr, "  %d rigs but only %d built-in themes; not every rig can have its own\n",
+			len(current), len(tmux.ListThemeNames()))
+	} else if len(plan.shared) > 0 {
+		fmt.Fprintf(os.Stderr, "  locked rigs keep their theme; unlock one to let spread reassign it\n")
+	}
+
+	if changed > 0 && !globalDryRun {
+		fmt.Printf("\nReassigned %d rig(s); run 'gt theme apply --all' to restyle running sessions\n", changed)
+	}
+	return nil
+}
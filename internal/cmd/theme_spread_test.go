@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlanThemeSpread(t *testing.T) {
+	palette := []string{"ocean", "forest", "rust"}
+
+	plan := planThemeSpread([]spreadRig{
+		{name: "alpha", theme: "ocean"},
+		{name: "beta", theme: "ocean", configured: true},
+		{name: "gamma", theme: "forest"},
+	}, palette)
+	// beta chose ocean, so alpha moves - to rust, as gamma already has forest
+	if want := map[string]string{"alpha": "rust"}; !reflect.DeepEqual(plan.changes, want) {
+		t.Errorf("changes = %v, want %v", plan.changes, want)
+	}
+	if len(plan.shared) != 0 {
+		t.Errorf("shared = %v, want none", plan.shared)
+	}
+
+	// A locked rig keeps its theme even over a configured one
+	plan = planThemeSpread([]spreadRig{
+		{name: "alpha", theme: "ocean", locked: true},
+		{name: "beta", theme: "ocean", configured: true},
+	}, palette)
+	if want := map[string]string{"beta": "forest"}; !reflect.DeepEqual(plan.changes, want) {
+		t.Errorf("locked: changes = %v, want %v", plan.changes, want)
+	}
+
+	// More rigs than themes: the leftovers share, and are reported
+	plan = planThemeSpread([]spreadRig{
+		{name: "a", theme: "ocean"},
+		{name: "b", theme: "ocean"},
+		{name: "c", theme: "ocean"},
+		{name: "d", theme: "ocean"},
+	}, palette)
+	if len(plan.changes) != 2 {
+		t.Errorf("changes = %v, want two rigs moved", plan.changes)
+	}
+	if want := [][]string{{"a", "d"}}; !reflect.DeepEqual(plan.shared, want) {
+		t.Errorf("shared = %v, want %v", plan.shared, want)
+	}
+
+	// Two locked rigs on one theme can't be separated
+	plan = planThemeSpread([]spreadRig{
+		{name: "a", theme: "ocean", locked: true},
+		{name: "b", theme: "ocean", locked: true},
+	}, palette)
+	if len(plan.changes) != 0 || len(plan.shared) != 1 {
+		t.Errorf("locked pair: changes = %v, shared = %v; want none changed, one shared", plan.changes, plan.shared)
+	}
+}
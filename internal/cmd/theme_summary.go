@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+)
+
+var themeApplyJSON bool
+
+func init() {
+	themeApplyCmd.Flags().BoolVar(&themeApplyJSON, "json", false, "Print the per-rig summary as JSON instead of per-session lines")
+	themeApplyCmd.MarkFlagsMutuallyExclusive("json", "reset")
+}
+
+// themeRigResult counts what 'gt theme apply' did with one rig's sessions.
+// Skipped covers sessions already themed and ones left alone because they
+// look manually customized.
+type themeRigResult struct {
+	Rig     string `json:"rig"` // "" for town-level sessions (Mayor, Deacon)
+	Applied int    `json:"applied"`
+	Skipped int    `json:"skipped"`
+	Failed  int    `json:"failed"`
+}
+
+// themeApplySummary is the per-rig outcome of an apply, as printed with
+// --all and emitted with --json.
+type themeApplySummary struct {
+	DryRun bool             `json:"dry_run,omitempty"`
+	Rigs   []themeRigResult `json:"rigs"`
+	Total  themeRigResult   `json:"total"` // Rig is ""
+}
+
+// themeApplyTally accumulates themeRigResults by rig during an apply.
+type themeApplyTally map[string]*themeRigResult
+
+// rig returns the rig's result, adding it on first use.
+func (t themeApplyTally) rig(name string) *themeRigResult {
+	r, ok := t[name]
+	if !ok {
+		r = &themeRigResult{Rig: name}
+		t[name] = r
+	}
+	return r
+}
+
+// summary returns the tallied results in rig order (town-level first) with
+// their total.
+func (t themeApplyTally) summary() themeApplySummary {
+	s := themeApplySummary{DryRun: globalDryRun, Rigs: []themeRigResult{}}
+	for _, r := range t {
+		s.Rigs = append(s.Rigs, *r)
+		s.Total.Applied += r.Applied
+		s.Total.Skipped += r.Skipped
+		s.Total.Failed += r.Failed
+	}
+	sort.Slice(s.Rigs, func(i, j int) bool { return s.Rigs[i].Rig < s.Rigs[j].Rig })
+	return s
+}
+
+// writeThemeApplySummary prints a summary as a table: one row per rig and
+// a total row.
+func writeThemeApplySummary(w io.Writer, s themeApplySummary) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	applied := "APPLIED"
+	if s.DryRun {
+		applied = "WOULD APPLY"
+	}
+	fmt.Fprintf(tw, "RIG\t%s\tSKIPPED\tFAILED\n", applied)
+	for _, r := range s.Rigs {
+		name := r.Rig
+		if name == "" {
+			name = "(town)"
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\n", name, r.Applied, r.Skipped, r.Failed)
+	}
+	fmt.Fprintf(tw, "TOTAL\t%d\t%d\t%d\n", s.Total.Applied, s.Total.Skipped, s.Total.Failed)
+	_ = tw.Flush()
+}
+
+// printThemeApplyJSON emits a summary for --json.
+func printThemeApplyJSON(s themeApplySummary) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
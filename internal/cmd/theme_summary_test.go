@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestThemeApplyTally(t *testing.T) {
+	tally := make(themeApplyTally)
+	tally.rig("gastown").Applied += 2
+	tally.rig("gastown").Failed++
+	tally.rig("beads").Skipped++
+	tally.rig("").Applied++
+
+	s := tally.summary()
+	if len(s.Rigs) != 3 || s.Rigs[0].Rig != "" || s.Rigs[1].Rig != "beads" || s.Rigs[2].Rig != "gastown" {
+		t.Fatalf("summary rigs = %+v, want town, beads, gastown", s.Rigs)
+	}
+	if s.Total != (themeRigResult{Applied: 3, Skipped: 1, Failed: 1}) {
+		t.Errorf("total = %+v", s.Total)
+	}
+
+	var buf bytes.Buffer
+	writeThemeApplySummary(&buf, s)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("table =\n%s\nwant header, 3 rigs and a total", buf.String())
+	}
+	for i, want := range [][]string{{"RIG", "APPLIED", "SKIPPED", "FAILED"}, {"(town)", "1", "0", "0"}, {"beads", "0", "1", "0"}, {"gastown", "2", "0", "1"}, {"TOTAL", "3", "1", "1"}} {
+		if got := strings.Fields(lines[i]); strings.Join(got, " ") != strings.Join(want, " ") {
+			t.Errorf("row %d = %q, want %q", i, got, want)
+		}
+	}
+}
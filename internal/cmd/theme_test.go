@@ -0,0 +1,912 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/ui"
+)
+
+func TestResolveThemeRig(t *testing.T) {
+	_, rigName := setupTestRigForSettings(t)
+
+	tests := []struct {
+		name    string
+		flag    string
+		envRig  string
+		want    string
+		wantErr bool
+	}{
+		{name: "flag set", flag: rigName, want: rigName},
+		{name: "flag wins over GT_RIG", flag: rigName, envRig: "otherrig", want: rigName},
+		{name: "flag unset uses GT_RIG", envRig: "otherrig", want: "otherrig"},
+		{name: "unknown rig flag errors", flag: "nosuchrig", wantErr: true},
+		{name: "unknown rig flag errors even with GT_RIG", flag: "nosuchrig", envRig: rigName, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GT_RIG", tt.envRig)
+			themeRigFlag = tt.flag
+			defer func() { themeRigFlag = "" }()
+
+			got, err := resolveThemeRig()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("resolveThemeRig() = %q, want error", got)
+				}
+				if !strings.Contains(err.Error(), tt.flag) {
+					t.Errorf("error %q should name rig %q", err, tt.flag)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveThemeRig() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveThemeRig() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseThemeSession(t *testing.T) {
+	tests := []struct {
+		sess       string
+		wantRig    string
+		wantWorker string
+		wantRole   string
+		wantOK     bool
+	}{
+		{"hq-mayor", "", "Mayor", "coordinator", true},
+		{"hq-deacon", "", "Deacon", "health-check", true},
+		{"gt-gastown-witness", "gastown", "witness", "witness", true},
+		{"gt-gastown-refinery", "gastown", "refinery", "refinery", true},
+		{"gt-gastown-crew-max", "gastown", "max", "crew", true},
+		{"gt-gastown-Toast", "gastown", "Toast", "polecat", true},
+		{"gt-gastown", "", "", "", false},
+		{"gt--witness", "", "witness", "witness", false},
+		{"hq-boot", "", "", "", false},
+		{"dev", "", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sess, func(t *testing.T) {
+			rig, worker, role, ok := parseThemeSession(tt.sess)
+			if ok != tt.wantOK {
+				t.Fatalf("parseThemeSession(%q) ok = %v, want %v", tt.sess, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if rig != tt.wantRig || worker != tt.wantWorker || role != tt.wantRole {
+				t.Errorf("parseThemeSession(%q) = (%q, %q, %q), want (%q, %q, %q)",
+					tt.sess, rig, worker, role, tt.wantRig, tt.wantWorker, tt.wantRole)
+			}
+		})
+	}
+}
+
+func TestThemeApplyInScope(t *testing.T) {
+	tests := []struct {
+		name    string
+		sessRig string
+		target  string
+		all     bool
+		want    bool
+	}{
+		{"same rig", "gastown", "gastown", false, true},
+		{"other rig witness skipped", "beads", "gastown", false, false},
+		{"town-level skipped for targeted rig", "", "gastown", false, false},
+		{"no target applies to all", "beads", "", false, true},
+		{"no target applies to town-level", "", "", false, true},
+		{"all overrides target", "beads", "gastown", true, true},
+		{"all includes town-level", "", "gastown", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := themeApplyInScope(tt.sessRig, tt.target, tt.all); got != tt.want {
+				t.Errorf("themeApplyInScope(%q, %q, %v) = %v, want %v",
+					tt.sessRig, tt.target, tt.all, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThemeSessionInScope(t *testing.T) {
+	defer func() {
+		themeIncludeMayor, themeExcludeMayor = false, false
+		themeIncludeDeacon, themeExcludeDeacon = false, false
+	}()
+
+	// Defaults match themeApplyInScope
+	if themeSessionInScope("", "coordinator", "gastown", false) {
+		t.Error("mayor in scope of a single-rig apply by default")
+	}
+	if !themeSessionInScope("", "health-check", "gastown", true) {
+		t.Error("deacon out of scope of --all by default")
+	}
+
+	themeIncludeMayor = true
+	if !themeSessionInScope("", "coordinator", "gastown", false) {
+		t.Error("--include-mayor: mayor out of scope of a single-rig apply")
+	}
+	if themeSessionInScope("", "health-check", "gastown", false) {
+		t.Error("--include-mayor pulled in the deacon")
+	}
+
+	themeIncludeMayor, themeExcludeMayor, themeExcludeDeacon = false, true, true
+	if themeSessionInScope("", "coordinator", "gastown", true) || themeSessionInScope("", "health-check", "gastown", true) {
+		t.Error("--exclude-mayor/--exclude-deacon: town-level sessions still in scope of --all")
+	}
+	if !themeSessionInScope("beads", "witness", "gastown", true) {
+		t.Error("--exclude-mayor/--exclude-deacon dropped a rig session from --all")
+	}
+}
+
+func TestThemeApplyAllAndRigMutuallyExclusive(t *testing.T) {
+	defer func() {
+		themeApplyAllFlag = false
+		themeRigFlag = ""
+		_ = themeApplyCmd.Flags().Set("all", "false")
+		_ = themeApplyCmd.Flags().Set("rig", "")
+		themeApplyCmd.Flags().Lookup("all").Changed = false
+		themeApplyCmd.Flags().Lookup("rig").Changed = false
+	}()
+
+	if err := themeApplyCmd.ParseFlags([]string{"--all", "--rig", "gastown"}); err != nil {
+		t.Fatalf("ParseFlags: %v", err)
+	}
+	if err := themeApplyCmd.ValidateFlagGroups(); err == nil {
+		t.Error("expected --all and --rig together to be rejected")
+	}
+}
+
+func TestSetRigThemeApplyAbortsOnSaveFailure(t *testing.T) {
+	// Not in a workspace, so the save must fail before any apply is attempted
+	t.Chdir(t.TempDir())
+	t.Setenv("GT_TOWN_ROOT", "")
+	themeSetApplyFlag = true
+	defer func() { themeSetApplyFlag = false }()
+
+	err := setRigTheme("gastown", "forest")
+	if err == nil || !strings.Contains(err.Error(), "saving theme config") {
+		t.Fatalf("setRigTheme() error = %v, want saving theme config error", err)
+	}
+}
+
+func TestSetRigThemeUnknownTheme(t *testing.T) {
+	err := setRigTheme("gastown", "nosuchtheme")
+	if err == nil || !strings.Contains(err.Error(), "gt theme --list") {
+		t.Fatalf("setRigTheme() error = %v, want unknown theme error", err)
+	}
+}
+
+func TestInvalidThemeColorsRejected(t *testing.T) {
+	townRoot, _ := setupTestRigForSettings(t)
+	settingsPath := filepath.Join(townRoot, "testrig", "settings", "config.json")
+
+	settings := config.NewRigSettings()
+	settings.Theme = &config.ThemeConfig{
+		ThemeDefs: map[string]config.CustomTheme{"broken": {BG: "#12345", FG: "white"}},
+	}
+	if err := config.SaveRigSettings(settingsPath, settings); err != nil {
+		t.Fatalf("SaveRigSettings: %v", err)
+	}
+
+	err := setRigTheme("testrig", "broken")
+	if err == nil || !strings.Contains(err.Error(), `bg: invalid color "#12345"`) {
+		t.Fatalf("setRigTheme(broken) error = %v, want bg field and value named", err)
+	}
+	if got := loadRigTheme("testrig"); got != "" {
+		t.Errorf("invalid theme was saved: %q", got)
+	}
+
+	if err := saveCustomTheme(tmux.Theme{Name: "neon", BG: "#000000", FG: "colour300"}); err == nil ||
+		!strings.Contains(err.Error(), "fg:") {
+		t.Errorf("saveCustomTheme(colour300) error = %v, want fg error", err)
+	}
+
+	if note := invalidThemeNote(tmux.Theme{Name: "broken", BG: "#12345", FG: "white"}); !strings.Contains(note, "#12345") {
+		t.Errorf("invalidThemeNote = %q, want offending value", note)
+	}
+	if note := invalidThemeNote(tmux.Theme{Name: "fine", BG: "#123456", FG: "white"}); note != "" {
+		t.Errorf("invalidThemeNote(valid) = %q, want empty", note)
+	}
+}
+
+func TestSaveRigThemeRecordsHistory(t *testing.T) {
+	townRoot, rigName := setupTestRigForSettings(t)
+	t.Setenv("GT_ROLE", "")
+	rigPath := filepath.Join(townRoot, rigName)
+
+	// Pre-existing role override must survive a theme change
+	settings := config.NewRigSettings()
+	settings.Theme = &config.ThemeConfig{Name: "ocean", RoleThemes: map[string]string{"witness": "plum"}}
+	settingsPath := filepath.Join(rigPath, "settings", "config.json")
+	if err := config.SaveRigSettings(settingsPath, settings); err != nil {
+		t.Fatalf("save settings: %v", err)
+	}
+
+	if err := saveRigTheme(rigName, "forest"); err != nil {
+		t.Fatalf("saveRigTheme: %v", err)
+	}
+
+	loaded, err := config.LoadRigSettings(settingsPath)
+	if err != nil {
+		t.Fatalf("reload settings: %v", err)
+	}
+	if loaded.Theme.Name != "forest" || loaded.Theme.RoleThemes["witness"] != "plum" {
+		t.Errorf("theme config = %+v, want forest with witness=plum", loaded.Theme)
+	}
+
+	history, err := config.LoadThemeHistory(config.ThemeHistoryPath(rigPath))
+	if err != nil {
+		t.Fatalf("LoadThemeHistory: %v", err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("expected 1 history entry, got %d", len(history))
+	}
+	if history[0].Previous != "ocean" || history[0].Theme != "forest" || history[0].ChangedBy == "" {
+		t.Errorf("history entry = %+v, want ocean -> forest with changed_by", history[0])
+	}
+}
+
+func TestSaveRigThemeDryRunWritesNothing(t *testing.T) {
+	townRoot, rigName := setupTestRigForSettings(t)
+	rigPath := filepath.Join(townRoot, rigName)
+	globalDryRun = true
+	defer func() { globalDryRun = false }()
+
+	if err := saveRigTheme(rigName, "forest"); err != nil {
+		t.Fatalf("saveRigTheme: %v", err)
+	}
+
+	if _, err := config.LoadRigSettings(filepath.Join(rigPath, "settings", "config.json")); err == nil {
+		t.Error("dry run should not write settings/config.json")
+	}
+	history, err := config.LoadThemeHistory(config.ThemeHistoryPath(rigPath))
+	if err != nil {
+		t.Fatalf("LoadThemeHistory: %v", err)
+	}
+	if len(history) != 0 {
+		t.Errorf("dry run recorded %d history entries, want 0", len(history))
+	}
+}
+
+func TestGlobalDryRunShadowedByLocalFlag(t *testing.T) {
+	// Commands with their own --dry-run keep it; the global flag must not
+	// replace it during flag merging.
+	if f := hookCmd.Flags().Lookup("dry-run"); f == nil || f.Shorthand != "n" {
+		t.Fatalf("hook --dry-run = %+v, want local flag with -n shorthand", f)
+	}
+	if f := themeCmd.InheritedFlags().Lookup("dry-run"); f == nil || f != rootCmd.PersistentFlags().Lookup("dry-run") {
+		t.Errorf("theme --dry-run should resolve to the global flag")
+	}
+}
+
+func TestThemeProgress(t *testing.T) {
+	var buf bytes.Buffer
+	p := &themeProgress{w: &buf, total: 47, enabled: true}
+
+	p.step(12, "gt-acme-joe")
+	p.step(13, "gt-acme-max")
+	p.clear()
+	p.clear() // already clean: no-op
+
+	want := "\r\033[K[12/47] gt-acme-joe\r\033[K[13/47] gt-acme-max\r\033[K"
+	if got := buf.String(); got != want {
+		t.Errorf("progress output = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	off := &themeProgress{w: &buf, total: 2}
+	off.step(1, "gt-acme-joe")
+	off.clear()
+	if buf.Len() != 0 {
+		t.Errorf("disabled progress wrote %q", buf.String())
+	}
+}
+
+func TestThemeApplyShouldRollback(t *testing.T) {
+	tests := []struct {
+		name            string
+		applied, failed int
+		strict          bool
+		want            bool
+	}{
+		{"no failures", 5, 0, false, false},
+		{"no failures strict", 5, 0, true, false},
+		{"minority failed", 3, 1, false, false},
+		{"minority failed strict", 3, 1, true, true},
+		{"exactly half failed", 2, 2, false, false},
+		{"majority failed", 1, 3, false, true},
+		{"all failed", 0, 4, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := themeApplyShouldRollback(tt.applied, tt.failed, tt.strict); got != tt.want {
+				t.Errorf("themeApplyShouldRollback(%d, %d, %v) = %v, want %v",
+					tt.applied, tt.failed, tt.strict, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionIsIdle(t *testing.T) {
+	now := time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		last    time.Time
+		minutes int
+		want    bool
+	}{
+		{"recent activity", now.Add(-2 * time.Minute), 10, false},
+		{"exactly at threshold", now.Add(-10 * time.Minute), 10, true},
+		{"long idle", now.Add(-time.Hour), 10, true},
+		{"disabled", now.Add(-time.Hour), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sessionIsIdle(tt.last, now, tt.minutes); got != tt.want {
+				t.Errorf("sessionIsIdle(%v, %d) = %v, want %v", now.Sub(tt.last), tt.minutes, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThemeAdjustSavesCustomTheme(t *testing.T) {
+	setupTestRigForSettings(t)
+	defer func() {
+		themeAdjustName = ""
+		themeAdjustLighten = 0
+	}()
+
+	themeAdjustName = "ocean-light"
+	themeAdjustLighten = 10
+	if err := runThemeAdjust(nil, []string{"ocean"}); err != nil {
+		t.Fatalf("runThemeAdjust: %v", err)
+	}
+
+	theme := resolveThemeByName("ocean-light")
+	if theme == nil {
+		t.Fatal("custom theme not resolvable after adjust")
+	}
+	if theme.BG == tmux.GetThemeByName("ocean").BG {
+		t.Errorf("custom theme BG %s should differ from base", theme.BG)
+	}
+
+	// Custom themes can be the base for further adjustments
+	themeAdjustName = "ocean-lighter"
+	if err := runThemeAdjust(nil, []string{"ocean-light"}); err != nil {
+		t.Fatalf("runThemeAdjust from custom base: %v", err)
+	}
+
+	// Built-in names are protected, degenerate output is rejected
+	themeAdjustName = "forest"
+	if err := runThemeAdjust(nil, []string{"ocean"}); err == nil {
+		t.Error("expected error when shadowing a built-in theme")
+	}
+	themeAdjustName = "blank"
+	themeAdjustLighten = 100
+	if err := runThemeAdjust(nil, []string{"ocean"}); err == nil {
+		t.Error("expected error for unreadable theme")
+	}
+	if resolveThemeByName("blank") != nil {
+		t.Error("rejected theme must not be saved")
+	}
+}
+
+func TestDetectCurrentRigWithReason(t *testing.T) {
+	townRoot, rigName := setupTestRigForSettings(t)
+	for _, k := range []string{"GT_RIG", "GT_POLECAT", "GT_CREW", "GT_ROLE"} {
+		t.Setenv(k, "")
+	}
+
+	t.Setenv("GT_RIG", "fromenv")
+	if rig, reason := detectCurrentRigWithReason(); rig != "fromenv" || reason != "GT_RIG" {
+		t.Errorf("with GT_RIG: got (%q, %q)", rig, reason)
+	}
+	t.Setenv("GT_RIG", "")
+
+	t.Chdir(filepath.Join(townRoot, rigName))
+	if rig, _ := detectCurrentRigWithReason(); rig != rigName {
+		t.Errorf("in rig dir: rig = %q, want %q", rig, rigName)
+	}
+
+	t.Chdir(filepath.Join(townRoot, "mayor"))
+	if rig, reason := detectCurrentRigWithReason(); rig != "" || !strings.Contains(reason, "not under any rig") {
+		t.Errorf("in mayor/: got (%q, %q), want no rig with explanation", rig, reason)
+	}
+}
+
+func TestGetThemeForUnknownRig(t *testing.T) {
+	townRoot, _ := setupTestRigForSettings(t)
+
+	if got := getThemeForRig(unknownRigName); !got.Equal(tmux.UnknownTheme()) {
+		t.Errorf("getThemeForRig(unknown) = %+v, want built-in unknown theme", got)
+	}
+
+	// Town config can pick a different theme for the fallback
+	mayorCfg := config.NewMayorConfig()
+	mayorCfg.Theme = &config.TownThemeConfig{Unknown: "slate"}
+	if err := config.SaveMayorConfig(filepath.Join(townRoot, "mayor", "config.json"), mayorCfg); err != nil {
+		t.Fatalf("SaveMayorConfig: %v", err)
+	}
+	if got := getThemeForRig(unknownRigName); got.Name != "slate" {
+		t.Errorf("getThemeForRig(unknown) = %q, want configured slate", got.Name)
+	}
+}
+
+func TestWorkerTheme(t *testing.T) {
+	townRoot, _ := setupTestRigForSettings(t)
+	settingsPath := filepath.Join(townRoot, "testrig", "settings", "config.json")
+
+	settings := config.NewRigSettings()
+	settings.Theme = &config.ThemeConfig{Name: "forest", RoleThemes: map[string]string{"crew": "slate"}}
+	if err := config.SaveRigSettings(settingsPath, settings); err != nil {
+		t.Fatalf("SaveRigSettings: %v", err)
+	}
+
+	captureStdout(t, func() {
+		if err := setWorkerTheme("testrig", "max", "plum"); err != nil {
+			t.Fatalf("setWorkerTheme: %v", err)
+		}
+	})
+	saved, err := config.LoadRigSettings(settingsPath)
+	if err != nil {
+		t.Fatalf("LoadRigSettings: %v", err)
+	}
+	if saved.Theme.Workers["max"] != "plum" || saved.Theme.Name != "forest" {
+		t.Errorf("saved theme = %+v, want worker max=plum beside rig forest", saved.Theme)
+	}
+
+	// worker -> role -> rig
+	if got := getThemeForWorker("testrig", "max", "crew"); got.Name != "plum" {
+		t.Errorf("getThemeForWorker(max) = %s, want worker override plum", got.Name)
+	}
+	if got := getThemeForWorker("testrig", "joe", "crew"); got.Name != "slate" {
+		t.Errorf("getThemeForWorker(joe, crew) = %s, want role theme slate", got.Name)
+	}
+	if got := getThemeForWorker("testrig", "Toast", "polecat"); got.Name != "forest" {
+		t.Errorf("getThemeForWorker(Toast, polecat) = %s, want rig theme forest", got.Name)
+	}
+}
+
+func TestGetThemeForRigThemeDefs(t *testing.T) {
+	townRoot, _ := setupTestRigForSettings(t)
+	settingsPath := filepath.Join(townRoot, "testrig", "settings", "config.json")
+
+	settings := config.NewRigSettings()
+	settings.Theme = &config.ThemeConfig{
+		Name: "house",
+		ThemeDefs: map[string]config.CustomTheme{
+			"house": {BG: "#102030", FG: "#f0f0f0"},
+			"ocean": {BG: "#000080", FG: "#ffffff"}, // shadows the built-in
+		},
+		RoleThemes: map[string]string{"witness": "ocean"},
+	}
+	if err := config.SaveRigSettings(settingsPath, settings); err != nil {
+		t.Fatalf("SaveRigSettings: %v", err)
+	}
+
+	if got := getThemeForRig("testrig"); got.Name != "house" || got.BG != "#102030" {
+		t.Errorf("getThemeForRig = %+v, want rig-local house theme", got)
+	}
+	if got := getThemeForRole("testrig", "witness"); got.BG != "#000080" {
+		t.Errorf("getThemeForRole(witness) BG = %q, want rig-local ocean #000080", got.BG)
+	}
+
+	// Rig-local themes are invisible outside the rig.
+	if got := resolveThemeByName("house"); got != nil {
+		t.Errorf("resolveThemeByName(house) = %+v, want nil outside the rig", got)
+	}
+	if got := resolveRigThemeByName("otherrig", "ocean"); got == nil || got.BG == "#000080" {
+		t.Errorf("resolveRigThemeByName(otherrig, ocean) = %+v, want built-in ocean", got)
+	}
+}
+
+func TestGetThemeForRigEnvOverride(t *testing.T) {
+	townRoot, _ := setupTestRigForSettings(t)
+	settingsPath := filepath.Join(townRoot, "testrig", "settings", "config.json")
+
+	settings := config.NewRigSettings()
+	settings.Theme = &config.ThemeConfig{
+		Name:      "forest",
+		ThemeDefs: map[string]config.CustomTheme{"house": {BG: "#102030", FG: "#f0f0f0"}},
+	}
+	if err := config.SaveRigSettings(settingsPath, settings); err != nil {
+		t.Fatalf("SaveRigSettings: %v", err)
+	}
+
+	t.Setenv(themeOverrideEnv, "plum")
+	if got := getThemeForRig("testrig"); got.Name != "plum" {
+		t.Errorf("with override: getThemeForRig = %s, want plum", got.Name)
+	}
+	if got := getThemeForRig(unknownRigName); got.Name != "plum" {
+		t.Errorf("with override: getThemeForRig(unknown) = %s, want plum", got.Name)
+	}
+
+	t.Setenv(themeOverrideEnv, "house") // rig-local themes are visible to the override
+	if got := getThemeForRig("testrig"); got.Name != "house" {
+		t.Errorf("rig-local override: getThemeForRig = %s, want house", got.Name)
+	}
+
+	t.Setenv(themeOverrideEnv, "nosuchtheme")
+	if got := getThemeForRig("testrig"); got.Name != "forest" {
+		t.Errorf("unknown override: getThemeForRig = %s, want configured forest", got.Name)
+	}
+
+	t.Setenv(themeOverrideEnv, "")
+	if got := getThemeForRig("testrig"); got.Name != "forest" {
+		t.Errorf("no override: getThemeForRig = %s, want forest", got.Name)
+	}
+}
+
+func TestListThemesGroupBy(t *testing.T) {
+	setupTestRigForSettings(t)
+	if err := saveCustomTheme(tmux.Theme{Name: "daylight", BG: "#f0f0e0", FG: "#202020"}); err != nil {
+		t.Fatalf("saveCustomTheme: %v", err)
+	}
+
+	flat := captureStdout(t, func() {
+		if err := listThemes(""); err != nil {
+			t.Fatalf("listThemes: %v", err)
+		}
+	})
+	if !strings.HasPrefix(flat, "Available themes:\n  ocean ") || !strings.Contains(flat, "daylight") {
+		t.Errorf("flat list = %q", flat)
+	}
+
+	bySource := captureStdout(t, func() {
+		if err := listThemes("source"); err != nil {
+			t.Fatalf("listThemes(source): %v", err)
+		}
+	})
+	builtin, custom, special := strings.Index(bySource, "built-in:"), strings.Index(bySource, "custom:"), strings.Index(bySource, "special:")
+	if builtin < 0 || custom < builtin || special < custom {
+		t.Fatalf("source sections out of order:\n%s", bySource)
+	}
+	if mayor := strings.Index(bySource, "mayor"); mayor < special {
+		t.Errorf("mayor theme not in the special section:\n%s", bySource)
+	}
+
+	byTone := captureStdout(t, func() {
+		if err := listThemes("tone"); err != nil {
+			t.Fatalf("listThemes(tone): %v", err)
+		}
+	})
+	light := strings.Index(byTone, "light:")
+	if light < 0 || !strings.Contains(byTone[light:], "daylight") {
+		t.Errorf("daylight not listed under light:\n%s", byTone)
+	}
+
+	if err := listThemes("mood"); err == nil {
+		t.Error("listThemes(mood) succeeded, want invalid --group-by error")
+	}
+}
+
+func TestListThemesColor(t *testing.T) {
+	setupTestRigForSettings(t)
+	defer ui.SetColorEnabled(ui.ShouldUseColor())
+
+	ui.SetColorEnabled(false)
+	plain := captureStdout(t, func() {
+		if err := listThemes("source"); err != nil {
+			t.Fatalf("listThemes: %v", err)
+		}
+	})
+	if strings.Contains(plain, "\x1b[") {
+		t.Errorf("color disabled, but the list has escape sequences:\n%q", plain)
+	}
+
+	ui.SetColorEnabled(true)
+	colored := captureStdout(t, func() {
+		if err := listThemes("source"); err != nil {
+			t.Fatalf("listThemes: %v", err)
+		}
+	})
+	if !strings.Contains(colored, "\x1b[") {
+		t.Errorf("color enabled, but the list is plain:\n%s", colored)
+	}
+}
+
+func TestMatchThemeName(t *testing.T) {
+	setupTestRigForSettings(t)
+	if err := saveCustomTheme(tmux.Theme{Name: "forest-light", BG: "#5a8a6a", FG: "#101010"}); err != nil {
+		t.Fatalf("saveCustomTheme: %v", err)
+	}
+
+	tests := []struct {
+		input   string
+		want    string
+		wantErr string
+	}{
+		{"forest", "forest", ""}, // exact match beats the longer custom theme
+		{"MID", "midnight", ""},
+		{"forest-l", "forest-light", ""},
+		{"for", "", `ambiguous theme "for" matches: forest, forest-light`},
+		{"zzz", "", "unknown theme: zzz"},
+		{"", "", "unknown theme"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := matchThemeName("testrig", tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("matchThemeName(%q) error = %v, want containing %q", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("matchThemeName(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestThemePostApplyHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook runs with sh")
+	}
+	townRoot, rigName := setupTestRigForSettings(t)
+	t.Setenv(themeOverrideEnv, "")
+	out := filepath.Join(t.TempDir(), "hook.out")
+
+	mayorCfg := config.NewMayorConfig()
+	mayorCfg.Theme = &config.TownThemeConfig{
+		PostApplyHook: `printf '%s|%s|%s|%s|%s|%s' "$1" "$2" "$GT_THEME_APPLY_RIG" "$GT_THEME_APPLY_SESSIONS" "$GT_THEME_APPLY_COUNT" "$GT_THEME_APPLY_FAILED" > ` + out,
+	}
+	if err := config.SaveMayorConfig(filepath.Join(townRoot, "mayor", "config.json"), mayorCfg); err != nil {
+		t.Fatalf("SaveMayorConfig: %v", err)
+	}
+
+	runThemePostApplyHook(rigName, false, []string{"gt-testrig-max", "gt-testrig-witness"}, 1)
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("hook did not run: %v", err)
+	}
+	themeName := getThemeForRig(rigName).Name
+	want := rigName + "|" + themeName + "|" + rigName + "|gt-testrig-max gt-testrig-witness|2|1"
+	if string(got) != want {
+		t.Errorf("hook saw %q, want %q", got, want)
+	}
+
+	// --all: no single rig or theme
+	runThemePostApplyHook(rigName, true, []string{"gt-testrig-max"}, 0)
+	if got, _ := os.ReadFile(out); string(got) != "|||gt-testrig-max|1|0" {
+		t.Errorf("hook with --all saw %q", got)
+	}
+
+	// A failing hook is reported, not fatal
+	if _, err := execThemePostApplyHook("echo oops; exit 3", townRoot, nil); err == nil {
+		t.Error("execThemePostApplyHook(exit 3) = nil error, want failure")
+	}
+	mayorCfg.Theme.PostApplyHook = "exit 3"
+	if err := config.SaveMayorConfig(filepath.Join(townRoot, "mayor", "config.json"), mayorCfg); err != nil {
+		t.Fatalf("SaveMayorConfig: %v", err)
+	}
+	runThemePostApplyHook(rigName, false, []string{"gt-testrig-max"}, 0)
+}
+
+func TestCheckThemeApplyScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		townRoot string
+		rig      string
+		all      bool
+		wantErr  bool
+	}{
+		{"rig detected", "/town", "gastown", false, false},
+		{"all without rig", "/town", "", true, false},
+		{"no rig and no --all", "/town", "", false, true},
+		{"outside town", "", "gastown", false, true},
+		{"outside town with --all", "", "", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkThemeApplyScope(tt.townRoot, tt.rig, tt.all)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkThemeApplyScope(%q, %q, %v) = %v, wantErr %v", tt.townRoot, tt.rig, tt.all, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestThemeCloneSavesCustomTheme(t *testing.T) {
+	setupTestRigForSettings(t)
+	defer func() { themeCloneOverwrite = false }()
+
+	captureStdout(t, func() {
+		if err := runThemeClone(nil, []string{"forest", "myforest"}); err != nil {
+			t.Fatalf("runThemeClone: %v", err)
+		}
+	})
+	clone := resolveThemeByName("myforest")
+	forest := tmux.GetThemeByName("forest")
+	if clone == nil || clone.BG != forest.BG || clone.FG != forest.FG {
+		t.Fatalf("clone = %+v, want forest's colors", clone)
+	}
+
+	// Existing custom themes need --overwrite; built-in names are refused
+	if err := runThemeClone(nil, []string{"ocean", "myforest"}); err == nil {
+		t.Error("expected error cloning onto an existing custom theme")
+	}
+	if err := runThemeClone(nil, []string{"ocean", "forest"}); err == nil {
+		t.Error("expected error cloning onto a built-in name")
+	}
+	if err := runThemeClone(nil, []string{"nosuchtheme", "mine"}); err == nil {
+		t.Error("expected error for unknown source theme")
+	}
+
+	themeCloneOverwrite = true
+	captureStdout(t, func() {
+		if err := runThemeClone(nil, []string{"ocean", "myforest"}); err != nil {
+			t.Fatalf("runThemeClone --overwrite: %v", err)
+		}
+	})
+	if got := resolveThemeByName("myforest"); got == nil || got.BG != tmux.GetThemeByName("ocean").BG {
+		t.Errorf("overwritten clone = %+v, want ocean's colors", got)
+	}
+}
+
+func TestThemeRefreshInterval(t *testing.T) {
+	tests := []struct {
+		name string
+		rig  *config.ThemeConfig
+		town *config.TownThemeConfig
+		want int
+	}{
+		{"unset", nil, nil, 0},
+		{"town default", &config.ThemeConfig{}, &config.TownThemeConfig{RefreshInterval: 15}, 15},
+		{"rig overrides town", &config.ThemeConfig{RefreshInterval: 2}, &config.TownThemeConfig{RefreshInterval: 15}, 2},
+		{"rig only", &config.ThemeConfig{RefreshInterval: 30}, nil, 30},
+	}
+	for _, tt := range tests {
+		if got := themeRefreshInterval(tt.rig, tt.town); got != tt.want {
+			t.Errorf("%s: themeRefreshInterval() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTerminalTitleEnabled(t *testing.T) {
+	tests := []struct {
+		name string
+		rig  *config.ThemeConfig
+		town *config.TownThemeConfig
+		want bool
+	}{
+		{"unset", nil, nil, false},
+		{"off", &config.ThemeConfig{}, &config.TownThemeConfig{}, false},
+		{"rig", &config.ThemeConfig{TerminalTitle: true}, nil, true},
+		{"town", nil, &config.TownThemeConfig{TerminalTitle: true}, true},
+	}
+	for _, tt := range tests {
+		if got := terminalTitleEnabled(tt.rig, tt.town); got != tt.want {
+			t.Errorf("%s: terminalTitleEnabled() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestThemeLooksCustomized(t *testing.T) {
+	townRoot, _ := setupTestRigForSettings(t)
+	settings := config.NewRigSettings()
+	settings.Theme = &config.ThemeConfig{ThemeDefs: map[string]config.CustomTheme{"house": {BG: "#102030", FG: "#f0f0f0"}}}
+	if err := config.SaveRigSettings(filepath.Join(townRoot, "testrig", "settings", "config.json"), settings); err != nil {
+		t.Fatalf("SaveRigSettings: %v", err)
+	}
+
+	ocean := *tmux.GetThemeByName("ocean")
+	expected := *tmux.GetThemeByName("forest")
+	tests := []struct {
+		name string
+		cur  tmux.Theme
+		want bool
+	}{
+		{"unset", tmux.Theme{}, false},
+		{"expected", expected, false},
+		{"other palette theme", ocean, false},
+		{"idle-dimmed", expected.Dimmed(), false},
+		{"256-color", ocean.ForCaps(tmux.Colors256), false},
+		{"special", tmux.DeaconTheme(), false},
+		{"rig-local", tmux.Theme{BG: "#102030", FG: "#f0f0f0"}, false},
+		{"hand-picked", tmux.Theme{BG: "#123456", FG: "#fedcba"}, true},
+	}
+	for _, tt := range tests {
+		if got := themeLooksCustomized(tt.cur, "testrig", expected); got != tt.want {
+			t.Errorf("%s: themeLooksCustomized(%s) = %v, want %v", tt.name, tt.cur.Style(), got, tt.want)
+		}
+	}
+	if !themeLooksCustomized(tmux.Theme{BG: "#102030", FG: "#f0f0f0"}, "otherrig", expected) {
+		t.Error("another rig's local theme should look customized")
+	}
+}
+
+func TestThemePickItems(t *testing.T) {
+	townRoot, _ := setupTestRigForSettings(t)
+	settings := config.NewRigSettings()
+	settings.Theme = &config.ThemeConfig{ThemeDefs: map[string]config.CustomTheme{
+		"house": {BG: "#102030", FG: "#f0f0f0"},
+		"ocean": {BG: "#000080", FG: "#ffffff"}, // shadows the built-in
+	}}
+	if err := config.SaveRigSettings(filepath.Join(townRoot, "testrig", "settings", "config.json"), settings); err != nil {
+		t.Fatalf("SaveRigSettings: %v", err)
+	}
+
+	items := themePickItems("testrig")
+	if len(items) != len(tmux.DefaultPalette)+1 {
+		t.Fatalf("got %d items, want the palette plus house", len(items))
+	}
+	for _, item := range items {
+		switch item.Theme.Name {
+		case "ocean":
+			if item.Theme.BG != "#000080" || item.Source != "rig testrig" {
+				t.Errorf("ocean = %+v, want the rig-local definition", item)
+			}
+		case "forest":
+			if item.Source != "built-in" {
+				t.Errorf("forest source = %q, want built-in", item.Source)
+			}
+		}
+	}
+	if last := items[len(items)-1]; last.Theme.Name != "house" {
+		t.Errorf("last item = %q, want rig-local house", last.Theme.Name)
+	}
+}
+
+func TestThemePickerNeedsTerminal(t *testing.T) {
+	// Test output is never a terminal
+	if err := runThemePicker("testrig"); err == nil || !strings.Contains(err.Error(), "interactive terminal") {
+		t.Errorf("runThemePicker() = %v, want interactive terminal error", err)
+	}
+}
+
+func TestLoadSourcedCustomThemes(t *testing.T) {
+	townRoot, _ := setupTestRigForSettings(t)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	write := func(dir, name, content string) {
+		t.Helper()
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(userThemesDir(), "mine.json", `{"sunset": {"bg": "#b33a00", "fg": "#f5f5dc"}, "ocean": {"bg": "#000080", "fg": "#ffffff"}}`)
+	write(townThemesDir(townRoot), "team.json", `{"sunset": {"bg": "#ff4500", "fg": "#000000"}}`)
+	mayorCfg := config.NewMayorConfig()
+	mayorCfg.Theme = &config.TownThemeConfig{Custom: map[string]config.CustomTheme{"house": {BG: "#102030", FG: "#f0f0f0"}}}
+	if err := config.SaveMayorConfig(filepath.Join(townRoot, "mayor", "config.json"), mayorCfg); err != nil {
+		t.Fatal(err)
+	}
+
+	layers := make(map[string]string)
+	for _, theme := range loadSourcedCustomThemes() {
+		layers[theme.Name] = themeSourceLayer(theme.Source)
+	}
+	want := map[string]string{"ocean": "user", "sunset": "town", "house": "custom"}
+	for name, layer := range want {
+		if layers[name] != layer {
+			t.Errorf("%s comes from %q, want %q (all: %v)", name, layers[name], layer, layers)
+		}
+	}
+	if theme := resolveThemeByName("ocean"); theme == nil || theme.BG != "#000080" {
+		t.Errorf("resolveThemeByName(ocean) = %+v, want the user's override of the built-in", theme)
+	}
+	if theme := resolveThemeByName("sunset"); theme == nil || theme.BG != "#ff4500" {
+		t.Errorf("resolveThemeByName(sunset) = %+v, want the town file's definition", theme)
+	}
+}
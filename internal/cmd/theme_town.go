@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+var themeExportTownCmd = &cobra.Command{
+	Use:   "export-town",
+	Short: "Export every rig's theme assignment as JSON",
+	Long: `Write the town's visual configuration to stdout as JSON.
+
+Every rig is listed with its theme: rigs with a theme in their settings are
+marked configured (with any role_themes overrides), the rest show the theme
+they get by default from the rig name. The town's custom themes
+(mayor/config.json) are included so the assignments resolve elsewhere.
+
+Use 'gt theme import-town' to restore the file into this or another town.
+
+Examples:
+  gt theme export-town > town-themes.json`,
+	Args: cobra.NoArgs,
+	RunE: runThemeExportTown,
+}
+
+var themeImportTownCmd = &cobra.Command{
+	Use:   "import-town <file>",
+	Short: "Apply rig theme assignments from 'gt theme export-town'",
+	Long: `Restore theme assignments written by 'gt theme export-town'.
+
+Custom themes in the file are saved to mayor/config.json first (replacing
+same-named ones), then each configured rig's theme and role_themes are
+saved to its settings. Rigs exported with their default theme keep
+whatever theme they have here. Rigs in the file that don't exist in this town, and themes that
+don't resolve, are reported as warnings and skipped.
+
+Saved themes are not applied to running sessions; run 'gt theme apply
+--all' afterwards. Use - to read from stdin. With the global --dry-run
+flag, the changes are listed without writing anything.
+
+Examples:
+  gt theme import-town town-themes.json
+  gt theme import-town - < town-themes.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runThemeImportTown,
+}
+
+func init() {
+	themeCmd.AddCommand(themeExportTownCmd)
+	themeCmd.AddCommand(themeImportTownCmd)
+}
+
+// TownThemeExport is the file format of gt theme export-town.
+type TownThemeExport struct {
+	Rigs   map[string]TownThemeRig       `json:"rigs"`
+	Custom map[string]config.CustomTheme `json:"custom,omitempty"`
+}
+
+// TownThemeRig is one rig's theme assignment.
+type TownThemeRig struct {
+	Theme      string            `json:"theme"`
+	Default    bool              `json:"default,omitempty"` // theme not configured; assigned from the rig name
+	RoleThemes map[string]string `json:"role_themes,omitempty"`
+}
+
+// newTownThemeExport builds the export from each rig's theme settings (nil
+// for rigs with none) and the town theme config (nil if unset).
+func newTownThemeExport(rigThemes map[string]*config.ThemeConfig, town *config.TownThemeConfig) TownThemeExport {
+	export := TownThemeExport{Rigs: make(map[string]TownThemeRig, len(rigThemes))}
+	for rigName, tc := range rigThemes {
+		entry := TownThemeRig{Theme: tmux.AssignTheme(rigName).Name, Default: true}
+		if tc != nil {
+			if tc.Name != "" {
+				entry.Theme, entry.Default = tc.Name, false
+			}
+			if len(tc.RoleThemes) > 0 {
+				entry.RoleThemes = tc.RoleThemes
+			}
+		}
+		export.Rigs[rigName] = entry
+	}
+	if town != nil && len(town.Custom) > 0 {
+		export.Custom = town.Custom
+	}
+	return export
+}
+
+func runThemeExportTown(cmd *cobra.Command, args []string) error {
+	rigs, _, err := getAllRigs()
+	if err != nil {
+		return err
+	}
+	rigThemes := make(map[string]*config.ThemeConfig, len(rigs))
+	for _, r := range rigs {
+		rigThemes[r.Name] = loadRigThemeConfig(r.Name)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(newTownThemeExport(rigThemes, loadTownThemeConfig()))
+}
+
+func runThemeImportTown(cmd *cobra.Command, args []string) error {
+	var data []byte
+	var err error
+	if args[0] == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", args[0], err)
+	}
+	var export TownThemeExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return fmt.Errorf("parsing %s: %w", args[0], err)
+	}
+
+	rigs, _, err := getAllRigs()
+	if err != nil {
+		return err
+	}
+	local := make(map[string]bool, len(rigs))
+	for _, r := range rigs {
+		local[r.Name] = true
+	}
+
+	// Custom themes first, so rig assignments that use them resolve
+	customNames := make([]string, 0, len(export.Custom))
+	for name := range export.Custom {
+		customNames = append(customNames, name)
+	}
+	sort.Strings(customNames)
+	imported := make(map[string]bool, len(customNames))
+	for _, name := range customNames {
+		c := export.Custom[name]
+		theme := tmux.Theme{Name: name, BG: c.BG, FG: c.FG, StatusPosition: c.StatusPosition, StatusCenter: c.StatusCenter}
+		if globalDryRun {
+			err = theme.Validate()
+		} else {
+			err = saveCustomTheme(theme)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Warning: skipping custom theme '%s': %v\n", style.Dim.Render("⚠"), name, err)
+			continue
+		}
+		imported[name] = true
+		if globalDryRun {
+			fmt.Printf("Would save custom theme '%s' (%s)\n", name, theme.Style())
+		} else {
+			fmt.Printf("Saved custom theme '%s'\n", name)
+		}
+	}
+
+	rigNames := make([]string, 0, len(export.Rigs))
+	for name := range export.Rigs {
+		rigNames = append(rigNames, name)
+	}
+	sort.Strings(rigNames)
+	resolves := func(rigName, themeName string) bool {
+		return imported[themeName] || resolveRigThemeByName(rigName, themeName) != nil
+	}
+	for _, rigName := range rigNames {
+		entry := export.Rigs[rigName]
+		if entry.Default && len(entry.RoleThemes) == 0 {
+			continue
+		}
+		if !local[rigName] {
+			fmt.Fprintf(os.Stderr, "%s Warning: rig '%s' not found in this town, skipping\n", style.Dim.Render("⚠"), rigName)
+			continue
+		}
+		if err := importRigTheme(rigName, entry, resolves); err != nil {
+			fmt.Fprintf(os.Stderr, "%s Warning: rig '%s': %v\n", style.Dim.Render("⚠"), rigName, err)
+		}
+	}
+
+	if !globalDryRun {
+		fmt.Printf("Run 'gt theme apply --all' to apply to running sessions\n")
+	}
+	return nil
+}
+
+// importRigTheme saves one rig's exported theme and role overrides, skipping
+// names that don't resolve.
+func importRigTheme(rigName string, entry TownThemeRig, resolves func(rigName, themeName string) bool) error {
+	if !entry.Default && entry.Theme != "" {
+		if !resolves(rigName, entry.Theme) {
+			return fmt.Errorf("%w '%s', skipping", errUnknownTheme, entry.Theme)
+		}
+		if err := saveRigTheme(rigName, entry.Theme); err != nil {
+			return err
+		}
+		if !globalDryRun {
+			fmt.Printf("Theme '%s' saved for rig '%s'\n", entry.Theme, rigName)
+		}
+	}
+
+	roles := make(map[string]string, len(entry.RoleThemes))
+	for role, themeName := range entry.RoleThemes {
+		if !resolves(rigName, themeName) {
+			fmt.Fprintf(os.Stderr, "%s Warning: rig '%s': unknown %s theme '%s', skipping\n", style.Dim.Render("⚠"), rigName, role, themeName)
+			continue
+		}
+		roles[role] = themeName
+	}
+	if len(roles) == 0 {
+		return nil
+	}
+	if globalDryRun {
+		fmt.Printf("Would set role themes for rig '%s': %v\n", rigName, roles)
+		return nil
+	}
+
+	_, r, err := getRig(rigName)
+	if err != nil {
+		return err
+	}
+	err = config.UpdateRigSettings(config.RigSettingsPath(r.Path), func(settings *config.RigSettings) error {
+		if settings.Theme == nil {
+			settings.Theme = &config.ThemeConfig{}
+		}
+		if settings.Theme.RoleThemes == nil {
+			settings.Theme.RoleThemes = make(map[string]string)
+		}
+		for role, themeName := range roles {
+			settings.Theme.RoleThemes[role] = themeName
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("saving role themes: %w", err)
+	}
+	fmt.Printf("Role themes saved for rig '%s'\n", rigName)
+	return nil
+}
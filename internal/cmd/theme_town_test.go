@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+func TestNewTownThemeExport(t *testing.T) {
+	export := newTownThemeExport(map[string]*config.ThemeConfig{
+		"gastown": {Name: "forest", RoleThemes: map[string]string{"witness": "rust"}},
+		"beads":   nil,
+		"roles":   {RoleThemes: map[string]string{"crew": "teal"}},
+	}, &config.TownThemeConfig{Custom: map[string]config.CustomTheme{"mine": {BG: "#112233", FG: "#eeeeee"}}})
+
+	if got := export.Rigs["gastown"]; got.Theme != "forest" || got.Default || got.RoleThemes["witness"] != "rust" {
+		t.Errorf("gastown = %+v, want configured forest with witness=rust", got)
+	}
+	if got := export.Rigs["beads"]; got.Theme != tmux.AssignTheme("beads").Name || !got.Default {
+		t.Errorf("beads = %+v, want default %s", got, tmux.AssignTheme("beads").Name)
+	}
+	if got := export.Rigs["roles"]; !got.Default || got.RoleThemes["crew"] != "teal" {
+		t.Errorf("roles = %+v, want default theme with crew=teal", got)
+	}
+	if export.Custom["mine"].BG != "#112233" {
+		t.Errorf("custom = %+v, want mine", export.Custom)
+	}
+}
+
+func TestThemeImportTown(t *testing.T) {
+	townRoot, rigName := setupTestRigForSettings(t)
+
+	export := TownThemeExport{
+		Rigs: map[string]TownThemeRig{
+			rigName:   {Theme: "mine", RoleThemes: map[string]string{"witness": "plum", "crew": "nope"}},
+			"missing": {Theme: "ocean"},
+		},
+		Custom: map[string]config.CustomTheme{"mine": {BG: "#112233", FG: "#eeeeee"}},
+	}
+	data, err := json.Marshal(export)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), "themes.json")
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	captureStdout(t, func() {
+		if err := runThemeImportTown(themeImportTownCmd, []string{file}); err != nil {
+			t.Fatalf("import-town: %v", err)
+		}
+	})
+
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(filepath.Join(townRoot, rigName)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.Theme == nil || settings.Theme.Name != "mine" {
+		t.Fatalf("rig theme = %+v, want mine", settings.Theme)
+	}
+	if settings.Theme.RoleThemes["witness"] != "plum" {
+		t.Errorf("witness theme = %q, want plum", settings.Theme.RoleThemes["witness"])
+	}
+	if _, ok := settings.Theme.RoleThemes["crew"]; ok {
+		t.Errorf("unresolvable crew theme was saved: %+v", settings.Theme.RoleThemes)
+	}
+	if custom := findTheme(loadCustomThemes(), "mine"); custom == nil || custom.BG != "#112233" {
+		t.Errorf("custom theme mine = %+v, want #112233", custom)
+	}
+}
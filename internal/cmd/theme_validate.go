@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+var themeValidateCmd = &cobra.Command{
+	Use:   "validate <file>",
+	Short: "Check a custom themes file before distributing it",
+	Long: `Check every theme in a themes file and report all problems.
+
+The file is a JSON object of custom themes by name, in the format of
+theme.custom in mayor/config.json and theme_defs in rig settings:
+
+  {"house": {"bg": "#102030", "fg": "#f0f0f0", "status_position": "top"}}
+
+Each theme is checked for:
+  - a usable name: non-empty, without spaces or slashes, and not one of
+    the built-in themes (ocean, forest, ..., mayor, unknown), which it
+    would never shadow
+  - both bg and fg set, as colors tmux accepts (colour0-colour255,
+    #rrggbb or a named color)
+  - a valid status_position (top, bottom or unset)
+  - a valid status_center (identity, rig, role, worker or unset)
+  - readable contrast between #rrggbb bg and fg
+
+Exits non-zero if any theme has a problem, so it can gate CI. Nothing is
+read from or written to a town, and no tmux session is touched. Use - to
+read from stdin.
+
+Examples:
+  gt theme validate themes.json
+  gt theme validate - < themes.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runThemeValidate,
+}
+
+func init() {
+	themeCmd.AddCommand(themeValidateCmd)
+}
+
+// themeFileProblems returns every problem with the themes in a themes file,
+// as "<name>: <problem>" in name order.
+func themeFileProblems(defs map[string]config.CustomTheme) []string {
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []string
+	for _, name := range names {
+		def := defs[name]
+		add := func(format string, args ...interface{}) {
+			problems = append(problems, fmt.Sprintf("%q: ", name)+fmt.Sprintf(format, args...))
+		}
+
+		if name == "" || strings.ContainsAny(name, " \t/") {
+			add("bad name (must be non-empty, without spaces or slashes)")
+		} else if isBuiltinThemeName(name) {
+			add("collides with the built-in theme of the same name")
+		}
+		colorsOK := true
+		for _, c := range []struct{ field, value string }{{"bg", def.BG}, {"fg", def.FG}} {
+			if c.value == "" {
+				add("%s is not set", c.field)
+				colorsOK = false
+			} else if err := tmux.ValidateColor(c.value); err != nil {
+				add("%s: %v", c.field, err)
+				colorsOK = false
+			}
+		}
+		if err := tmux.ValidateStatusPosition(def.StatusPosition); err != nil {
+			add("status_position: %v", err)
+		}
+		if err := tmux.ValidateStatusCenter(def.StatusCenter); err != nil {
+			add("status_center: %v", err)
+		}
+		if colorsOK {
+			theme := tmux.Theme{Name: name, BG: def.BG, FG: def.FG}
+			if err := theme.ValidateContrast(); err != nil {
+				add("%v", err)
+			}
+		}
+	}
+	return problems
+}
+
+func runThemeValidate(cmd *cobra.Command, args []string) error {
+	var data []byte
+	var err error
+	if args[0] == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", args[0], err)
+	}
+	var defs map[string]config.CustomTheme
+	if err := json.Unmarshal(data, &defs); err != nil {
+		return fmt.Errorf("parsing %s: %w", args[0], err)
+	}
+
+	problems := themeFileProblems(defs)
+	if len(problems) == 0 {
+		fmt.Printf("%s %d theme(s) in %s are valid\n", style.Success.Render("✓"), len(defs), args[0])
+		return nil
+	}
+	for _, p := range problems {
+		fmt.Fprintf(os.Stderr, "  %s %s\n", style.Error.Render("✗"), p)
+	}
+	return fmt.Errorf("%s: %d problem(s) in %d theme(s)", args[0], len(problems), len(defs))
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+func TestThemeFileProblems(t *testing.T) {
+	if got := themeFileProblems(map[string]config.CustomTheme{
+		"house": {BG: "#102030", FG: "#f0f0f0", StatusPosition: "top", StatusCenter: "rig"},
+		"named": {BG: "blue", FG: "brightwhite"},
+	}); len(got) != 0 {
+		t.Errorf("valid file: problems = %q", got)
+	}
+
+	got := themeFileProblems(map[string]config.CustomTheme{
+		"ocean":   {BG: "#102030", FG: "#f0f0f0"},
+		"my team": {BG: "#102030", FG: "#f0f0f0"},
+		"bad":     {BG: "darkorange", StatusPosition: "side", StatusCenter: "clock"},
+		"murky":   {BG: "#202020", FG: "#303030"},
+	})
+	want := []string{
+		`"bad": bg: ` + tmux.ValidateColor("darkorange").Error(),
+		`"bad": fg is not set`,
+		`"bad": status_position: invalid position "side" (want top or bottom)`,
+		`"bad": status_center: invalid status center "clock" (want identity, rig, role, worker)`,
+		`"murky": low contrast: background and foreground lightness differ by 6% (need 15%)`,
+		`"my team": bad name (must be non-empty, without spaces or slashes)`,
+		`"ocean": collides with the built-in theme of the same name`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("problems =\n%q\nwant\n%q", got, want)
+	}
+}
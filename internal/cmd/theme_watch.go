@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+var (
+	themeWatchFlag     bool
+	themeWatchInterval time.Duration
+)
+
+func init() {
+	themeApplyCmd.Flags().BoolVar(&themeWatchFlag, "watch", false, "Keep running, theming new sessions as they appear")
+	themeApplyCmd.Flags().DurationVar(&themeWatchInterval, "interval", 5*time.Second, "With --watch, how often to look for new sessions")
+	themeApplyCmd.MarkFlagsMutuallyExclusive("watch", "reset")
+	themeApplyCmd.MarkFlagsMutuallyExclusive("watch", "atomic")
+	themeApplyCmd.MarkFlagsMutuallyExclusive("watch", "json")
+}
+
+// themeWatchAction is what 'gt theme apply --watch' does with a session
+// whose status bar currently has cur (curErr if it couldn't be read) and
+// should have theme.
+type themeWatchAction int
+
+const (
+	themeWatchApply      themeWatchAction = iota // not themed yet: apply
+	themeWatchSkip                               // already has the expected theme
+	themeWatchCustomized                         // colors look set by hand: leave alone
+)
+
+// watchActionFor decides a session's themeWatchAction, with the same rules
+// as --only-missing: themed sessions are skipped, and ones that look
+// manually customized are left alone unless --force.
+func watchActionFor(cur tmux.Theme, curErr error, rig string, theme tmux.Theme) themeWatchAction {
+	if curErr != nil {
+		return themeWatchApply
+	}
+	if cur.Equal(theme) {
+		return themeWatchSkip
+	}
+	if !themeForceFlag && themeLooksCustomized(cur, rig, theme) {
+		return themeWatchCustomized
+	}
+	return themeWatchApply
+}
+
+// watchThemeApply themes sessions in scope for rigName that don't have
+// their theme yet, then keeps looking every --interval until interrupted,
+// logging each session it themes: a foreground alternative to tmux hooks
+// for sessions started outside 'gt session new'.
+func watchThemeApply(rigName string, all bool) error {
+	if themeWatchInterval <= 0 {
+		return fmt.Errorf("--interval must be positive, got %s", themeWatchInterval)
+	}
+	if globalDryRun {
+		return fmt.Errorf("--watch does not support --dry-run (use gt theme apply --only-missing --dry-run)")
+	}
+	t := themeTmux()
+	if t.Socket() != "" {
+		if err := t.CheckServer(); err != nil {
+			return err
+		}
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	ticker := time.NewTicker(themeWatchInterval)
+	defer ticker.Stop()
+
+	scope := "rig " + rigName
+	if all || rigName == "" {
+		scope = "all rigs"
+	}
+	fmt.Printf("%s\n", style.Dim.Render(fmt.Sprintf("Watching %s for new sessions every %s (Ctrl+C to stop)", scope, themeWatchInterval)))
+
+	reported := make(map[string]string) // session -> last warning logged, so each is logged once
+	for {
+		themeWatchTick(t, rigName, all, reported)
+
+		select {
+		case <-sigChan:
+			fmt.Println("Stopped.")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// themeWatchTick themes the sessions in scope that need it, logging each
+// apply with a timestamp. Failures and skipped customized sessions are
+// logged only when they change, so a broken session doesn't flood the log.
+func themeWatchTick(t *tmux.Tmux, rigName string, all bool, reported map[string]string) {
+	now := time.Now().Format("15:04:05")
+	sessions, err := t.ListSessions()
+	if err != nil {
+		warnOnce(reported, "", now, fmt.Sprintf("listing sessions: %v", err))
+		return
+	}
+	delete(reported, "")
+
+	caps := tmux.DetectColorCaps()
+	var applied []string
+	failed := 0
+	for _, sess := range sessions {
+		rig, worker, role, ok := parseThemeSession(sess)
+		if !ok || !themeSessionInScope(rig, role, rigName, all) {
+			continue
+		}
+		theme := sessionTheme(rig, worker, role).ForCaps(caps)
+
+		cur, curErr := t.CurrentTheme(sess)
+		switch watchActionFor(cur, curErr, rig, theme) {
+		case themeWatchSkip:
+			continue
+		case themeWatchCustomized:
+			warnOnce(reported, sess, now, fmt.Sprintf("%s: looks manually customized (%s); skipping", sess, cur.Style()))
+			continue
+		}
+
+		if err := applyThemeToSession(t, sess, rig, worker, role, theme); err != nil {
+			warnOnce(reported, sess, now, fmt.Sprintf("%s: %v", sess, err))
+			failed++
+			continue
+		}
+		delete(reported, sess)
+		fmt.Printf("[%s] %s: applied %s theme\n", now, sess, theme.Name)
+		applied = append(applied, sess)
+	}
+
+	if len(applied) > 0 {
+		runThemePostApplyHook(rigName, all, applied, failed)
+	}
+}
+
+// warnOnce logs msg to stderr, stamped with now, unless it is the last
+// message logged for key.
+func warnOnce(reported map[string]string, key, now, msg string) {
+	if last, seen := reported[key]; seen && last == msg {
+		return
+	}
+	reported[key] = msg
+	fmt.Fprintf(os.Stderr, "[%s] %s\n", now, msg)
+}
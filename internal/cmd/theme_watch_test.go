@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+func TestWatchActionFor(t *testing.T) {
+	defer func() { themeForceFlag = false }()
+	theme := tmux.AssignTheme("gastown")
+	custom := tmux.Theme{BG: "#123456", FG: "#654321"}
+
+	if got := watchActionFor(tmux.Theme{}, errors.New("no status-style"), "gastown", theme); got != themeWatchApply {
+		t.Errorf("unreadable status: action = %v, want apply", got)
+	}
+	if got := watchActionFor(theme, nil, "gastown", theme); got != themeWatchSkip {
+		t.Errorf("already themed: action = %v, want skip", got)
+	}
+	if got := watchActionFor(custom, nil, "gastown", theme); got != themeWatchCustomized {
+		t.Errorf("hand-set colors: action = %v, want customized", got)
+	}
+	themeForceFlag = true
+	if got := watchActionFor(custom, nil, "gastown", theme); got != themeWatchApply {
+		t.Errorf("hand-set colors with --force: action = %v, want apply", got)
+	}
+}
+
+func TestWarnOnce(t *testing.T) {
+	reported := make(map[string]string)
+	var logged []string
+	for _, msg := range []string{"boom", "boom", "bang", "boom"} {
+		before := reported["gt-gastown-max"]
+		warnOnce(reported, "gt-gastown-max", "12:00:00", msg)
+		if reported["gt-gastown-max"] != before {
+			logged = append(logged, msg)
+		}
+	}
+	if len(logged) != 3 {
+		t.Errorf("logged %v, want each change logged once", logged)
+	}
+}
@@ -170,13 +170,20 @@ func runUnsling(cmd *cobra.Command, args []string) error {
 	// Previously, only the agent's hook slot was cleared but the bead itself stayed
 	// in "hooked" status forever. Now we update the bead to match the documented
 	// behavior: "The bead's status changes from 'hooked' back to 'open'."
+	// Clearing picked_up_at makes the next pickup start fresh.
 	if hookedBead.Status == beads.StatusHooked {
 		openStatus := "open"
 		emptyAssignee := ""
-		if err := b.Update(hookedBeadID, beads.UpdateOptions{
+		opts := beads.UpdateOptions{
 			Status:   &openStatus,
 			Assignee: &emptyAssignee,
-		}); err != nil {
+		}
+		if fields := beads.ParseAttachmentFields(hookedBead); fields != nil && fields.PickedUpAt != "" {
+			fields.PickedUpAt = ""
+			desc := beads.SetAttachmentFields(hookedBead, fields)
+			opts.Description = &desc
+		}
+		if err := b.Update(hookedBeadID, opts); err != nil {
 			// Non-fatal: warn but don't fail the unsling. The hook slot is already
 			// cleared, so the agent is unblocked. The bead status is a bookkeeping
 			// issue that can be fixed manually.
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -22,6 +23,11 @@ Identity is determined by:
 
 Use --identity flag with mail commands to override.
 
+It also prints the detected context - town root, rig, tmux session, and
+worker/role - with where each value came from, or why it couldn't be
+determined, plus the Gas Town environment variables that are set. Use it
+to debug commands (like 'gt theme') that report an "unknown" rig.
+
 Examples:
   gt whoami                      # Show current identity
   gt mail inbox                  # Check inbox for current identity
@@ -76,5 +82,64 @@ func runWhoami(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	printWhoamiContext()
 	return nil
 }
+
+// whoamiEnvVars are the environment variables that feed context detection.
+var whoamiEnvVars = []string{
+	"GT_ROLE", "GT_RIG", "GT_POLECAT", "GT_CREW", "GT_TOWN_ROOT",
+	"BD_ACTOR", "BEADS_DIR", "TMUX", "TMUX_PANE",
+}
+
+// printWhoamiContext prints everything gt infers about the current context.
+func printWhoamiContext() {
+	fmt.Printf("\n%s\n", style.Bold.Render("Context:"))
+
+	if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
+		source := "cwd"
+		if os.Getenv("GT_TOWN_ROOT") != "" {
+			source = "GT_TOWN_ROOT"
+		}
+		fmt.Printf("  Town:    %s %s\n", townRoot, style.Dim.Render("("+source+")"))
+	} else {
+		fmt.Printf("  Town:    %s\n", style.Dim.Render("(none: cwd not inside a Gas Town workspace)"))
+	}
+
+	if rig, reason := detectCurrentRigWithReason(); rig != "" {
+		fmt.Printf("  Rig:     %s %s\n", rig, style.Dim.Render("(from "+reason+")"))
+	} else {
+		fmt.Printf("  Rig:     %s\n", style.Dim.Render("(none: "+reason+")"))
+	}
+
+	session, source := "", ""
+	if tmux.IsInsideTmux() {
+		if s, err := getCurrentTmuxSession(); err == nil {
+			session, source = s, "tmux"
+		}
+	}
+	if session == "" {
+		if s := detectCurrentSession(); s != "" {
+			session, source = s, "built from GT_* env"
+		}
+	}
+	if session == "" {
+		fmt.Printf("  Session: %s\n", style.Dim.Render("(none: not in tmux and no GT_* session env)"))
+	} else {
+		fmt.Printf("  Session: %s %s\n", session, style.Dim.Render("("+source+")"))
+		if _, worker, role, ok := parseThemeSession(session); ok {
+			fmt.Printf("  Worker:  %s %s\n", worker, style.Dim.Render("(role: "+role+")"))
+		} else {
+			fmt.Printf("  Worker:  %s\n", style.Dim.Render("(none: session name is not a Gas Town agent session)"))
+		}
+	}
+
+	fmt.Printf("\n%s\n", style.Bold.Render("Environment:"))
+	for _, name := range whoamiEnvVars {
+		if v, ok := os.LookupEnv(name); ok {
+			fmt.Printf("  %s=%s\n", name, v)
+		} else {
+			fmt.Printf("  %s %s\n", name, style.Dim.Render("(unset)"))
+		}
+	}
+}
@@ -8,12 +8,18 @@ import (
 	"os/exec"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/fsys"
 )
 
+// fileSystem is used for all config file IO in this file. Tests can swap in
+// an in-memory fsys.Mem.
+var fileSystem fsys.FS = fsys.OS{}
+
 var (
 	// ErrNotFound indicates the config file does not exist.
 	ErrNotFound = errors.New("config file not found")
@@ -30,7 +36,7 @@ var (
 
 // LoadTownConfig loads and validates a town configuration file.
 func LoadTownConfig(path string) (*TownConfig, error) {
-	data, err := os.ReadFile(path) //nolint:gosec // G304: path is from trusted config location
+	data, err := fileSystem.ReadFile(path) //nolint:gosec // G304: path is from trusted config location
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
@@ -56,7 +62,7 @@ func SaveTownConfig(path string, config *TownConfig) error {
 		return err
 	}
 
-	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+	if err := fileSystem.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return fmt.Errorf("creating directory: %w", err)
 	}
 
@@ -65,7 +71,7 @@ func SaveTownConfig(path string, config *TownConfig) error {
 		return fmt.Errorf("encoding config: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0600); err != nil {
+	if err := fileSystem.WriteFile(path, data, 0600); err != nil {
 		return fmt.Errorf("writing config: %w", err)
 	}
 
@@ -74,7 +80,7 @@ func SaveTownConfig(path string, config *TownConfig) error {
 
 // LoadRigsConfig loads and validates a rigs registry file.
 func LoadRigsConfig(path string) (*RigsConfig, error) {
-	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
+	data, err := fileSystem.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
@@ -100,7 +106,7 @@ func SaveRigsConfig(path string, config *RigsConfig) error {
 		return err
 	}
 
-	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+	if err := fileSystem.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return fmt.Errorf("creating directory: %w", err)
 	}
 
@@ -109,7 +115,7 @@ func SaveRigsConfig(path string, config *RigsConfig) error {
 		return fmt.Errorf("encoding config: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0600); err != nil {
+	if err := fileSystem.WriteFile(path, data, 0600); err != nil {
 		return fmt.Errorf("writing config: %w", err)
 	}
 
@@ -143,7 +149,7 @@ func validateRigsConfig(c *RigsConfig) error {
 
 // LoadRigConfig loads and validates a rig configuration file.
 func LoadRigConfig(path string) (*RigConfig, error) {
-	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
+	data, err := fileSystem.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
@@ -156,21 +162,71 @@ func LoadRigConfig(path string) (*RigConfig, error) {
 		return nil, fmt.Errorf("parsing config: %w", err)
 	}
 
-	if err := validateRigConfig(&config); err != nil {
+	if err := ValidateRigConfig(&config); err != nil {
 		return nil, err
 	}
 
 	return &config, nil
 }
 
-// SaveRigConfig saves a rig configuration to a file.
+// RigDir returns the directory of rigName within townRoot, honoring a
+// nested Path registered for it in mayor/rigs.json.
+func RigDir(townRoot, rigName string) string {
+	if rigs, err := LoadRigsConfig(constants.MayorRigsPath(townRoot)); err == nil {
+		if entry, ok := rigs.Rigs[rigName]; ok {
+			return entry.Dir(townRoot, rigName)
+		}
+	}
+	return filepath.Join(townRoot, rigName)
+}
+
+// RigDisplayName returns the name rigName is shown as: the display_name in
+// its config.json, or rigName itself when unset or unreadable.
+func RigDisplayName(townRoot, rigName string) string {
+	if rigName == "" || townRoot == "" {
+		return rigName
+	}
+	cfg, err := LoadRigConfig(filepath.Join(RigDir(townRoot, rigName), "config.json"))
+	if err != nil || cfg.DisplayName == "" {
+		return rigName
+	}
+	return cfg.DisplayName
+}
+
+// FindRigFromDir returns the name of the rig containing dir: the nearest
+// ancestor below townRoot holding a rig config.json (type "rig"). This
+// handles nested layouts such as <town>/group/rig-a. Returns "" when dir
+// is not inside a rig.
+func FindRigFromDir(townRoot, dir string) string {
+	townRoot = filepath.Clean(townRoot)
+	for dir = filepath.Clean(dir); dir != townRoot; dir = filepath.Dir(dir) {
+		if rel, err := filepath.Rel(townRoot, dir); err != nil || strings.HasPrefix(rel, "..") {
+			return ""
+		}
+		if cfg, err := LoadRigConfig(filepath.Join(dir, "config.json")); err == nil && cfg.Type == "rig" {
+			return cfg.Name
+		}
+	}
+	return ""
+}
+
+// SaveRigConfig saves a rig configuration to a file. The write is atomic
+// and locked against other gt processes; use UpdateRigConfig for a
+// read-modify-write.
 func SaveRigConfig(path string, config *RigConfig) error {
-	if err := validateRigConfig(config); err != nil {
+	unlock, err := lockConfigFile(path)
+	if err != nil {
 		return err
 	}
+	defer unlock()
+	return writeRigConfig(path, config)
+}
 
-	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
-		return fmt.Errorf("creating directory: %w", err)
+// writeRigConfig validates and atomically writes a rig config; the caller
+// holds the file's lock.
+func writeRigConfig(path string, config *RigConfig) error {
+	if err := ValidateRigConfig(config); err != nil {
+		return err
 	}
 
 	data, err := json.MarshalIndent(config, "", "  ")
@@ -178,15 +234,16 @@ func SaveRigConfig(path string, config *RigConfig) error {
 		return fmt.Errorf("encoding config: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil { //nolint:gosec // G306: config files don't contain secrets
+	if err := writeFileAtomic(path, data); err != nil {
 		return fmt.Errorf("writing config: %w", err)
 	}
 
 	return nil
 }
 
-// validateRigConfig validates a RigConfig (identity only).
-func validateRigConfig(c *RigConfig) error {
+// ValidateRigConfig validates a RigConfig (identity only). It rejects
+// configs whose Version is newer than this gt supports.
+func ValidateRigConfig(c *RigConfig) error {
 	if c.Type != "rig" && c.Type != "" {
 		return fmt.Errorf("%w: expected type 'rig', got '%s'", ErrInvalidType, c.Type)
 	}
@@ -199,8 +256,8 @@ func validateRigConfig(c *RigConfig) error {
 	return nil
 }
 
-// validateRigSettings validates a RigSettings.
-func validateRigSettings(c *RigSettings) error {
+// ValidateRigSettings validates a RigSettings.
+func ValidateRigSettings(c *RigSettings) error {
 	if c.Type != "rig-settings" && c.Type != "" {
 		return fmt.Errorf("%w: expected type 'rig-settings', got '%s'", ErrInvalidType, c.Type)
 	}
@@ -212,6 +269,145 @@ func validateRigSettings(c *RigSettings) error {
 			return err
 		}
 	}
+	if c.Theme != nil {
+		if err := validateThemeDefs(c.Theme.ThemeDefs); err != nil {
+			return err
+		}
+		if err := validateRefreshInterval(c.Theme.RefreshInterval); err != nil {
+			return err
+		}
+		if c.Theme.StatusLeftLength < 0 || c.Theme.StatusRightLength < 0 {
+			return fmt.Errorf("%w: status_left_length and status_right_length must be non-negative", ErrMissingField)
+		}
+		if c.Theme.Layout != "" {
+			if err := ValidateLayout(c.Theme.Layout); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ErrInvalidRefreshInterval indicates a negative theme refresh_interval.
+var ErrInvalidRefreshInterval = errors.New("invalid refresh_interval")
+
+// validateRefreshInterval checks a status bar refresh interval: a positive
+// number of seconds, or 0 for the default.
+func validateRefreshInterval(seconds int) error {
+	if seconds < 0 {
+		return fmt.Errorf("%w: %d (want a positive number of seconds, or 0 for the default)", ErrInvalidRefreshInterval, seconds)
+	}
+	return nil
+}
+
+// ErrInvalidLayout indicates a theme layout tmux would not accept.
+var ErrInvalidLayout = errors.New("invalid layout")
+
+// LayoutPresets are tmux's built-in window layouts.
+var LayoutPresets = []string{"even-horizontal", "even-vertical", "main-horizontal", "main-vertical", "tiled"}
+
+// ValidateLayout checks a tmux window layout: one of LayoutPresets, or a
+// custom layout string as printed by #{window_layout}, whose checksum
+// must match.
+func ValidateLayout(layout string) error {
+	for _, preset := range LayoutPresets {
+		if layout == preset {
+			return nil
+		}
+	}
+	sum, body, ok := strings.Cut(layout, ",")
+	if !ok || len(sum) != 4 {
+		return fmt.Errorf("%w: %q (want one of %s, or a #{window_layout} string)",
+			ErrInvalidLayout, layout, strings.Join(LayoutPresets, ", "))
+	}
+	want, err := strconv.ParseUint(sum, 16, 16)
+	if err != nil {
+		return fmt.Errorf("%w: %q: bad checksum %q", ErrInvalidLayout, layout, sum)
+	}
+	if rest, ok := parseLayoutCell(body); !ok || rest != "" {
+		return fmt.Errorf("%w: %q: malformed layout", ErrInvalidLayout, layout)
+	}
+	if got := layoutChecksum(body); uint64(got) != want {
+		return fmt.Errorf("%w: %q: checksum %04x doesn't match its cells (%04x)", ErrInvalidLayout, layout, want, got)
+	}
+	return nil
+}
+
+// parseLayoutCell consumes one layout cell, "WxH,X,Y" followed by a pane ID
+// (",N") or child cells ("{...}" side by side, "[...]" stacked), and
+// returns the unparsed remainder.
+func parseLayoutCell(s string) (string, bool) {
+	var ok bool
+	if s, ok = parseLayoutNumber(s); !ok {
+		return "", false
+	}
+	for _, sep := range []string{"x", ",", ","} {
+		if !strings.HasPrefix(s, sep) {
+			return "", false
+		}
+		if s, ok = parseLayoutNumber(s[1:]); !ok {
+			return "", false
+		}
+	}
+	switch {
+	case strings.HasPrefix(s, ","):
+		return parseLayoutNumber(s[1:])
+	case strings.HasPrefix(s, "{"), strings.HasPrefix(s, "["):
+		end := "}"
+		if s[0] == '[' {
+			end = "]"
+		}
+		s = s[1:]
+		for {
+			if s, ok = parseLayoutCell(s); !ok {
+				return "", false
+			}
+			if strings.HasPrefix(s, ",") {
+				s = s[1:]
+				continue
+			}
+			if !strings.HasPrefix(s, end) {
+				return "", false
+			}
+			return s[1:], true
+		}
+	}
+	return s, true
+}
+
+// parseLayoutNumber consumes a decimal number.
+func parseLayoutNumber(s string) (string, bool) {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return s[i:], i > 0
+}
+
+// layoutChecksum is tmux's layout_checksum over a layout's cells.
+func layoutChecksum(layout string) uint16 {
+	var sum uint16
+	for i := 0; i < len(layout); i++ {
+		sum = (sum >> 1) + ((sum & 1) << 15)
+		sum += uint16(layout[i])
+	}
+	return sum
+}
+
+// ErrInvalidThemeDef indicates a malformed rig-local theme definition.
+var ErrInvalidThemeDef = errors.New("invalid theme definition")
+
+// validateThemeDefs validates a rig's embedded theme definitions: names
+// must be non-empty words and both colors must be set.
+func validateThemeDefs(defs map[string]CustomTheme) error {
+	for name, def := range defs {
+		if name == "" || strings.ContainsAny(name, " \t/") {
+			return fmt.Errorf("%w: bad name %q", ErrInvalidThemeDef, name)
+		}
+		if def.BG == "" || def.FG == "" {
+			return fmt.Errorf("%w: %s needs both bg and fg", ErrInvalidThemeDef, name)
+		}
+	}
 	return nil
 }
 
@@ -266,7 +462,7 @@ func NewRigSettings() *RigSettings {
 
 // LoadRigSettings loads and validates a rig settings file.
 func LoadRigSettings(path string) (*RigSettings, error) {
-	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
+	data, err := fileSystem.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
@@ -274,26 +470,41 @@ func LoadRigSettings(path string) (*RigSettings, error) {
 		return nil, fmt.Errorf("reading settings: %w", err)
 	}
 
+	return ParseRigSettings(data)
+}
+
+// ParseRigSettings parses and validates rig settings JSON, as read from a
+// settings/config.json file.
+func ParseRigSettings(data []byte) (*RigSettings, error) {
 	var settings RigSettings
 	if err := json.Unmarshal(data, &settings); err != nil {
 		return nil, fmt.Errorf("parsing settings: %w", err)
 	}
 
-	if err := validateRigSettings(&settings); err != nil {
+	if err := ValidateRigSettings(&settings); err != nil {
 		return nil, err
 	}
 
 	return &settings, nil
 }
 
-// SaveRigSettings saves rig settings to a file.
+// SaveRigSettings saves rig settings to a file. The write is atomic and
+// locked against other gt processes; use UpdateRigSettings for a
+// read-modify-write.
 func SaveRigSettings(path string, settings *RigSettings) error {
-	if err := validateRigSettings(settings); err != nil {
+	unlock, err := lockConfigFile(path)
+	if err != nil {
 		return err
 	}
+	defer unlock()
+	return writeRigSettings(path, settings)
+}
 
-	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
-		return fmt.Errorf("creating directory: %w", err)
+// writeRigSettings validates and atomically writes rig settings; the
+// caller holds the file's lock.
+func writeRigSettings(path string, settings *RigSettings) error {
+	if err := ValidateRigSettings(settings); err != nil {
+		return err
 	}
 
 	data, err := json.MarshalIndent(settings, "", "  ")
@@ -301,7 +512,7 @@ func SaveRigSettings(path string, settings *RigSettings) error {
 		return fmt.Errorf("encoding settings: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil { //nolint:gosec // G306: settings files don't contain secrets
+	if err := writeFileAtomic(path, data); err != nil {
 		return fmt.Errorf("writing settings: %w", err)
 	}
 
@@ -310,7 +521,7 @@ func SaveRigSettings(path string, settings *RigSettings) error {
 
 // LoadMayorConfig loads and validates a mayor config file.
 func LoadMayorConfig(path string) (*MayorConfig, error) {
-	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
+	data, err := fileSystem.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
@@ -336,7 +547,7 @@ func SaveMayorConfig(path string, config *MayorConfig) error {
 		return err
 	}
 
-	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+	if err := fileSystem.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return fmt.Errorf("creating directory: %w", err)
 	}
 
@@ -345,7 +556,7 @@ func SaveMayorConfig(path string, config *MayorConfig) error {
 		return fmt.Errorf("encoding config: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil { //nolint:gosec // G306: config files don't contain secrets
+	if err := fileSystem.WriteFile(path, data, 0644); err != nil { //nolint:gosec // G306: config files don't contain secrets
 		return fmt.Errorf("writing config: %w", err)
 	}
 
@@ -360,6 +571,11 @@ func validateMayorConfig(c *MayorConfig) error {
 	if c.Version > CurrentMayorConfigVersion {
 		return fmt.Errorf("%w: got %d, max supported %d", ErrInvalidVersion, c.Version, CurrentMayorConfigVersion)
 	}
+	if c.Theme != nil {
+		if err := validateRefreshInterval(c.Theme.RefreshInterval); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -378,7 +594,7 @@ func DaemonPatrolConfigPath(townRoot string) string {
 
 // LoadDaemonPatrolConfig loads and validates a daemon patrol config file.
 func LoadDaemonPatrolConfig(path string) (*DaemonPatrolConfig, error) {
-	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally
+	data, err := fileSystem.ReadFile(path) //nolint:gosec // G304: path is constructed internally
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
@@ -404,7 +620,7 @@ func SaveDaemonPatrolConfig(path string, config *DaemonPatrolConfig) error {
 		return err
 	}
 
-	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+	if err := fileSystem.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return fmt.Errorf("creating directory: %w", err)
 	}
 
@@ -413,7 +629,7 @@ func SaveDaemonPatrolConfig(path string, config *DaemonPatrolConfig) error {
 		return fmt.Errorf("encoding daemon patrol config: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil { //nolint:gosec // G306: config files don't contain secrets
+	if err := fileSystem.WriteFile(path, data, 0644); err != nil { //nolint:gosec // G306: config files don't contain secrets
 		return fmt.Errorf("writing daemon patrol config: %w", err)
 	}
 
@@ -433,7 +649,7 @@ func validateDaemonPatrolConfig(c *DaemonPatrolConfig) error {
 // EnsureDaemonPatrolConfig creates the daemon patrol config if it doesn't exist.
 func EnsureDaemonPatrolConfig(townRoot string) error {
 	path := DaemonPatrolConfigPath(townRoot)
-	if _, err := os.Stat(path); err != nil {
+	if _, err := fileSystem.Stat(path); err != nil {
 		if !os.IsNotExist(err) {
 			return fmt.Errorf("checking daemon patrol config: %w", err)
 		}
@@ -444,7 +660,7 @@ func EnsureDaemonPatrolConfig(townRoot string) error {
 
 // LoadAccountsConfig loads and validates an accounts configuration file.
 func LoadAccountsConfig(path string) (*AccountsConfig, error) {
-	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
+	data, err := fileSystem.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
@@ -470,7 +686,7 @@ func SaveAccountsConfig(path string, config *AccountsConfig) error {
 		return err
 	}
 
-	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+	if err := fileSystem.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return fmt.Errorf("creating directory: %w", err)
 	}
 
@@ -479,7 +695,7 @@ func SaveAccountsConfig(path string, config *AccountsConfig) error {
 		return fmt.Errorf("encoding accounts config: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil { //nolint:gosec // G306: accounts config doesn't contain sensitive credentials
+	if err := fileSystem.WriteFile(path, data, 0644); err != nil { //nolint:gosec // G306: accounts config doesn't contain sensitive credentials
 		return fmt.Errorf("writing accounts config: %w", err)
 	}
 
@@ -591,7 +807,7 @@ func expandPath(path string) string {
 
 // LoadMessagingConfig loads and validates a messaging configuration file.
 func LoadMessagingConfig(path string) (*MessagingConfig, error) {
-	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
+	data, err := fileSystem.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
@@ -617,7 +833,7 @@ func SaveMessagingConfig(path string, config *MessagingConfig) error {
 		return err
 	}
 
-	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+	if err := fileSystem.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return fmt.Errorf("creating directory: %w", err)
 	}
 
@@ -626,7 +842,7 @@ func SaveMessagingConfig(path string, config *MessagingConfig) error {
 		return fmt.Errorf("encoding messaging config: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil { //nolint:gosec // G306: messaging config doesn't contain secrets
+	if err := fileSystem.WriteFile(path, data, 0644); err != nil { //nolint:gosec // G306: messaging config doesn't contain secrets
 		return fmt.Errorf("writing messaging config: %w", err)
 	}
 
@@ -742,7 +958,7 @@ func RigSettingsPath(rigPath string) string {
 
 // LoadOrCreateTownSettings loads town settings or creates defaults if missing.
 func LoadOrCreateTownSettings(path string) (*TownSettings, error) {
-	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally
+	data, err := fileSystem.ReadFile(path) //nolint:gosec // G304: path is constructed internally
 	if err != nil {
 		if os.IsNotExist(err) {
 			return NewTownSettings(), nil
@@ -766,7 +982,7 @@ func SaveTownSettings(path string, settings *TownSettings) error {
 		return fmt.Errorf("%w: got %d, max supported %d", ErrInvalidVersion, settings.Version, CurrentTownSettingsVersion)
 	}
 
-	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+	if err := fileSystem.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return fmt.Errorf("creating directory: %w", err)
 	}
 
@@ -775,7 +991,7 @@ func SaveTownSettings(path string, settings *TownSettings) error {
 		return fmt.Errorf("encoding settings: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil { //nolint:gosec // G306: settings files don't contain secrets
+	if err := fileSystem.WriteFile(path, data, 0644); err != nil { //nolint:gosec // G306: settings files don't contain secrets
 		return fmt.Errorf("writing settings: %w", err)
 	}
 
@@ -1275,7 +1491,7 @@ func findTownRootFromCwd() (string, error) {
 
 	current := absDir
 	for {
-		if _, err := os.Stat(filepath.Join(current, marker)); err == nil {
+		if _, err := fileSystem.Stat(filepath.Join(current, marker)); err == nil {
 			return current, nil
 		}
 
@@ -1660,7 +1876,7 @@ func EscalationConfigPath(townRoot string) string {
 
 // LoadEscalationConfig loads and validates an escalation configuration file.
 func LoadEscalationConfig(path string) (*EscalationConfig, error) {
-	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
+	data, err := fileSystem.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
@@ -1698,7 +1914,7 @@ func SaveEscalationConfig(path string, config *EscalationConfig) error {
 		return err
 	}
 
-	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+	if err := fileSystem.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return fmt.Errorf("creating directory: %w", err)
 	}
 
@@ -1707,7 +1923,7 @@ func SaveEscalationConfig(path string, config *EscalationConfig) error {
 		return fmt.Errorf("encoding escalation config: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil { //nolint:gosec // G306: escalation config doesn't contain secrets
+	if err := fileSystem.WriteFile(path, data, 0644); err != nil { //nolint:gosec // G306: escalation config doesn't contain secrets
 		return fmt.Errorf("writing escalation config: %w", err)
 	}
 
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"encoding/json"
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -10,6 +12,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/fsys"
 )
 
 // skipIfAgentBinaryMissing skips the test if any of the specified agent binaries
@@ -282,9 +285,9 @@ func TestRigConfigValidation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateRigConfig(tt.config)
+			err := ValidateRigConfig(tt.config)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("validateRigConfig() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("ValidateRigConfig() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
@@ -348,9 +351,9 @@ func TestRigSettingsValidation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateRigSettings(tt.settings)
+			err := ValidateRigSettings(tt.settings)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("validateRigSettings() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("ValidateRigSettings() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
@@ -3515,3 +3518,213 @@ func TestBuildStartupCommandWithAgentOverride_NoGTAgentWhenNoOverride(t *testing
 		t.Errorf("expected no GT_AGENT in command when no override, got: %q", cmd)
 	}
 }
+
+func TestRigDirAndFindRigFromDir(t *testing.T) {
+	townRoot := t.TempDir()
+	rigs := &RigsConfig{
+		Version: 1,
+		Rigs: map[string]RigEntry{
+			"flat":  {GitURL: "git@example.com:flat.git"},
+			"rig-a": {GitURL: "git@example.com:a.git", Path: "group/rig-a"},
+		},
+	}
+	if err := SaveRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"), rigs); err != nil {
+		t.Fatalf("SaveRigsConfig: %v", err)
+	}
+
+	if got, want := RigDir(townRoot, "flat"), filepath.Join(townRoot, "flat"); got != want {
+		t.Errorf("RigDir(flat) = %q, want %q", got, want)
+	}
+	nested := filepath.Join(townRoot, "group", "rig-a")
+	if got := RigDir(townRoot, "rig-a"); got != nested {
+		t.Errorf("RigDir(rig-a) = %q, want %q", got, nested)
+	}
+	if got, want := RigDir(townRoot, "unknown"), filepath.Join(townRoot, "unknown"); got != want {
+		t.Errorf("RigDir(unknown) = %q, want %q", got, want)
+	}
+
+	if err := SaveRigConfig(filepath.Join(nested, "config.json"), NewRigConfig("rig-a", "git@example.com:a.git")); err != nil {
+		t.Fatalf("SaveRigConfig: %v", err)
+	}
+	deep := filepath.Join(nested, "crew", "max", "src")
+	if err := os.MkdirAll(deep, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := FindRigFromDir(townRoot, deep); got != "rig-a" {
+		t.Errorf("FindRigFromDir(deep nested) = %q, want rig-a", got)
+	}
+	if got := FindRigFromDir(townRoot, filepath.Join(townRoot, "group")); got != "" {
+		t.Errorf("FindRigFromDir(group) = %q, want empty", got)
+	}
+	if got := FindRigFromDir(townRoot, t.TempDir()); got != "" {
+		t.Errorf("FindRigFromDir(outside town) = %q, want empty", got)
+	}
+}
+
+func TestRigDisplayName(t *testing.T) {
+	townRoot := t.TempDir()
+	cfg := NewRigConfig("acme-web-2", "git@example.com:web.git")
+	if err := SaveRigConfig(filepath.Join(townRoot, "acme-web-2", "config.json"), cfg); err != nil {
+		t.Fatalf("SaveRigConfig: %v", err)
+	}
+	if got := RigDisplayName(townRoot, "acme-web-2"); got != "acme-web-2" {
+		t.Errorf("RigDisplayName() without display_name = %q, want the rig name", got)
+	}
+
+	cfg.DisplayName = "Acme Web"
+	if err := SaveRigConfig(filepath.Join(townRoot, "acme-web-2", "config.json"), cfg); err != nil {
+		t.Fatalf("SaveRigConfig: %v", err)
+	}
+	if got := RigDisplayName(townRoot, "acme-web-2"); got != "Acme Web" {
+		t.Errorf("RigDisplayName() = %q, want Acme Web", got)
+	}
+	if got := RigDisplayName(townRoot, "missing"); got != "missing" {
+		t.Errorf("RigDisplayName(missing) = %q, want the rig name", got)
+	}
+}
+
+// useMemFS swaps the package filesystem for an in-memory one for the rest of
+// the test. Tests using it must not call t.Parallel.
+func useMemFS(t *testing.T) *fsys.Mem {
+	t.Helper()
+	mem := fsys.NewMem()
+	old := fileSystem
+	fileSystem = mem
+	t.Cleanup(func() { fileSystem = old })
+	return mem
+}
+
+func TestRigSettingsRoundTripInMemory(t *testing.T) {
+	mem := useMemFS(t)
+	path := "/town/testrig/settings/config.json"
+
+	original := NewRigSettings()
+	original.Theme = &ThemeConfig{Name: "forest"}
+	if err := SaveRigSettings(path, original); err != nil {
+		t.Fatalf("SaveRigSettings: %v", err)
+	}
+
+	loaded, err := LoadRigSettings(path)
+	if err != nil {
+		t.Fatalf("LoadRigSettings: %v", err)
+	}
+	if loaded.Theme == nil || loaded.Theme.Name != "forest" {
+		t.Errorf("Theme = %+v, want forest", loaded.Theme)
+	}
+	if got := mem.Files(); len(got) != 1 || got[0] != path {
+		t.Errorf("files = %v, want only %s", got, path)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("real filesystem was touched: %v", err)
+	}
+
+	if _, err := LoadRigSettings("/town/missing/settings/config.json"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("LoadRigSettings(missing) = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRigSettingsThemeDefsValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		defs    map[string]CustomTheme
+		wantErr bool
+	}{
+		{"valid", map[string]CustomTheme{"house": {BG: "#102030", FG: "#f0f0f0"}}, false},
+		{"missing fg", map[string]CustomTheme{"house": {BG: "#102030"}}, true},
+		{"empty name", map[string]CustomTheme{"": {BG: "#102030", FG: "#f0f0f0"}}, true},
+		{"name with space", map[string]CustomTheme{"my house": {BG: "#102030", FG: "#f0f0f0"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := NewRigSettings()
+			settings.Theme = &ThemeConfig{ThemeDefs: tt.defs}
+			data, err := json.Marshal(settings)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = ParseRigSettings(data)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("ParseRigSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidThemeDef) {
+				t.Errorf("error = %v, want ErrInvalidThemeDef", err)
+			}
+		})
+	}
+}
+
+func TestRefreshIntervalValidation(t *testing.T) {
+	for _, tt := range []struct {
+		seconds int
+		wantErr bool
+	}{{0, false}, {2, false}, {60, false}, {-1, true}} {
+		settings := NewRigSettings()
+		settings.Theme = &ThemeConfig{RefreshInterval: tt.seconds}
+		data, err := json.Marshal(settings)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = ParseRigSettings(data)
+		if tt.wantErr != (err != nil) {
+			t.Errorf("refresh_interval %d: ParseRigSettings() error = %v, wantErr %v", tt.seconds, err, tt.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidRefreshInterval) {
+			t.Errorf("error = %v, want ErrInvalidRefreshInterval", err)
+		}
+
+		mayor := NewMayorConfig()
+		mayor.Theme = &TownThemeConfig{RefreshInterval: tt.seconds}
+		if err := validateMayorConfig(mayor); tt.wantErr != (err != nil) {
+			t.Errorf("refresh_interval %d: validateMayorConfig() error = %v, wantErr %v", tt.seconds, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateLayout(t *testing.T) {
+	valid := []string{
+		"tiled",
+		"main-vertical",
+		"d67e,80x24,0,0{40x24,0,0,0,39x24,41,0[39x12,41,0,1,39x11,41,13,2]}",
+	}
+	for _, layout := range valid {
+		if err := ValidateLayout(layout); err != nil {
+			t.Errorf("ValidateLayout(%q) = %v, want nil", layout, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"grid",
+		"d67f,80x24,0,0{40x24,0,0,0,39x24,41,0[39x12,41,0,1,39x11,41,13,2]}", // checksum
+		"zzzz,80x24,0,0,0",
+		"1234,80x24,0,0{40x24,0,0,0",
+		"1234,80x24,0",
+	}
+	for _, layout := range invalid {
+		if err := ValidateLayout(layout); !errors.Is(err, ErrInvalidLayout) {
+			t.Errorf("ValidateLayout(%q) = %v, want ErrInvalidLayout", layout, err)
+		}
+	}
+
+	settings := NewRigSettings()
+	settings.Theme = &ThemeConfig{Layout: "grid"}
+	if err := ValidateRigSettings(settings); !errors.Is(err, ErrInvalidLayout) {
+		t.Errorf("ValidateRigSettings with layout grid = %v, want ErrInvalidLayout", err)
+	}
+}
+
+func TestValidateRigSettingsStatusLengths(t *testing.T) {
+	settings := NewRigSettings()
+	settings.Theme = &ThemeConfig{StatusLeftLength: 40, StatusRightLength: 80}
+	if err := ValidateRigSettings(settings); err != nil {
+		t.Errorf("ValidateRigSettings with status lengths 40/80 = %v, want nil", err)
+	}
+
+	settings.Theme = &ThemeConfig{StatusRightLength: -1}
+	if err := ValidateRigSettings(settings); err == nil {
+		t.Error("ValidateRigSettings with status_right_length -1 = nil, want error")
+	}
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/fsys"
+)
+
+// lockConfigFile takes an exclusive cross-process lock guarding path,
+// blocking until it is available. The lock lives in a sibling
+// "<path>.lock" file. It is a no-op when fileSystem is not the real
+// filesystem (tests using fsys.Mem).
+func lockConfigFile(path string) (unlock func(), err error) {
+	if _, ok := fileSystem.(fsys.OS); !ok {
+		return func() {}, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("creating directory: %w", err)
+	}
+	lock := flock.New(path + ".lock")
+	if err := lock.Lock(); err != nil {
+		return nil, fmt.Errorf("locking %s: %w", path, err)
+	}
+	return func() { _ = lock.Unlock() }, nil
+}
+
+// writeFileAtomic writes data to a temp file next to path and renames it
+// into place, so readers never see a torn file. Callers hold the path's
+// lock, which also keeps the fixed temp name from being shared.
+func writeFileAtomic(path string, data []byte) error {
+	if err := fileSystem.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := fileSystem.WriteFile(tmp, data, 0644); err != nil { //nolint:gosec // G306: config files don't contain secrets
+		return err
+	}
+	if err := fileSystem.Rename(tmp, path); err != nil {
+		_ = fileSystem.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// UpdateRigSettings applies update to the rig settings at path as one
+// read-modify-write, holding the file's lock so concurrent gt processes
+// (e.g. 'gt theme' in two panes) cannot lose each other's changes. A
+// missing file starts from NewRigSettings. If update returns an error,
+// nothing is written and the error is returned unchanged.
+func UpdateRigSettings(path string, update func(*RigSettings) error) error {
+	unlock, err := lockConfigFile(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	settings, err := LoadRigSettings(path)
+	if errors.Is(err, ErrNotFound) {
+		settings, err = NewRigSettings(), nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := update(settings); err != nil {
+		return err
+	}
+	return writeRigSettings(path, settings)
+}
+
+// UpdateRigConfig applies update to the rig config at path as one locked
+// read-modify-write, like UpdateRigSettings. The file must already exist.
+func UpdateRigConfig(path string, update func(*RigConfig) error) error {
+	unlock, err := lockConfigFile(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	cfg, err := LoadRigConfig(path)
+	if err != nil {
+		return err
+	}
+	if err := update(cfg); err != nil {
+		return err
+	}
+	return writeRigConfig(path, cfg)
+}
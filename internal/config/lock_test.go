@@ -0,0 +1,88 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestUpdateRigSettingsConcurrentNoLostWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testrig", "settings", "config.json")
+	themes := []string{"ocean", "forest", "rust", "plum", "slate"}
+
+	const writers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- UpdateRigSettings(path, func(s *RigSettings) error {
+				if s.Theme == nil {
+					s.Theme = &ThemeConfig{}
+				}
+				if s.Theme.RoleThemes == nil {
+					s.Theme.RoleThemes = make(map[string]string)
+				}
+				s.Theme.Name = themes[i%len(themes)]
+				s.Theme.RoleThemes[fmt.Sprintf("writer%d", i)] = themes[i%len(themes)]
+				return nil
+			})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("UpdateRigSettings: %v", err)
+		}
+	}
+
+	settings, err := LoadRigSettings(path)
+	if err != nil {
+		t.Fatalf("LoadRigSettings: %v", err)
+	}
+	if got := len(settings.Theme.RoleThemes); got != writers {
+		t.Errorf("RoleThemes has %d entries, want %d (lost updates)", got, writers)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temp file left behind: %v", err)
+	}
+}
+
+func TestUpdateRigSettingsErrorWritesNothing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings", "config.json")
+	original := NewRigSettings()
+	original.Theme = &ThemeConfig{Name: "forest"}
+	if err := SaveRigSettings(path, original); err != nil {
+		t.Fatalf("SaveRigSettings: %v", err)
+	}
+
+	errStop := errors.New("stop")
+	err := UpdateRigSettings(path, func(s *RigSettings) error {
+		s.Theme.Name = "ocean"
+		return errStop
+	})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("UpdateRigSettings error = %v, want errStop", err)
+	}
+
+	settings, err := LoadRigSettings(path)
+	if err != nil {
+		t.Fatalf("LoadRigSettings: %v", err)
+	}
+	if settings.Theme.Name != "forest" {
+		t.Errorf("Theme.Name = %q, want unchanged forest", settings.Theme.Name)
+	}
+}
+
+func TestUpdateRigConfigMissing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	err := UpdateRigConfig(path, func(*RigConfig) error { return nil })
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("UpdateRigConfig(missing) = %v, want ErrNotFound", err)
+	}
+}
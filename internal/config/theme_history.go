@@ -0,0 +1,75 @@
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ThemeChange records one change to a rig's tmux theme.
+// Entries are appended to settings/theme-history.jsonl, one JSON object per line,
+// so the history never bloats settings/config.json.
+type ThemeChange struct {
+	Timestamp time.Time `json:"timestamp"`
+	Previous  string    `json:"previous,omitempty"`   // theme before the change (empty = default)
+	Theme     string    `json:"theme"`                // theme after the change
+	ChangedBy string    `json:"changed_by,omitempty"` // identity that made the change
+}
+
+// ThemeHistoryPath returns the path to a rig's theme history log.
+func ThemeHistoryPath(rigPath string) string {
+	return filepath.Join(rigPath, "settings", "theme-history.jsonl")
+}
+
+// AppendThemeChange appends a theme change entry to the history log.
+func AppendThemeChange(path string, change ThemeChange) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+
+	data, err := json.Marshal(change)
+	if err != nil {
+		return fmt.Errorf("encoding theme change: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) //nolint:gosec // G302: history is non-sensitive
+	if err != nil {
+		return fmt.Errorf("opening theme history: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("writing theme history: %w", err)
+	}
+	return nil
+}
+
+// LoadThemeHistory reads all theme change entries, oldest first.
+// A missing file yields an empty history; malformed lines are skipped.
+func LoadThemeHistory(path string) ([]ThemeChange, error) {
+	f, err := os.Open(path) //nolint:gosec // G304: path is constructed internally
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("opening theme history: %w", err)
+	}
+	defer f.Close()
+
+	var history []ThemeChange
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var change ThemeChange
+		if err := json.Unmarshal(scanner.Bytes(), &change); err != nil {
+			continue // tolerate partial/corrupt lines
+		}
+		history = append(history, change)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading theme history: %w", err)
+	}
+	return history, nil
+}
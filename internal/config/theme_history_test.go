@@ -0,0 +1,69 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestThemeHistoryRoundTrip(t *testing.T) {
+	rigPath := t.TempDir()
+	path := ThemeHistoryPath(rigPath)
+
+	// Missing file is an empty history
+	history, err := LoadThemeHistory(path)
+	if err != nil {
+		t.Fatalf("LoadThemeHistory (missing): %v", err)
+	}
+	if len(history) != 0 {
+		t.Fatalf("expected empty history, got %d entries", len(history))
+	}
+
+	now := time.Now().Truncate(time.Second)
+	changes := []ThemeChange{
+		{Timestamp: now, Theme: "ocean", ChangedBy: "overseer"},
+		{Timestamp: now.Add(time.Minute), Previous: "ocean", Theme: "forest", ChangedBy: "gastown/crew/joe"},
+	}
+	for _, c := range changes {
+		if err := AppendThemeChange(path, c); err != nil {
+			t.Fatalf("AppendThemeChange: %v", err)
+		}
+	}
+
+	history, err = LoadThemeHistory(path)
+	if err != nil {
+		t.Fatalf("LoadThemeHistory: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(history))
+	}
+	if history[1].Previous != "ocean" || history[1].Theme != "forest" || history[1].ChangedBy != "gastown/crew/joe" {
+		t.Errorf("unexpected second entry: %+v", history[1])
+	}
+	if !history[0].Timestamp.Equal(now) {
+		t.Errorf("timestamp = %v, want %v", history[0].Timestamp, now)
+	}
+}
+
+func TestLoadThemeHistorySkipsMalformedLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "theme-history.jsonl")
+	content := `{"timestamp":"2026-01-01T00:00:00Z","theme":"ocean"}
+not json
+{"timestamp":"2026-01-02T00:00:00Z","previous":"ocean","theme":"rust"}
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := LoadThemeHistory(path)
+	if err != nil {
+		t.Fatalf("LoadThemeHistory: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected 2 valid entries, got %d", len(history))
+	}
+	if history[1].Theme != "rust" {
+		t.Errorf("second entry theme = %q, want rust", history[1].Theme)
+	}
+}
@@ -68,6 +68,11 @@ type TownSettings struct {
 	// Agent addresses like "gastown/crew/jack" become "gastown.crew.jack@{domain}".
 	// Default: "gastown.local"
 	AgentEmailDomain string `json:"agent_email_domain,omitempty"`
+
+	// HookContextMax caps the length in bytes of the context message given
+	// to 'gt hook -m'. Longer messages are truncated (or rejected with
+	// --strict). Default: 4096
+	HookContextMax int `json:"hook_context_max,omitempty"`
 }
 
 // NewTownSettings creates a new TownSettings with defaults.
@@ -167,6 +172,19 @@ type RigEntry struct {
 	LocalRepo   string       `json:"local_repo,omitempty"`
 	AddedAt     time.Time    `json:"added_at"`
 	BeadsConfig *BeadsConfig `json:"beads,omitempty"`
+
+	// Path is the rig directory relative to the town root, for nested
+	// layouts (e.g. "group/rig-a"). Empty means the directory is the rig
+	// name. Sessions are always named after the rig name, never the path.
+	Path string `json:"path,omitempty"`
+}
+
+// Dir returns the rig's directory within townRoot.
+func (e RigEntry) Dir(townRoot, name string) string {
+	if e.Path != "" {
+		return filepath.Join(townRoot, filepath.FromSlash(e.Path))
+	}
+	return filepath.Join(townRoot, name)
 }
 
 // BeadsConfig represents beads configuration for a rig.
@@ -198,6 +216,11 @@ type RigConfig struct {
 	LocalRepo string       `json:"local_repo,omitempty"`
 	CreatedAt time.Time    `json:"created_at"` // when the rig was created
 	Beads     *BeadsConfig `json:"beads,omitempty"`
+
+	// DisplayName is a human-friendly name shown instead of the rig name in
+	// status bars, terminal titles and listings. The rig name stays the
+	// identifier in session names, paths and commands.
+	DisplayName string `json:"display_name,omitempty"`
 }
 
 // WorkflowConfig represents workflow settings for a rig.
@@ -649,15 +672,84 @@ type ThemeConfig struct {
 	// Custom overrides the palette with specific colors.
 	Custom *CustomTheme `json:"custom,omitempty"`
 
+	// ThemeDefs defines named themes usable only within this rig. They are
+	// resolved before town custom and built-in themes, so a rig directory
+	// carries its own themes with it when copied or cloned.
+	ThemeDefs map[string]CustomTheme `json:"theme_defs,omitempty"`
+
 	// RoleThemes overrides themes for specific roles in this rig.
 	// Keys: "witness", "refinery", "crew", "polecat"
 	RoleThemes map[string]string `json:"role_themes,omitempty"`
+
+	// Workers overrides themes for individual crew members or polecats in
+	// this rig, by worker name. A worker's theme wins over RoleThemes.
+	Workers map[string]string `json:"workers,omitempty"`
+
+	// StatusLeft is a custom status-left template replacing the default
+	// identity segment. ${VAR} tokens expand from the session's tmux
+	// environment at apply time (e.g. "${GT_RIG}/${GT_CLUSTER} ").
+	StatusLeft string `json:"status_left,omitempty"`
+
+	// IdleDimMinutes dims worker sessions (crew, polecats) whose tmux
+	// session has had no activity for this many minutes. 0 disables.
+	IdleDimMinutes int `json:"idle_dim_minutes,omitempty"`
+
+	// GitStatus adds the git state of each worker's working tree to this
+	// rig's status bars: clean, or dirty in the theme's reversed colors.
+	// It runs git on every refresh, so it is off unless set here or in
+	// the town's theme.git_status.
+	GitStatus bool `json:"git_status,omitempty"`
+
+	// TerminalTitle names this rig's windows and the outer terminal's title
+	// (tmux set-titles) after each agent on every apply, as
+	// 'gt theme apply --titles' does. Off by default, for users who manage
+	// terminal titles themselves; the town's theme.terminal_title turns it
+	// on everywhere.
+	TerminalTitle bool `json:"terminal_title,omitempty"`
+
+	// StatusPosition places the status line ("top" or "bottom") for this
+	// rig's sessions, overriding the theme's preference. Empty defers to
+	// the theme, which by default leaves tmux's setting unchanged.
+	StatusPosition string `json:"status_position,omitempty"`
+
+	// StatusCenter gives this rig's status bars a centered segment ("rig",
+	// "worker", "role" or "identity"; see tmux.StatusCenterTokens),
+	// overriding the theme's preference. Needs tmux 3.0 or newer.
+	StatusCenter string `json:"status_center,omitempty"`
+
+	// RefreshInterval is how often, in seconds, this rig's status bars
+	// re-run their dynamic status (tmux status-interval). 0 defers to the
+	// town's theme.refresh_interval, then the built-in 5 seconds.
+	RefreshInterval int `json:"refresh_interval,omitempty"`
+
+	// StatusLeftLength and StatusRightLength cap how many cells tmux
+	// draws of each side of the status bar (status-left-length,
+	// status-right-length). 0 keeps gt's defaults: the left side fits the
+	// session's identity, the right side gets 80.
+	StatusLeftLength  int `json:"status_left_length,omitempty"`
+	StatusRightLength int `json:"status_right_length,omitempty"`
+
+	// Layout is the preferred tmux window layout for this rig's sessions,
+	// applied by 'gt session layout': a preset such as "main-vertical" or
+	// "tiled", or a custom #{window_layout} string.
+	Layout string `json:"layout,omitempty"`
+
+	// Locked keeps 'gt theme spread' from reassigning this rig's theme.
+	Locked bool `json:"locked,omitempty"`
 }
 
 // CustomTheme allows specifying exact colors for the status bar.
 type CustomTheme struct {
 	BG string `json:"bg"` // Background color (hex or tmux color name)
 	FG string `json:"fg"` // Foreground color (hex or tmux color name)
+
+	// StatusPosition is the theme's preferred status line position
+	// ("top" or "bottom"); empty leaves it unchanged.
+	StatusPosition string `json:"status_position,omitempty"`
+
+	// StatusCenter is what the theme shows in a centered status segment
+	// ("rig", "worker", "role" or "identity"); empty for none.
+	StatusCenter string `json:"status_center,omitempty"`
 }
 
 // TownThemeConfig represents global theme settings (mayor/config.json).
@@ -665,6 +757,47 @@ type TownThemeConfig struct {
 	// RoleDefaults sets default themes for roles across all rigs.
 	// Keys: "witness", "refinery", "crew", "polecat"
 	RoleDefaults map[string]string `json:"role_defaults,omitempty"`
+
+	// Custom holds named themes defined for this town (e.g. by
+	// 'gt theme adjust'). They can be used anywhere a palette name can.
+	Custom map[string]CustomTheme `json:"custom,omitempty"`
+
+	// Unknown names the theme used when the rig can't be detected.
+	// Defaults to the built-in muted gray "unknown" theme.
+	Unknown string `json:"unknown,omitempty"`
+
+	// TmuxSocket selects the tmux server 'gt theme apply' styles: a socket
+	// path (tmux -S) or socket name (tmux -L). GT_TMUX_SOCKET overrides it.
+	TmuxSocket string `json:"tmux_socket,omitempty"`
+
+	// PostApplyHook is a shell command run once after 'gt theme apply'
+	// themes at least one session (e.g. to notify a dashboard). See
+	// 'gt theme apply --help' for the environment it receives.
+	PostApplyHook string `json:"post_apply_hook,omitempty"`
+
+	// RefreshInterval is the town-wide status bar refresh interval in
+	// seconds (tmux status-interval); rigs can override it. 0 uses the
+	// built-in 5 seconds.
+	RefreshInterval int `json:"refresh_interval,omitempty"`
+
+	// GitStatus turns on the worker git status segment (see
+	// ThemeConfig.GitStatus) for every rig.
+	GitStatus bool `json:"git_status,omitempty"`
+
+	// TerminalTitle turns on agent window names and terminal titles (see
+	// ThemeConfig.TerminalTitle) for every rig.
+	TerminalTitle bool `json:"terminal_title,omitempty"`
+
+	// Seed is mixed into the rig name hash that picks each rig's default
+	// theme. Changing it reshuffles the defaults of every rig without a
+	// configured theme; empty keeps the original assignments.
+	// GT_THEME_SEED overrides it.
+	Seed string `json:"seed,omitempty"`
+
+	// Focus names the rig 'gt theme focus' singled out: its sessions keep
+	// their theme and every other session gets the dimmed variant, until
+	// 'gt theme unfocus'.
+	Focus string `json:"focus,omitempty"`
 }
 
 // BuiltinRoleThemes returns the default themes for each role.
@@ -533,7 +533,7 @@ func (m *Manager) Start(name string, opts StartOptions) error {
 
 	// Apply rig-based theming (non-fatal: theming failure doesn't affect operation)
 	theme := tmux.AssignTheme(m.rig.Name)
-	_ = t.ConfigureGasTownSession(sessionID, theme, m.rig.Name, name, "crew")
+	_ = t.ConfigureGasTownSession(sessionID, theme, m.rig.DisplayName(), name, "crew")
 
 	// Set up C-b n/p keybindings for crew session cycling (non-fatal)
 	_ = t.SetCrewCycleBindings(sessionID)
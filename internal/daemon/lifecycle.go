@@ -550,7 +550,7 @@ func (d *Daemon) applySessionTheme(sessionName string, parsed *ParsedIdentity) {
 		_ = d.tmux.ConfigureGasTownSession(sessionName, theme, "", "Mayor", "coordinator")
 	} else if parsed.RigName != "" {
 		theme := tmux.AssignTheme(parsed.RigName)
-		_ = d.tmux.ConfigureGasTownSession(sessionName, theme, parsed.RigName, parsed.RoleType, parsed.RoleType)
+		_ = d.tmux.ConfigureGasTownSession(sessionName, theme, config.RigDisplayName(d.config.TownRoot, parsed.RigName), parsed.RoleType, parsed.RoleType)
 	}
 }
 
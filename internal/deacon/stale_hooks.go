@@ -8,8 +8,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/wisp"
 )
 
 // StaleHookConfig holds configurable parameters for stale hook detection.
@@ -30,11 +32,12 @@ func DefaultStaleHookConfig() *StaleHookConfig {
 
 // HookedBead represents a bead in hooked status from bd list output.
 type HookedBead struct {
-	ID        string    `json:"id"`
-	Title     string    `json:"title"`
-	Status    string    `json:"status"`
-	Assignee  string    `json:"assignee"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Status      string    `json:"status"`
+	Assignee    string    `json:"assignee"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 // StaleHookResult represents the result of processing a stale hooked bead.
@@ -44,6 +47,7 @@ type StaleHookResult struct {
 	Assignee    string `json:"assignee"`
 	Age         string `json:"age"`
 	AgentAlive  bool   `json:"agent_alive"`
+	Pinned      bool   `json:"pinned"`
 	Unhooked    bool   `json:"unhooked"`
 	Error       string `json:"error,omitempty"`
 }
@@ -57,7 +61,9 @@ type StaleHookScanResult struct {
 	Results     []*StaleHookResult `json:"results"`
 }
 
-// ScanStaleHooks finds hooked beads older than the threshold and optionally unhooks them.
+// ScanStaleHooks finds hooked beads older than the threshold and optionally
+// unhooks them. Pinned hooks (see wisp.SetHookPinned) are reported but never
+// unhooked.
 func ScanStaleHooks(townRoot string, cfg *StaleHookConfig) (*StaleHookScanResult, error) {
 	if cfg == nil {
 		cfg = DefaultStaleHookConfig()
@@ -93,6 +99,11 @@ func ScanStaleHooks(townRoot string, cfg *StaleHookConfig) (*StaleHookScanResult
 			Title:    bead.Title,
 			Assignee: bead.Assignee,
 			Age:      time.Since(bead.UpdatedAt).Round(time.Minute).String(),
+			Pinned:   wisp.HookPinned(&beads.Issue{Description: bead.Description}),
+		}
+		if hookResult.Pinned {
+			result.Results = append(result.Results, hookResult)
+			continue
 		}
 
 		// Check if assignee agent is still alive
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/wisp"
 )
 
 // HookAttachmentValidCheck verifies that attached molecules exist and are not closed.
@@ -187,6 +188,63 @@ func (c *HookAttachmentValidCheck) Fix(ctx *CheckContext) error {
 	return nil
 }
 
+// HookValidCheck verifies that every hooked bead passes wisp.ValidateHook,
+// catching hooks with malformed IDs or assignees that agents write by hand
+// (bd update --status=hooked) or that older versions left behind.
+type HookValidCheck struct {
+	BaseCheck
+}
+
+// NewHookValidCheck creates a new hook validity check.
+func NewHookValidCheck() *HookValidCheck {
+	return &HookValidCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "hook-valid",
+			CheckDescription: "Verify hooked beads have a valid ID, assignee and status",
+			CheckCategory:    CategoryHooks,
+		},
+	}
+}
+
+// Run validates the hooked beads in the town and every rig.
+func (c *HookValidCheck) Run(ctx *CheckContext) *CheckResult {
+	beadsDirs := []string{filepath.Join(ctx.TownRoot, ".beads")}
+	attachCheck := &HookAttachmentValidCheck{}
+	beadsDirs = append(beadsDirs, attachCheck.findRigBeadsDirs(ctx.TownRoot)...)
+
+	var details []string
+	for _, beadsDir := range beadsDirs {
+		hooked, err := beads.New(filepath.Dir(beadsDir)).List(beads.ListOptions{
+			Status:   beads.StatusHooked,
+			Priority: -1,
+		})
+		if err != nil {
+			continue // Can't list hooked beads - skip this directory
+		}
+		for _, issue := range hooked {
+			if err := wisp.ValidateHook(issue); err != nil {
+				details = append(details, err.Error())
+			}
+		}
+	}
+
+	if len(details) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "All hooked beads are valid",
+		}
+	}
+
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusWarning,
+		Message: fmt.Sprintf("Found %d invalid hook(s)", len(details)),
+		Details: details,
+		FixHint: "Re-sling with 'gt sling <id> <agent>', or release with 'gt unsling <id>'",
+	}
+}
+
 // HookSingletonCheck ensures each agent has at most one handoff bead.
 // Detects when multiple pinned beads exist with the same "{role} Handoff" title,
 // which can cause confusion about which handoff is authoritative.
@@ -124,6 +124,32 @@ func TestHookAttachmentValidCheck_FindRigBeadsDirs(t *testing.T) {
 
 // Tests for HookSingletonCheck
 
+func TestNewHookValidCheck(t *testing.T) {
+	check := NewHookValidCheck()
+
+	if check.Name() != "hook-valid" {
+		t.Errorf("expected name 'hook-valid', got %q", check.Name())
+	}
+
+	if check.CanFix() {
+		t.Error("expected CanFix to return false")
+	}
+}
+
+func TestHookValidCheck_NoBeadsDir(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	check := NewHookValidCheck()
+	ctx := &CheckContext{TownRoot: tmpDir}
+
+	result := check.Run(ctx)
+
+	// No beads dir means nothing to check, should be OK
+	if result.Status != StatusOK {
+		t.Errorf("expected StatusOK when no beads dir, got %v", result.Status)
+	}
+}
+
 func TestNewHookSingletonCheck(t *testing.T) {
 	check := NewHookSingletonCheck()
 
@@ -0,0 +1,132 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// RigSources holds the rig as seen by each independent detection source.
+// Empty fields mean the source has no opinion.
+type RigSources struct {
+	Env     string // GT_RIG
+	Session string // rig parsed from the current tmux session name
+	Cwd     string // rig enclosing the working directory
+}
+
+// RigDetectionCheck detects when GT_RIG, the tmux session name and the
+// working directory point at different rigs. Disagreement usually means a
+// misconfigured or manually renamed session, and causes theming and other
+// rig-scoped commands to act on the wrong rig.
+type RigDetectionCheck struct {
+	BaseCheck
+	sources func(ctx *CheckContext) RigSources // nil means detect from the environment
+}
+
+// NewRigDetectionCheck creates a new rig detection check.
+func NewRigDetectionCheck() *RigDetectionCheck {
+	return &RigDetectionCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "rig-detection",
+			CheckDescription: "Check GT_RIG, session name and cwd agree on the current rig",
+			CheckCategory:    CategoryConfig,
+		},
+	}
+}
+
+// NewRigDetectionCheckWithSources creates a check with fixed sources (for testing).
+func NewRigDetectionCheckWithSources(sources RigSources) *RigDetectionCheck {
+	c := NewRigDetectionCheck()
+	c.sources = func(*CheckContext) RigSources { return sources }
+	return c
+}
+
+// Run compares the rig reported by each detection source.
+func (c *RigDetectionCheck) Run(ctx *CheckContext) *CheckResult {
+	detect := c.sources
+	if detect == nil {
+		detect = detectRigSources
+	}
+	src := detect(ctx)
+
+	seen := make(map[string]bool)
+	for _, rig := range []string{src.Env, src.Session, src.Cwd} {
+		if rig != "" {
+			seen[rig] = true
+		}
+	}
+
+	switch len(seen) {
+	case 0:
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "No rig context (not in a rig session or directory)",
+		}
+	case 1:
+		for rig := range seen {
+			return &CheckResult{
+				Name:    c.Name(),
+				Status:  StatusOK,
+				Message: fmt.Sprintf("Rig detection consistent: %s", rig),
+			}
+		}
+	}
+
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusWarning,
+		Message: "GT_RIG, session name and cwd disagree on the current rig",
+		Details: []string{
+			"GT_RIG:       " + orNone(src.Env),
+			"session name: " + orNone(src.Session),
+			"cwd:          " + orNone(src.Cwd),
+		},
+		FixHint: rigDetectionHint(src),
+	}
+}
+
+// rigDetectionHint recommends which source to trust for disagreeing sources.
+// The session name is authoritative: it is what gt theme apply and the
+// status line key off, and GT_RIG is set from it when the session starts.
+func rigDetectionHint(src RigSources) string {
+	switch {
+	case src.Session != "" && src.Env != "" && src.Session != src.Env:
+		return fmt.Sprintf("Trust the session name (%s): GT_RIG=%s is stale or was set by hand; restart the session or fix GT_RIG", src.Session, src.Env)
+	case src.Session != "" && src.Cwd != "" && src.Session != src.Cwd:
+		return fmt.Sprintf("Trust the session name (%s): cwd is inside rig %s, so commands run here may pick the wrong rig; cd back or pass --rig", src.Session, src.Cwd)
+	case src.Env != "" && src.Cwd != "":
+		return fmt.Sprintf("Trust GT_RIG (%s) inside agent sessions; cwd is inside rig %s, so pass --rig when working across rigs", src.Env, src.Cwd)
+	}
+	return "Pass --rig explicitly to rig-scoped commands"
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+// detectRigSources reads each rig detection source from the environment.
+func detectRigSources(ctx *CheckContext) RigSources {
+	src := RigSources{Env: os.Getenv("GT_RIG")}
+
+	if tmux.IsInsideTmux() {
+		if out, err := exec.Command("tmux", "display-message", "-p", "#{session_name}").Output(); err == nil {
+			if id, err := session.ParseSessionName(strings.TrimSpace(string(out))); err == nil {
+				src.Session = id.Rig
+			}
+		}
+	}
+
+	if cwd, err := os.Getwd(); err == nil && ctx.TownRoot != "" {
+		src.Cwd = config.FindRigFromDir(ctx.TownRoot, cwd)
+	}
+	return src
+}
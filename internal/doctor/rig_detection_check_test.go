@@ -0,0 +1,39 @@
+package doctor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRigDetectionCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		sources    RigSources
+		wantStatus CheckStatus
+		wantHint   string
+	}{
+		{"no context", RigSources{}, StatusOK, ""},
+		{"all agree", RigSources{Env: "gastown", Session: "gastown", Cwd: "gastown"}, StatusOK, ""},
+		{"partial agree", RigSources{Env: "gastown", Cwd: "gastown"}, StatusOK, ""},
+		{"stale env", RigSources{Env: "beads", Session: "gastown", Cwd: "gastown"}, StatusWarning, "GT_RIG=beads is stale"},
+		{"cwd elsewhere", RigSources{Env: "gastown", Session: "gastown", Cwd: "beads"}, StatusWarning, "cwd is inside rig beads"},
+		{"no session", RigSources{Env: "gastown", Cwd: "beads"}, StatusWarning, "Trust GT_RIG (gastown)"},
+		{"all differ", RigSources{Env: "a", Session: "b", Cwd: "c"}, StatusWarning, "Trust the session name (b)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := NewRigDetectionCheckWithSources(tt.sources)
+			result := check.Run(&CheckContext{TownRoot: t.TempDir()})
+			if result.Status != tt.wantStatus {
+				t.Fatalf("Status = %v, want %v (%s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if !strings.Contains(result.FixHint, tt.wantHint) {
+				t.Errorf("FixHint = %q, want it to contain %q", result.FixHint, tt.wantHint)
+			}
+			if tt.wantStatus == StatusWarning && len(result.Details) != 3 {
+				t.Errorf("Details = %v, want one line per source", result.Details)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+// RigSettingsValidCheck verifies each rig's settings/config.json parses and
+// passes validation. Rigs without a settings/ directory are reported for
+// information only (rig-settings handles creating it); a corrupt config is an error.
+type RigSettingsValidCheck struct {
+	BaseCheck
+}
+
+// NewRigSettingsValidCheck creates a new rig settings validity check.
+func NewRigSettingsValidCheck() *RigSettingsValidCheck {
+	return &RigSettingsValidCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "rig-settings-valid",
+			CheckDescription: "Check that rig settings/config.json files are well-formed",
+			CheckCategory:    CategoryConfig,
+		},
+	}
+}
+
+// Run loads and validates each rig's settings/config.json.
+func (c *RigSettingsValidCheck) Run(ctx *CheckContext) *CheckResult {
+	rigs := findAllRigs(ctx.TownRoot)
+	if len(rigs) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "No rigs found",
+		}
+	}
+
+	var corrupt, missingDir []string
+	valid := 0
+
+	for _, rig := range rigs {
+		relPath, _ := filepath.Rel(ctx.TownRoot, rig)
+		settingsDir := constants.RigSettingsPath(rig)
+
+		info, err := os.Stat(settingsDir)
+		if err != nil || !info.IsDir() {
+			missingDir = append(missingDir, fmt.Sprintf("No settings/ directory: %s", relPath))
+			continue
+		}
+		if _, err := os.ReadDir(settingsDir); err != nil {
+			corrupt = append(corrupt, fmt.Sprintf("%s: %v", settingsDir, err))
+			continue
+		}
+
+		configPath := filepath.Join(settingsDir, "config.json")
+		if _, err := config.LoadRigSettings(configPath); err != nil {
+			if errors.Is(err, config.ErrNotFound) {
+				valid++ // No config.json is fine - defaults apply
+				continue
+			}
+			corrupt = append(corrupt, fmt.Sprintf("%s: %v", configPath, err))
+			continue
+		}
+		valid++
+	}
+
+	if len(corrupt) > 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: fmt.Sprintf("%d rig(s) have invalid settings/config.json", len(corrupt)),
+			Details: append(corrupt, missingDir...),
+			FixHint: "Fix or remove the listed files (see 'gt rig settings show <rig>')",
+		}
+	}
+
+	msg := fmt.Sprintf("%d rig(s) have valid settings", valid)
+	if len(missingDir) > 0 {
+		msg += fmt.Sprintf(", %d without settings/", len(missingDir))
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusOK,
+		Message: msg,
+		Details: missingDir,
+	}
+}
@@ -0,0 +1,98 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func setupRigSettingsTown(t *testing.T, rigs map[string]string) string {
+	t.Helper()
+	townRoot := t.TempDir()
+	for name, content := range rigs {
+		rigPath := filepath.Join(townRoot, name)
+		if err := os.MkdirAll(filepath.Join(rigPath, "crew"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if content == "-" {
+			continue // no settings/ directory
+		}
+		settingsDir := filepath.Join(rigPath, "settings")
+		if err := os.MkdirAll(settingsDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if content == "" {
+			continue // settings/ without config.json
+		}
+		if err := os.WriteFile(filepath.Join(settingsDir, "config.json"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return townRoot
+}
+
+func TestRigSettingsValidCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		rigs       map[string]string
+		wantStatus CheckStatus
+		wantDetail string
+	}{
+		{
+			name:       "no rigs",
+			rigs:       map[string]string{},
+			wantStatus: StatusOK,
+		},
+		{
+			name:       "valid config",
+			rigs:       map[string]string{"gastown": `{"type":"rig-settings","version":1}`},
+			wantStatus: StatusOK,
+		},
+		{
+			name:       "settings without config.json",
+			rigs:       map[string]string{"gastown": ""},
+			wantStatus: StatusOK,
+		},
+		{
+			name:       "missing settings dir is informational",
+			rigs:       map[string]string{"gastown": "-"},
+			wantStatus: StatusOK,
+			wantDetail: "No settings/ directory: gastown",
+		},
+		{
+			name:       "unparseable config",
+			rigs:       map[string]string{"gastown": `{not json`},
+			wantStatus: StatusError,
+			wantDetail: filepath.Join("gastown", "settings", "config.json") + ": parsing settings",
+		},
+		{
+			name:       "invalid type",
+			rigs:       map[string]string{"gastown": `{"type":"town"}`, "beads": "-"},
+			wantStatus: StatusError,
+			wantDetail: "expected type 'rig-settings'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			townRoot := setupRigSettingsTown(t, tt.rigs)
+			result := NewRigSettingsValidCheck().Run(&CheckContext{TownRoot: townRoot})
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantDetail != "" {
+				found := false
+				for _, d := range result.Details {
+					if strings.Contains(d, tt.wantDetail) {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("Details %v should contain %q", result.Details, tt.wantDetail)
+				}
+			}
+		})
+	}
+}
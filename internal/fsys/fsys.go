@@ -0,0 +1,51 @@
+// Package fsys abstracts the small set of filesystem calls used by the
+// config and wisp packages, so their IO paths can be tested in memory.
+package fsys
+
+import (
+	"io/fs"
+	"os"
+)
+
+// FS is the filesystem used for config and wisp IO.
+type FS interface {
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	MkdirAll(path string, perm fs.FileMode) error
+	Remove(name string) error
+	Rename(oldpath, newpath string) error
+	Stat(name string) (fs.FileInfo, error)
+}
+
+// OS is the real filesystem, backed by package os.
+type OS struct{}
+
+// ReadFile calls os.ReadFile.
+func (OS) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name) //nolint:gosec // G304: callers pass trusted paths
+}
+
+// WriteFile calls os.WriteFile.
+func (OS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+// MkdirAll calls os.MkdirAll.
+func (OS) MkdirAll(path string, perm fs.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+// Remove calls os.Remove.
+func (OS) Remove(name string) error {
+	return os.Remove(name)
+}
+
+// Rename calls os.Rename.
+func (OS) Rename(oldpath, newpath string) error {
+	return os.Rename(oldpath, newpath)
+}
+
+// Stat calls os.Stat.
+func (OS) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
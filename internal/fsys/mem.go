@@ -0,0 +1,195 @@
+package fsys
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	errIsDir    = errors.New("is a directory")
+	errNotDir   = errors.New("not a directory")
+	errNotEmpty = errors.New("directory not empty")
+)
+
+// Mem is an in-memory FS for tests. Paths are cleaned but otherwise used
+// as-is; there is no working directory or symlink handling. It is safe
+// for concurrent use.
+type Mem struct {
+	mu    sync.Mutex
+	files map[string]memFile
+	dirs  map[string]bool
+}
+
+type memFile struct {
+	data    []byte
+	perm    fs.FileMode
+	modTime time.Time
+}
+
+// NewMem returns an empty in-memory filesystem containing only the root
+// directory.
+func NewMem() *Mem {
+	return &Mem{
+		files: make(map[string]memFile),
+		dirs:  map[string]bool{string(filepath.Separator): true, ".": true},
+	}
+}
+
+// ReadFile returns a copy of the named file's contents.
+func (m *Mem) ReadFile(name string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = filepath.Clean(name)
+	f, ok := m.files[name]
+	if !ok {
+		if m.dirs[name] {
+			return nil, &fs.PathError{Op: "read", Path: name, Err: errIsDir}
+		}
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return append([]byte(nil), f.data...), nil
+}
+
+// WriteFile creates or truncates the named file. Like os.WriteFile, the
+// parent directory must already exist.
+func (m *Mem) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = filepath.Clean(name)
+	if m.dirs[name] {
+		return &fs.PathError{Op: "open", Path: name, Err: errIsDir}
+	}
+	if !m.dirs[filepath.Dir(name)] {
+		return &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	if f, ok := m.files[name]; ok {
+		perm = f.perm // like os.WriteFile, keep an existing file's mode
+	}
+	m.files[name] = memFile{data: append([]byte(nil), data...), perm: perm, modTime: time.Now()}
+	return nil
+}
+
+// MkdirAll creates a directory and any missing parents.
+func (m *Mem) MkdirAll(path string, _ fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path = filepath.Clean(path)
+	for p := path; !m.dirs[p]; p = filepath.Dir(p) {
+		if _, ok := m.files[p]; ok {
+			return &fs.PathError{Op: "mkdir", Path: p, Err: errNotDir}
+		}
+		m.dirs[p] = true
+	}
+	return nil
+}
+
+// Remove removes a file or an empty directory.
+func (m *Mem) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = filepath.Clean(name)
+	if _, ok := m.files[name]; ok {
+		delete(m.files, name)
+		return nil
+	}
+	if m.dirs[name] {
+		if len(m.children(name)) > 0 {
+			return &fs.PathError{Op: "remove", Path: name, Err: errNotEmpty}
+		}
+		delete(m.dirs, name)
+		return nil
+	}
+	return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+}
+
+// Rename moves a file, replacing any existing file at newpath.
+// Renaming directories is not supported.
+func (m *Mem) Rename(oldpath, newpath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	oldpath, newpath = filepath.Clean(oldpath), filepath.Clean(newpath)
+	f, ok := m.files[oldpath]
+	if !ok {
+		return &fs.PathError{Op: "rename", Path: oldpath, Err: fs.ErrNotExist}
+	}
+	if m.dirs[newpath] {
+		return &fs.PathError{Op: "rename", Path: newpath, Err: errIsDir}
+	}
+	if !m.dirs[filepath.Dir(newpath)] {
+		return &fs.PathError{Op: "rename", Path: newpath, Err: fs.ErrNotExist}
+	}
+	delete(m.files, oldpath)
+	m.files[newpath] = f
+	return nil
+}
+
+// Stat describes the named file or directory.
+func (m *Mem) Stat(name string) (fs.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = filepath.Clean(name)
+	if f, ok := m.files[name]; ok {
+		return memInfo{name: filepath.Base(name), size: int64(len(f.data)), mode: f.perm, modTime: f.modTime}, nil
+	}
+	if m.dirs[name] {
+		return memInfo{name: filepath.Base(name), mode: fs.ModeDir | 0755}, nil
+	}
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+}
+
+// Files returns the paths of all files, sorted. Useful for asserting that
+// no temp files were left behind.
+func (m *Mem) Files() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := make([]string, 0, len(m.files))
+	for name := range m.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// children returns every file and directory below dir.
+func (m *Mem) children(dir string) []string {
+	prefix := dir + string(filepath.Separator)
+	var out []string
+	for name := range m.files {
+		if strings.HasPrefix(name, prefix) {
+			out = append(out, name)
+		}
+	}
+	for name := range m.dirs {
+		if strings.HasPrefix(name, prefix) {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// memInfo implements fs.FileInfo for Mem entries.
+type memInfo struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (i memInfo) Name() string       { return i.name }
+func (i memInfo) Size() int64        { return i.size }
+func (i memInfo) Mode() fs.FileMode  { return i.mode }
+func (i memInfo) ModTime() time.Time { return i.modTime }
+func (i memInfo) IsDir() bool        { return i.mode.IsDir() }
+func (i memInfo) Sys() any           { return nil }
@@ -0,0 +1,75 @@
+package fsys
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"testing"
+)
+
+func TestMemReadWrite(t *testing.T) {
+	m := NewMem()
+
+	if err := m.WriteFile("/town/rig/config.json", []byte("{}"), 0644); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("WriteFile without parent = %v, want ErrNotExist", err)
+	}
+	if err := m.MkdirAll("/town/rig", 0755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := m.WriteFile("/town/rig/config.json", []byte("{}"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	data, err := m.ReadFile("/town/rig/../rig/config.json")
+	if err != nil || string(data) != "{}" {
+		t.Errorf("ReadFile = %q, %v; want {}", data, err)
+	}
+	if _, err := m.ReadFile("/town/missing.json"); !os.IsNotExist(err) {
+		t.Errorf("ReadFile(missing) = %v, want not-exist", err)
+	}
+
+	info, err := m.Stat("/town/rig")
+	if err != nil || !info.IsDir() {
+		t.Errorf("Stat(dir) = %v, %v; want directory", info, err)
+	}
+	info, err = m.Stat("/town/rig/config.json")
+	if err != nil || info.IsDir() || info.Size() != 2 {
+		t.Errorf("Stat(file) = %v, %v; want 2-byte file", info, err)
+	}
+}
+
+func TestMemRenameRemove(t *testing.T) {
+	m := NewMem()
+	if err := m.MkdirAll("/d", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.WriteFile("/d/f.tmp", []byte("new"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.WriteFile("/d/f", []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Rename("/d/f.tmp", "/d/f"); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	if data, _ := m.ReadFile("/d/f"); string(data) != "new" {
+		t.Errorf("after rename = %q, want new", data)
+	}
+	if got := m.Files(); len(got) != 1 || got[0] != "/d/f" {
+		t.Errorf("Files() = %v, want [/d/f]", got)
+	}
+
+	if err := m.Remove("/d"); err == nil {
+		t.Error("Remove(non-empty dir) succeeded, want error")
+	}
+	if err := m.Remove("/d/f"); err != nil {
+		t.Fatalf("Remove(file): %v", err)
+	}
+	if err := m.Remove("/d"); err != nil {
+		t.Fatalf("Remove(empty dir): %v", err)
+	}
+	if _, err := m.Stat("/d"); !os.IsNotExist(err) {
+		t.Errorf("Stat after remove = %v, want not-exist", err)
+	}
+}
@@ -172,7 +172,7 @@ func (m *Manager) Start(foreground bool, agentOverride string) error {
 
 	// Apply theme (non-fatal: theming failure doesn't affect operation)
 	theme := tmux.AssignTheme(m.rig.Name)
-	_ = t.ConfigureGasTownSession(sessionID, theme, m.rig.Name, "refinery", "refinery")
+	_ = t.ConfigureGasTownSession(sessionID, theme, m.rig.DisplayName(), "refinery", "refinery")
 
 	// Accept bypass permissions warning dialog if it appears.
 	// Must be before WaitForRuntimeReady to avoid race where dialog blocks prompt detection.
@@ -81,6 +81,7 @@ type RigConfig struct {
 	DefaultBranch string       `json:"default_branch,omitempty"` // main, master, etc.
 	CreatedAt     time.Time    `json:"created_at"`               // when rig was created
 	Beads         *BeadsConfig `json:"beads,omitempty"`
+	DisplayName   string       `json:"display_name,omitempty"` // shown instead of the name (see config.RigConfig)
 }
 
 // BeadsConfig represents beads configuration for the rig.
@@ -143,7 +144,7 @@ func (m *Manager) RigExists(name string) bool {
 
 // loadRig loads rig details from the filesystem.
 func (m *Manager) loadRig(name string, entry config.RigEntry) (*Rig, error) {
-	rigPath := filepath.Join(m.townRoot, name)
+	rigPath := entry.Dir(m.townRoot, name)
 
 	// Verify directory exists
 	info, err := os.Stat(rigPath)
@@ -1051,6 +1052,51 @@ func (m *Manager) RegisterRig(opts RegisterRigOptions) (*RegisterRigResult, erro
 	return result, nil
 }
 
+// CreateRig scaffolds an empty rig: the container directory and its
+// config.json, registered in town config. Unlike AddRig nothing is cloned;
+// the rig has no repository until one is added to it.
+func (m *Manager) CreateRig(name string) (*Rig, error) {
+	if m.RigExists(name) {
+		return nil, ErrRigExists
+	}
+
+	if strings.ContainsAny(name, "-. ") {
+		sanitized := strings.NewReplacer("-", "_", ".", "_", " ", "_").Replace(name)
+		sanitized = strings.ToLower(sanitized)
+		return nil, fmt.Errorf("rig name %q contains invalid characters; hyphens, dots, and spaces are reserved for agent ID parsing. Try %q instead (underscores are allowed)", name, sanitized)
+	}
+
+	rigPath := filepath.Join(m.townRoot, name)
+	if _, err := os.Stat(rigPath); err == nil {
+		return nil, fmt.Errorf("directory already exists: %s\n\nTo adopt an existing directory, use:\n  gt rig add %s --adopt", rigPath, name)
+	}
+
+	if err := os.MkdirAll(rigPath, 0755); err != nil {
+		return nil, fmt.Errorf("creating rig directory: %w", err)
+	}
+
+	prefix := deriveBeadsPrefix(name)
+	rigConfig := &RigConfig{
+		Type:      "rig",
+		Version:   CurrentRigConfigVersion,
+		Name:      name,
+		CreatedAt: time.Now(),
+		Beads:     &BeadsConfig{Prefix: prefix},
+	}
+	if err := m.saveRigConfig(rigPath, rigConfig); err != nil {
+		_ = os.RemoveAll(rigPath)
+		return nil, fmt.Errorf("saving rig config: %w", err)
+	}
+
+	entry := config.RigEntry{
+		AddedAt:     rigConfig.CreatedAt,
+		BeadsConfig: &config.BeadsConfig{Prefix: prefix},
+	}
+	m.config.Rigs[name] = entry
+
+	return m.loadRig(name, entry)
+}
+
 // detectGitURL attempts to detect the git remote URL from an existing repository.
 func (m *Manager) detectGitURL(rigPath string) (string, error) {
 	possiblePaths := []string{
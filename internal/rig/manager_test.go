@@ -810,3 +810,41 @@ func TestDetectBeadsPrefixFromConfig_TrailingDash(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateRig(t *testing.T) {
+	root, rigsConfig := setupTestTown(t)
+	manager := NewManager(root, rigsConfig, git.NewGit(root))
+
+	r, err := manager.CreateRig("sandbox")
+	if err != nil {
+		t.Fatalf("CreateRig: %v", err)
+	}
+	if r.Path != filepath.Join(root, "sandbox") {
+		t.Errorf("Path = %q, want %q", r.Path, filepath.Join(root, "sandbox"))
+	}
+	if !manager.RigExists("sandbox") {
+		t.Error("rig should be registered after CreateRig")
+	}
+
+	cfg, err := LoadRigConfig(r.Path)
+	if err != nil {
+		t.Fatalf("LoadRigConfig: %v", err)
+	}
+	if cfg.Type != "rig" || cfg.Version != CurrentRigConfigVersion || cfg.Name != "sandbox" {
+		t.Errorf("config = %+v, want type rig, version %d, name sandbox", cfg, CurrentRigConfigVersion)
+	}
+
+	if _, err := manager.CreateRig("sandbox"); err != ErrRigExists {
+		t.Errorf("CreateRig(existing) = %v, want ErrRigExists", err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(root, "stray"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.CreateRig("stray"); err == nil || !strings.Contains(err.Error(), "directory already exists") {
+		t.Errorf("CreateRig(stray) = %v, want directory already exists", err)
+	}
+	if manager.RigExists("stray") {
+		t.Error("stray should not be registered when the directory already exists")
+	}
+}
@@ -83,6 +83,16 @@ func (r *Rig) BeadsPath() string {
 	return r.Path
 }
 
+// DisplayName returns the name to show for this rig: the configured
+// display_name, or Name if none is set or config cannot be loaded.
+func (r *Rig) DisplayName() string {
+	cfg, err := LoadRigConfig(r.Path)
+	if err != nil || cfg.DisplayName == "" {
+		return r.Name
+	}
+	return cfg.DisplayName
+}
+
 // DefaultBranch returns the configured default branch for this rig.
 // Falls back to "main" if not configured or if config cannot be loaded.
 func (r *Rig) DefaultBranch() string {
@@ -1,6 +1,8 @@
 package rig
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -144,3 +146,20 @@ func TestDefaultBranch_FallsBackToMain(t *testing.T) {
 		t.Errorf("DefaultBranch() = %q, want %q", got, "main")
 	}
 }
+
+func TestDisplayName(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	r := Rig{Name: "acme-web-2", Path: dir}
+	if got := r.DisplayName(); got != "acme-web-2" {
+		t.Errorf("DisplayName() without config = %q, want the name", got)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"type":"rig","name":"acme-web-2","display_name":"Acme Web"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := r.DisplayName(); got != "Acme Web" {
+		t.Errorf("DisplayName() = %q, want Acme Web", got)
+	}
+}
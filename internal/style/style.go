@@ -37,6 +37,11 @@ var (
 	Bold = lipgloss.NewStyle().
 		Bold(true)
 
+	// Header style for titles and section headings (bold blue)
+	Header = lipgloss.NewStyle().
+		Foreground(ui.ColorAccent).
+		Bold(true)
+
 	// SuccessPrefix is the checkmark prefix for success messages
 	SuccessPrefix = Success.Render(ui.IconPass)
 
@@ -20,6 +20,7 @@ func TestStyleVariables(t *testing.T) {
 		{"Info", Info.Render},
 		{"Dim", Dim.Render},
 		{"Bold", Bold.Render},
+		{"Header", Header.Render},
 	}
 
 	for _, tt := range tests {
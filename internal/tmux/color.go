@@ -0,0 +1,243 @@
+package tmux
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// HSL is a color in HSL space: hue in degrees [0, 360), saturation and
+// lightness as percentages [0, 100].
+type HSL struct {
+	H, S, L float64
+}
+
+// ParseHexColor converts a "#rrggbb" color to HSL.
+func ParseHexColor(hex string) (HSL, error) {
+	var r, g, b int
+	if len(hex) != 7 {
+		return HSL{}, fmt.Errorf("invalid color %q: want #rrggbb", hex)
+	}
+	if _, err := fmt.Sscanf(hex, "#%02x%02x%02x", &r, &g, &b); err != nil {
+		return HSL{}, fmt.Errorf("invalid color %q: want #rrggbb", hex)
+	}
+
+	rf, gf, bf := float64(r)/255, float64(g)/255, float64(b)/255
+	max := math.Max(rf, math.Max(gf, bf))
+	min := math.Min(rf, math.Min(gf, bf))
+	l := (max + min) / 2
+	if max == min {
+		return HSL{H: 0, S: 0, L: l * 100}, nil
+	}
+
+	d := max - min
+	s := d / (1 - math.Abs(2*l-1))
+	var h float64
+	switch max {
+	case rf:
+		h = math.Mod((gf-bf)/d, 6)
+	case gf:
+		h = (bf-rf)/d + 2
+	default:
+		h = (rf-gf)/d + 4
+	}
+	h *= 60
+	if h < 0 {
+		h += 360
+	}
+	return HSL{H: h, S: s * 100, L: l * 100}, nil
+}
+
+// Hex converts the color back to "#rrggbb", clamping out-of-range values.
+func (c HSL) Hex() string {
+	h := math.Mod(c.H, 360)
+	if h < 0 {
+		h += 360
+	}
+	s := clampPercent(c.S) / 100
+	l := clampPercent(c.L) / 100
+
+	chroma := (1 - math.Abs(2*l-1)) * s
+	x := chroma * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - chroma/2
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = chroma, x, 0
+	case h < 120:
+		r, g, b = x, chroma, 0
+	case h < 180:
+		r, g, b = 0, chroma, x
+	case h < 240:
+		r, g, b = 0, x, chroma
+	case h < 300:
+		r, g, b = x, 0, chroma
+	default:
+		r, g, b = chroma, 0, x
+	}
+	return fmt.Sprintf("#%02x%02x%02x", toByte(r+m), toByte(g+m), toByte(b+m))
+}
+
+// Adjust returns the color with lightness and saturation shifted by the
+// given percentage points, clamped to [0, 100].
+func (c HSL) Adjust(lighten, saturate float64) HSL {
+	return HSL{H: c.H, S: clampPercent(c.S + saturate), L: clampPercent(c.L + lighten)}
+}
+
+// minAdjustContrast is the smallest lightness gap (percentage points)
+// between background and foreground that Theme.Adjust will accept.
+const minAdjustContrast = 15
+
+// Adjust derives a new theme by shifting both colors' lightness and
+// saturation in HSL space. It fails if either color isn't "#rrggbb" or if
+// the result is degenerate: background and foreground so close in
+// lightness (e.g. both pushed to white) that the status bar is unreadable.
+func (t Theme) Adjust(name string, lighten, saturate float64) (Theme, error) {
+	bg, err := ParseHexColor(t.BG)
+	if err != nil {
+		return Theme{}, fmt.Errorf("background: %w", err)
+	}
+	fg, err := ParseHexColor(t.FG)
+	if err != nil {
+		return Theme{}, fmt.Errorf("foreground: %w", err)
+	}
+
+	bg, fg = bg.Adjust(lighten, saturate), fg.Adjust(lighten, saturate)
+	if math.Abs(bg.L-fg.L) < minAdjustContrast {
+		return Theme{}, fmt.Errorf("adjusted theme is unreadable: background and foreground lightness differ by %.0f%% (need %d%%)",
+			math.Abs(bg.L-fg.L), minAdjustContrast)
+	}
+	return t.WithOverrides(Theme{Name: name, BG: bg.Hex(), FG: fg.Hex()}), nil
+}
+
+// namedColors are the color names tmux accepts (case-insensitively) besides
+// colourN and #rrggbb: the eight ANSI colors, their aixterm bright variants,
+// and default/terminal.
+var namedColors = map[string]bool{
+	"default": true, "terminal": true,
+	"black": true, "red": true, "green": true, "yellow": true,
+	"blue": true, "magenta": true, "cyan": true, "white": true,
+	"brightblack": true, "brightred": true, "brightgreen": true, "brightyellow": true,
+	"brightblue": true, "brightmagenta": true, "brightcyan": true, "brightwhite": true,
+}
+
+// ValidateColor reports whether spec is a color tmux will accept in a style:
+// colour0-colour255 (or color0-color255), #rrggbb, or a named ANSI color.
+// X11 names (e.g. "darkorange") are rejected; use the hex value instead.
+func ValidateColor(spec string) error {
+	lower := strings.ToLower(spec)
+	switch {
+	case spec == "":
+		return fmt.Errorf("empty color")
+	case strings.HasPrefix(spec, "#"):
+		if len(spec) != 7 {
+			return fmt.Errorf("invalid color %q: want #rrggbb", spec)
+		}
+		if _, err := strconv.ParseUint(spec[1:], 16, 32); err != nil {
+			return fmt.Errorf("invalid color %q: want #rrggbb", spec)
+		}
+		return nil
+	case strings.HasPrefix(lower, "colour"), strings.HasPrefix(lower, "color"):
+		index := strings.TrimPrefix(lower, "color")
+		if strings.HasPrefix(lower, "colour") {
+			index = strings.TrimPrefix(lower, "colour")
+		}
+		n, err := strconv.Atoi(index)
+		if err != nil || index != strconv.Itoa(n) {
+			return fmt.Errorf("invalid color %q: want colour0-colour255", spec)
+		}
+		if n < 0 || n > 255 {
+			return fmt.Errorf("invalid color %q: index out of range 0-255", spec)
+		}
+		return nil
+	case namedColors[lower]:
+		return nil
+	}
+	return fmt.Errorf("invalid color %q: want colour0-colour255, #rrggbb or a named color (red, brightblue, default, ...)", spec)
+}
+
+// Validate checks both of the theme's colors with ValidateColor, naming
+// the offending field.
+func (t Theme) Validate() error {
+	if err := ValidateColor(t.BG); err != nil {
+		return fmt.Errorf("theme %s: bg: %w", t.Name, err)
+	}
+	if err := ValidateColor(t.FG); err != nil {
+		return fmt.Errorf("theme %s: fg: %w", t.Name, err)
+	}
+	if err := ValidateStatusPosition(t.StatusPosition); err != nil {
+		return fmt.Errorf("theme %s: status_position: %w", t.Name, err)
+	}
+	if err := ValidateStatusCenter(t.StatusCenter); err != nil {
+		return fmt.Errorf("theme %s: status_center: %w", t.Name, err)
+	}
+	return nil
+}
+
+// ValidateContrast checks that a theme's status bar is readable: when both
+// colors are "#rrggbb", their lightness must differ by at least as much as
+// Theme.Adjust requires. Other colors can't be measured and pass.
+func (t Theme) ValidateContrast() error {
+	bg, err := ParseHexColor(t.BG)
+	if err != nil {
+		return nil
+	}
+	fg, err := ParseHexColor(t.FG)
+	if err != nil {
+		return nil
+	}
+	if gap := math.Abs(bg.L - fg.L); gap < minAdjustContrast {
+		return fmt.Errorf("low contrast: background and foreground lightness differ by %.0f%% (need %d%%)",
+			gap, minAdjustContrast)
+	}
+	return nil
+}
+
+// ValidateStatusPosition checks a status line position: "top", "bottom",
+// or empty for unchanged.
+func ValidateStatusPosition(pos string) error {
+	switch pos {
+	case "", "top", "bottom":
+		return nil
+	}
+	return fmt.Errorf("invalid position %q (want top or bottom)", pos)
+}
+
+// Tone classifies the theme by its background: "dark" or "light", or ""
+// when the background isn't a #rrggbb color.
+func (t Theme) Tone() string {
+	bg, err := ParseHexColor(t.BG)
+	if err != nil {
+		return ""
+	}
+	if bg.L < 50 {
+		return "dark"
+	}
+	return "light"
+}
+
+// Temperature classifies the theme by its background hue: "warm" (reds,
+// oranges, yellows, magentas), "cool" (greens, cyans, blues, violets) or
+// "neutral" for near-grays. Returns "" when the background isn't #rrggbb.
+func (t Theme) Temperature() string {
+	bg, err := ParseHexColor(t.BG)
+	switch {
+	case err != nil:
+		return ""
+	case bg.S < 15:
+		return "neutral"
+	case bg.H < 75 || bg.H >= 320:
+		return "warm"
+	}
+	return "cool"
+}
+
+func clampPercent(v float64) float64 {
+	return math.Max(0, math.Min(100, v))
+}
+
+func toByte(v float64) int {
+	return int(math.Round(math.Max(0, math.Min(1, v)) * 255))
+}
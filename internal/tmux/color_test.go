@@ -0,0 +1,169 @@
+package tmux
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestParseHexColorRoundTrip(t *testing.T) {
+	for _, theme := range DefaultPalette {
+		for _, hex := range []string{theme.BG, theme.FG} {
+			c, err := ParseHexColor(hex)
+			if err != nil {
+				t.Fatalf("ParseHexColor(%q): %v", hex, err)
+			}
+			if got := c.Hex(); got != hex {
+				t.Errorf("round trip %q -> %+v -> %q", hex, c, got)
+			}
+		}
+	}
+}
+
+func TestParseHexColorKnownValues(t *testing.T) {
+	tests := []struct {
+		hex  string
+		want HSL
+	}{
+		{"#ff0000", HSL{0, 100, 50}},
+		{"#00ff00", HSL{120, 100, 50}},
+		{"#0000ff", HSL{240, 100, 50}},
+		{"#ffffff", HSL{0, 0, 100}},
+		{"#000000", HSL{0, 0, 0}},
+	}
+	for _, tt := range tests {
+		got, err := ParseHexColor(tt.hex)
+		if err != nil {
+			t.Fatalf("ParseHexColor(%q): %v", tt.hex, err)
+		}
+		if math.Abs(got.H-tt.want.H) > 0.5 || math.Abs(got.S-tt.want.S) > 0.5 || math.Abs(got.L-tt.want.L) > 0.5 {
+			t.Errorf("ParseHexColor(%q) = %+v, want %+v", tt.hex, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "red", "#fff", "#gggggg"} {
+		if _, err := ParseHexColor(bad); err == nil {
+			t.Errorf("ParseHexColor(%q) should fail", bad)
+		}
+	}
+}
+
+func TestHSLAdjustClamps(t *testing.T) {
+	c := HSL{H: 200, S: 90, L: 95}.Adjust(20, 30)
+	if c.L != 100 || c.S != 100 {
+		t.Errorf("Adjust past range = %+v, want S=100 L=100", c)
+	}
+	c = HSL{H: 200, S: 10, L: 5}.Adjust(-20, -30)
+	if c.L != 0 || c.S != 0 {
+		t.Errorf("Adjust below range = %+v, want S=0 L=0", c)
+	}
+}
+
+func TestThemeAdjust(t *testing.T) {
+	base := *GetThemeByName("ocean")
+
+	lighter, err := base.Adjust("ocean-light", 10, 0)
+	if err != nil {
+		t.Fatalf("Adjust lighten: %v", err)
+	}
+	if lighter.Name != "ocean-light" || lighter.BG == base.BG {
+		t.Errorf("lighter = %+v, want new BG", lighter)
+	}
+	before, _ := ParseHexColor(base.BG)
+	after, _ := ParseHexColor(lighter.BG)
+	if after.L <= before.L {
+		t.Errorf("lightened BG L = %.1f, want > %.1f", after.L, before.L)
+	}
+
+	// Pushing everything to white is rejected
+	if _, err := base.Adjust("white", 100, 0); err == nil || !strings.Contains(err.Error(), "unreadable") {
+		t.Errorf("Adjust to white: err = %v, want unreadable error", err)
+	}
+
+	// Named tmux colors can't be transformed
+	if _, err := (Theme{BG: "black", FG: "white"}).Adjust("x", 10, 0); err == nil {
+		t.Error("Adjust with named colors should fail")
+	}
+}
+
+func TestValidateColor(t *testing.T) {
+	valid := []string{"colour0", "colour255", "color17", "Colour42", "#1e3a5f", "#FFD700",
+		"red", "BrightBlue", "default", "terminal"}
+	for _, spec := range valid {
+		if err := ValidateColor(spec); err != nil {
+			t.Errorf("ValidateColor(%q) = %v, want nil", spec, err)
+		}
+	}
+
+	invalid := []string{"", "#12345", "#1234567", "#gg0000", "colour256", "colour-1", "colour",
+		"colour007", "colourcolor1", "darkorange", "navy blue"}
+	for _, spec := range invalid {
+		if err := ValidateColor(spec); err == nil {
+			t.Errorf("ValidateColor(%q) = nil, want error", spec)
+		}
+	}
+
+	for _, theme := range append(Palette(), MayorTheme(), DeaconTheme(), UnknownTheme(), DogTheme()) {
+		if err := theme.Validate(); err != nil {
+			t.Errorf("built-in theme %s invalid: %v", theme.Name, err)
+		}
+	}
+
+	err := Theme{Name: "bad", BG: "#000000", FG: "neon"}.Validate()
+	if err == nil || !strings.Contains(err.Error(), "fg") || !strings.Contains(err.Error(), `"neon"`) {
+		t.Errorf("Validate() = %v, want fg field and value named", err)
+	}
+}
+
+func TestThemeToneAndTemperature(t *testing.T) {
+	tests := []struct {
+		theme             Theme
+		tone, temperature string
+	}{
+		{Theme{BG: "#8b4513"}, "dark", "warm"},
+		{Theme{BG: "#1e3a5f"}, "dark", "cool"},
+		{Theme{BG: "#3a3a3a"}, "dark", "neutral"},
+		{Theme{BG: "#f5deb3"}, "light", "warm"},
+		{Theme{BG: "#add8e6"}, "light", "cool"},
+		{Theme{BG: "colour234"}, "", ""},
+	}
+	for _, tt := range tests {
+		if got := tt.theme.Tone(); got != tt.tone {
+			t.Errorf("Tone(%s) = %q, want %q", tt.theme.BG, got, tt.tone)
+		}
+		if got := tt.theme.Temperature(); got != tt.temperature {
+			t.Errorf("Temperature(%s) = %q, want %q", tt.theme.BG, got, tt.temperature)
+		}
+	}
+}
+
+func TestValidateStatusPosition(t *testing.T) {
+	for _, pos := range []string{"", "top", "bottom"} {
+		if err := ValidateStatusPosition(pos); err != nil {
+			t.Errorf("ValidateStatusPosition(%q) = %v, want nil", pos, err)
+		}
+	}
+	for _, pos := range []string{"Top", "left", "middle"} {
+		if err := ValidateStatusPosition(pos); err == nil {
+			t.Errorf("ValidateStatusPosition(%q) = nil, want error", pos)
+		}
+	}
+	theme := Theme{Name: "t", BG: "#1e3a5f", FG: "#e0e0e0", StatusPosition: "side"}
+	if err := theme.Validate(); err == nil || !strings.Contains(err.Error(), "status_position") {
+		t.Errorf("Validate() = %v, want status_position error", err)
+	}
+}
+
+func TestValidateContrast(t *testing.T) {
+	for _, theme := range DefaultPalette {
+		if err := theme.ValidateContrast(); err != nil {
+			t.Errorf("built-in %s: %v", theme.Name, err)
+		}
+	}
+	if err := (Theme{BG: "#202020", FG: "#303030"}).ValidateContrast(); err == nil {
+		t.Error("ValidateContrast accepted near-identical colors")
+	}
+	if err := (Theme{BG: "black", FG: "colour234"}).ValidateContrast(); err != nil {
+		t.Errorf("ValidateContrast(non-hex) = %v, want nil", err)
+	}
+}
@@ -0,0 +1,228 @@
+package tmux
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ColorCaps is how many colors the terminal showing tmux can display.
+// Theme.ForCaps maps theme colors down to what the terminal supports.
+type ColorCaps int
+
+const (
+	// ColorsTrue is 24-bit color: theme colors are used as-is.
+	ColorsTrue ColorCaps = iota
+	// Colors256 is the xterm 256-color palette (colour0-colour255).
+	Colors256
+	// Colors8 is the eight basic ANSI colors of legacy and serial terminals.
+	Colors8
+)
+
+// ColorCapsEnv overrides DetectColorCaps: "truecolor", "256" or "8".
+const ColorCapsEnv = "GT_TMUX_COLORS"
+
+// String returns the name ParseColorCaps accepts.
+func (c ColorCaps) String() string {
+	switch c {
+	case Colors256:
+		return "256"
+	case Colors8:
+		return "8"
+	default:
+		return "truecolor"
+	}
+}
+
+// ParseColorCaps parses "truecolor" (or "24bit"), "256" or "8".
+func ParseColorCaps(s string) (ColorCaps, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "truecolor", "24bit":
+		return ColorsTrue, nil
+	case "256":
+		return Colors256, nil
+	case "8":
+		return Colors8, nil
+	}
+	return ColorsTrue, fmt.Errorf("invalid color capability %q (want truecolor, 256 or 8)", s)
+}
+
+// legacyTerms are TERM values for terminals limited to the 8 ANSI colors.
+var legacyTerms = map[string]bool{
+	"ansi": true, "cons25": true, "dumb": true, "linux": true,
+	"vt100": true, "vt102": true, "vt220": true, "xterm-color": true,
+	"xterm-16color": true,
+}
+
+// DetectColorCaps guesses the terminal's color support from GT_TMUX_COLORS
+// and TERM. Only legacy terminals are detected (as Colors8): tmux already
+// downsamples #rrggbb for 256-color clients, and TERM inside tmux says
+// little about the outer terminal, so anything else is left as-is.
+// Colors256 is available through GT_TMUX_COLORS.
+func DetectColorCaps() ColorCaps {
+	if v := os.Getenv(ColorCapsEnv); v != "" {
+		if caps, err := ParseColorCaps(v); err == nil {
+			return caps
+		}
+	}
+	if legacyTerms[strings.ToLower(os.Getenv("TERM"))] {
+		return Colors8
+	}
+	return ColorsTrue
+}
+
+// ForCaps returns the theme with its colors mapped to what caps can show:
+// #rrggbb becomes the nearest colourN for Colors256, and every color
+// becomes the nearest of the eight ANSI colors for Colors8. With Colors8 a
+// foreground that lands on the background's color is swapped for black or
+// white so the status bar stays readable.
+func (t Theme) ForCaps(caps ColorCaps) Theme {
+	switch caps {
+	case Colors256:
+		t.BG, t.FG = to256(t.BG), to256(t.FG)
+	case Colors8:
+		t.BG, t.FG = to8(t.BG), to8(t.FG)
+		if strings.EqualFold(t.BG, t.FG) {
+			t.FG = "white"
+			if !darkANSI[t.BG] {
+				t.FG = "black"
+			}
+		}
+	}
+	return t
+}
+
+// ansi8 are the eight basic ANSI colors, in colour0-colour7 order.
+var ansi8 = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// darkANSI are the ANSI colors that need a white foreground.
+var darkANSI = map[string]bool{"black": true, "red": true, "green": true, "blue": true, "magenta": true}
+
+// systemRGB are the xterm defaults for colour0-colour15; actual system
+// colors vary by terminal.
+var systemRGB = [16][3]int{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels are the channel values of the xterm 6x6x6 color cube.
+var cubeLevels = []int{0, 95, 135, 175, 215, 255}
+
+// rgbOf returns the RGB value of a hex color or colourN, or ok=false for
+// names and invalid specs.
+func rgbOf(spec string) (r, g, b int, ok bool) {
+	lower := strings.ToLower(spec)
+	if strings.HasPrefix(lower, "#") {
+		if _, err := fmt.Sscanf(lower, "#%02x%02x%02x", &r, &g, &b); err != nil || len(lower) != 7 {
+			return 0, 0, 0, false
+		}
+		return r, g, b, true
+	}
+	index := strings.TrimPrefix(strings.TrimPrefix(lower, "colour"), "color")
+	n, err := strconv.Atoi(index)
+	if err != nil || index == lower || n < 0 || n > 255 {
+		return 0, 0, 0, false
+	}
+	switch {
+	case n < 16:
+		c := systemRGB[n]
+		return c[0], c[1], c[2], true
+	case n < 232:
+		n -= 16
+		return cubeLevels[n/36], cubeLevels[n/6%6], cubeLevels[n%6], true
+	default:
+		v := 8 + (n-232)*10
+		return v, v, v, true
+	}
+}
+
+// to256 maps a #rrggbb color to the nearest colourN; other specs are
+// already displayable and returned unchanged.
+func to256(spec string) string {
+	if !strings.HasPrefix(spec, "#") {
+		return spec
+	}
+	r, g, b, ok := rgbOf(spec)
+	if !ok {
+		return spec
+	}
+
+	nearestLevel := func(v int) int {
+		best := 0
+		for i, level := range cubeLevels {
+			if abs(v-level) < abs(v-cubeLevels[best]) {
+				best = i
+			}
+		}
+		return best
+	}
+	ri, gi, bi := nearestLevel(r), nearestLevel(g), nearestLevel(b)
+	cube := 16 + 36*ri + 6*gi + bi
+	cubeDist := sqDist(r, g, b, cubeLevels[ri], cubeLevels[gi], cubeLevels[bi])
+
+	avg := (r + g + b) / 3
+	grayIdx := min(max((avg-8+5)/10, 0), 23)
+	gray := 8 + grayIdx*10
+	if sqDist(r, g, b, gray, gray, gray) < cubeDist {
+		return fmt.Sprintf("colour%d", 232+grayIdx)
+	}
+	return fmt.Sprintf("colour%d", cube)
+}
+
+// to8 maps a color to the nearest of the eight ANSI colors. Nearness is
+// judged by hue, with dark, light and unsaturated colors going to black or
+// white: plain RGB distance would send every dark theme color to black and
+// make all rigs look the same.
+func to8(spec string) string {
+	lower := strings.ToLower(spec)
+	switch {
+	case lower == "default" || lower == "terminal":
+		return spec
+	case strings.HasPrefix(lower, "bright") && namedColors[lower]:
+		return strings.TrimPrefix(lower, "bright")
+	case namedColors[lower]:
+		return lower
+	}
+	if index := strings.TrimPrefix(strings.TrimPrefix(lower, "colour"), "color"); index != lower {
+		if n, err := strconv.Atoi(index); err == nil && n >= 0 && n < 16 {
+			return ansi8[n%8]
+		}
+	}
+
+	r, g, b, ok := rgbOf(spec)
+	if !ok {
+		return spec
+	}
+	hsl, _ := ParseHexColor(fmt.Sprintf("#%02x%02x%02x", r, g, b))
+	switch {
+	case hsl.L < 12:
+		return "black"
+	case hsl.L > 88:
+		return "white"
+	case hsl.S < 20:
+		if hsl.L < 50 {
+			return "black"
+		}
+		return "white"
+	}
+	// Hue sectors centered on red (0), yellow (60), green (120), cyan (180),
+	// blue (240) and magenta (300).
+	sectors := []string{"red", "yellow", "green", "cyan", "blue", "magenta"}
+	return sectors[int(math.Mod(hsl.H+30, 360)/60)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func sqDist(r1, g1, b1, r2, g2, b2 int) int {
+	dr, dg, db := r1-r2, g1-g2, b1-b2
+	return dr*dr + dg*dg + db*db
+}
@@ -0,0 +1,114 @@
+package tmux
+
+import "testing"
+
+func TestParseColorCaps(t *testing.T) {
+	for _, c := range []ColorCaps{ColorsTrue, Colors256, Colors8} {
+		got, err := ParseColorCaps(c.String())
+		if err != nil || got != c {
+			t.Errorf("ParseColorCaps(%q) = %v, %v; want %v", c.String(), got, err, c)
+		}
+	}
+	if _, err := ParseColorCaps("16"); err == nil {
+		t.Error("ParseColorCaps(16) = nil error, want failure")
+	}
+}
+
+func TestDetectColorCaps(t *testing.T) {
+	tests := []struct {
+		env, term string
+		want      ColorCaps
+	}{
+		{"", "linux", Colors8},
+		{"", "vt100", Colors8},
+		{"", "xterm-256color", ColorsTrue},
+		{"", "tmux-256color", ColorsTrue},
+		{"", "", ColorsTrue},
+		{"256", "linux", Colors256},
+		{"truecolor", "vt100", ColorsTrue},
+		{"bogus", "linux", Colors8},
+	}
+	for _, tt := range tests {
+		t.Setenv(ColorCapsEnv, tt.env)
+		t.Setenv("TERM", tt.term)
+		if got := DetectColorCaps(); got != tt.want {
+			t.Errorf("DetectColorCaps(env=%q, TERM=%q) = %v, want %v", tt.env, tt.term, got, tt.want)
+		}
+	}
+}
+
+func TestTo8(t *testing.T) {
+	tests := map[string]string{
+		"#1e3a5f":    "blue",  // ocean
+		"#2d5a3d":    "green", // forest
+		"#e0e0e0":    "white", // light grey fg
+		"#101010":    "black", // near black
+		"#8b3a3a":    "red",   // rust-ish
+		"#6b3a7a":    "magenta",
+		"#c0a030":    "yellow",
+		"#2a7a7a":    "cyan",
+		"#555555":    "black", // unsaturated dark
+		"colour4":    "blue",
+		"colour12":   "blue",
+		"colour196":  "red",
+		"colour250":  "white",
+		"brightcyan": "cyan",
+		"Red":        "red",
+		"default":    "default",
+	}
+	for spec, want := range tests {
+		if got := to8(spec); got != want {
+			t.Errorf("to8(%q) = %q, want %q", spec, got, want)
+		}
+	}
+}
+
+func TestTo256(t *testing.T) {
+	tests := map[string]string{
+		"#000000":  "colour16",
+		"#ffffff":  "colour231",
+		"#ff0000":  "colour196",
+		"#808080":  "colour244",
+		"#1e3a5f":  "colour237",
+		"colour42": "colour42",
+		"red":      "red",
+	}
+	for spec, want := range tests {
+		if got := to256(spec); got != want {
+			t.Errorf("to256(%q) = %q, want %q", spec, got, want)
+		}
+	}
+}
+
+func TestThemeForCaps(t *testing.T) {
+	theme := Theme{Name: "ocean", BG: "#1e3a5f", FG: "#e0e0e0", StatusPosition: "top"}
+
+	if got := theme.ForCaps(ColorsTrue); got != theme {
+		t.Errorf("ForCaps(truecolor) = %+v, want unchanged", got)
+	}
+	got := theme.ForCaps(Colors8)
+	if got.BG != "blue" || got.FG != "white" || got.Name != "ocean" || got.StatusPosition != "top" {
+		t.Errorf("ForCaps(8) = %+v, want blue/white ocean", got)
+	}
+	if err := got.Validate(); err != nil {
+		t.Errorf("ForCaps(8) result invalid: %v", err)
+	}
+
+	// Colors that collapse onto each other keep a readable foreground
+	same := Theme{Name: "x", BG: "#1e3a5f", FG: "#2a4a8f"}.ForCaps(Colors8)
+	if same.BG != "blue" || same.FG != "white" {
+		t.Errorf("collapsed ForCaps(8) = %+v, want blue/white", same)
+	}
+	light := Theme{Name: "y", BG: "#f0f0f0", FG: "#fafafa"}.ForCaps(Colors8)
+	if light.BG != "white" || light.FG != "black" {
+		t.Errorf("collapsed light ForCaps(8) = %+v, want white/black", light)
+	}
+
+	// Every built-in theme stays readable on 8 colors
+	for _, th := range DefaultPalette {
+		m := th.ForCaps(Colors8)
+		if m.BG == m.FG {
+			t.Errorf("%s on 8 colors: bg and fg both %s", th.Name, m.BG)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package tmux
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StatusCenterTokens are the values of Theme.StatusCenter, naming what the
+// centered status segment shows: the session's rig (its display name),
+// worker, role, or full identity as the pane title shows it ("😺
+// gastown/Toast").
+var StatusCenterTokens = []string{"identity", "rig", "role", "worker"}
+
+// statusCenterOption is the session option SetStatusFormat writes for a
+// centered segment. It is an array with a format per status line; gt sets
+// only the first (statusOptionEntry) and unsets the whole array, since
+// unsetting just the first line would leave an empty local array that
+// hides the global formats.
+const statusCenterOption = "status-format"
+
+// statusOptionEntry is the name to read or set opt by: the first status
+// line's format for statusCenterOption, opt itself otherwise.
+func statusOptionEntry(opt string) string {
+	if opt == statusCenterOption {
+		return opt + "[0]"
+	}
+	return opt
+}
+
+// StatusCenterSupported reports whether tmux v can draw a centered status
+// segment. status-format with alignment and the ranges that keep status-left,
+// window and status-right clicks working arrived in tmux 3.0.
+func StatusCenterSupported(v Version) bool {
+	return v.AtLeast(3, 0)
+}
+
+// ValidateStatusCenter checks a status center token: one of
+// StatusCenterTokens, or empty for no centered segment.
+func ValidateStatusCenter(token string) error {
+	if token == "" {
+		return nil
+	}
+	for _, t := range StatusCenterTokens {
+		if token == t {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid status center %q (want %s)", token, strings.Join(StatusCenterTokens, ", "))
+}
+
+// statusCenter returns the centered segment text token gives an agent
+// identity, or "" for no segment. Town-level agents have no rig, so "rig"
+// gives them none. '#' is escaped so names render literally.
+func statusCenter(token, rig, worker, role string) string {
+	var text string
+	switch token {
+	case "rig":
+		text = rig
+	case "worker":
+		text = worker
+	case "role":
+		text = role
+	case "identity":
+		text = paneTitle(rig, worker, role)
+	}
+	return strings.ReplaceAll(text, "#", "##")
+}
+
+// statusCenterFormat returns a status-format that draws the usual status
+// bar (status-left, the window list, status-right, with their lengths,
+// styles and mouse ranges) plus center in the middle.
+func statusCenterFormat(center string) string {
+	window := func(format, style string) string {
+		return "#[range=window|#{window_index} #{E:" + style + "}]#{T:" + format + "}#[norange default]" +
+			"#{?window_end_flag,,#{window-status-separator}}"
+	}
+	return "#[align=left range=left]#{T;=/#{status-left-length}:status-left}#[norange default]" +
+		"#{W:" + window("window-status-format", "window-status-style") + "," +
+		window("window-status-current-format", "window-status-current-style") + "}" +
+		"#[align=centre]" + center +
+		"#[align=right range=right]#{T;=/#{status-right-length}:status-right}#[norange default]"
+}
+
+// statusCenterArgs returns the set-option arguments that give session the
+// centered segment token describes on tmux v, or unset it when there is
+// none, so dropping a theme's status_center takes effect on the next
+// apply. It returns nil on a tmux without centered segments.
+func statusCenterArgs(v Version, session, token, rig, worker, role string) []string {
+	if !StatusCenterSupported(v) {
+		return nil
+	}
+	center := statusCenter(token, rig, worker, role)
+	if strings.TrimSpace(center) == "" {
+		return []string{"set-option", "-u", "-t", session, statusCenterOption}
+	}
+	return []string{"set-option", "-t", session, statusOptionEntry(statusCenterOption), statusCenterFormat(center)}
+}
+
+// statusCenterOptions are the session options for the centered segment
+// gt may set on tmux v.
+func statusCenterOptions(v Version) []string {
+	if !StatusCenterSupported(v) {
+		return nil
+	}
+	return []string{statusCenterOption}
+}
@@ -0,0 +1,100 @@
+package tmux
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateStatusCenter(t *testing.T) {
+	for _, tok := range append([]string{""}, StatusCenterTokens...) {
+		if err := ValidateStatusCenter(tok); err != nil {
+			t.Errorf("ValidateStatusCenter(%q) = %v", tok, err)
+		}
+	}
+	if err := ValidateStatusCenter("clock"); err == nil {
+		t.Error(`ValidateStatusCenter("clock") = nil, want error`)
+	}
+}
+
+func TestStatusCenter(t *testing.T) {
+	tests := []struct {
+		token, rig, worker, role string
+		want                     string
+	}{
+		{"rig", "Gas #Town", "Toast", "polecat", "Gas ##Town"},
+		{"worker", "gastown", "Toast", "polecat", "Toast"},
+		{"role", "gastown", "Toast", "polecat", "polecat"},
+		{"identity", "gastown", "max", "crew", "👷 gastown/crew/max"},
+		{"rig", "", "Mayor", "mayor", ""},
+		{"", "gastown", "Toast", "polecat", ""},
+	}
+	for _, tt := range tests {
+		if got := statusCenter(tt.token, tt.rig, tt.worker, tt.role); got != tt.want {
+			t.Errorf("statusCenter(%q, %s/%s) = %q, want %q", tt.token, tt.rig, tt.worker, got, tt.want)
+		}
+	}
+}
+
+func TestStatusCenterArgs(t *testing.T) {
+	if args := statusCenterArgs(Version{Major: 2, Minor: 9}, "gt-gastown-Toast", "rig", "gastown", "Toast", "polecat"); args != nil {
+		t.Errorf("tmux 2.9: args = %v, want none", args)
+	}
+
+	args := statusCenterArgs(Version{Major: 3, Minor: 0}, "gt-gastown-Toast", "rig", "gastown", "Toast", "polecat")
+	if len(args) != 5 || args[3] != "status-format[0]" || !strings.Contains(args[4], "#[align=centre]gastown#[align=right") {
+		t.Errorf("tmux 3.0: args = %v, want %s with a centered gastown", args, statusCenterOption)
+	}
+
+	// No segment unsets one left by an earlier theme.
+	args = statusCenterArgs(Version{}, "gt-mayor", "rig", "", "Mayor", "mayor")
+	if strings.Join(args, " ") != "set-option -u -t gt-mayor "+statusCenterOption {
+		t.Errorf("town session: args = %v, want unset", args)
+	}
+}
+
+func TestSetStatusFormatCenter(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	tm := NewTmux()
+	if v, _ := tm.Version(); !StatusCenterSupported(v) {
+		t.Skipf("tmux %s has no centered status segment", v)
+	}
+	sessionName := "gt-test-center-" + t.Name()
+	_ = tm.KillSession(sessionName)
+	if err := tm.NewSession(sessionName, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+
+	snap, err := tm.SnapshotStatus(sessionName)
+	if err != nil {
+		t.Fatalf("SnapshotStatus: %v", err)
+	}
+	if err := tm.SetStatusFormat(sessionName, "gastown", "Toast", "polecat", "rig"); err != nil {
+		t.Fatalf("SetStatusFormat: %v", err)
+	}
+	got, _ := tm.run("display-message", "-p", "-t", sessionName, "#{E:status-format[0]}")
+	if !strings.Contains(got, "#[align=centre]gastown") || !strings.Contains(got, "😺 gastown/Toast") {
+		t.Errorf("expanded status format = %q, want the identity on the left and gastown centered", got)
+	}
+
+	if err := tm.SetStatusFormat(sessionName, "gastown", "Toast", "polecat", ""); err != nil {
+		t.Fatalf("SetStatusFormat: %v", err)
+	}
+	if opts, _ := tm.run("show-options", "-t", sessionName); strings.Contains(opts, "status-format") {
+		t.Errorf("status-format still set after removing the center:\n%s", opts)
+	}
+
+	// RestoreStatus undoes the centered segment too.
+	if err := tm.SetStatusFormat(sessionName, "gastown", "Toast", "polecat", "worker"); err != nil {
+		t.Fatalf("SetStatusFormat: %v", err)
+	}
+	if err := tm.RestoreStatus(sessionName, snap); err != nil {
+		t.Fatalf("RestoreStatus: %v", err)
+	}
+	if opts, _ := tm.run("show-options", "-t", sessionName); strings.Contains(opts, "status-format") {
+		t.Errorf("status-format still set after RestoreStatus:\n%s", opts)
+	}
+}
@@ -4,17 +4,33 @@ package tmux
 import (
 	"fmt"
 	"hash/fnv"
+	"os"
+	"strings"
 )
 
 // Theme represents a tmux status bar color scheme.
+//
+// The JSON field names are stable: bg, fg, status_position and
+// status_center match config.CustomTheme, so a marshaled Theme can be stored as a custom theme.
 type Theme struct {
-	Name string // Human-readable name
-	BG   string // Background color (hex or tmux color name)
-	FG   string // Foreground color (hex or tmux color name)
+	Name string `json:"name"` // Human-readable name
+	BG   string `json:"bg"`   // Background color (hex or tmux color name)
+	FG   string `json:"fg"`   // Foreground color (hex or tmux color name)
+
+	// StatusPosition is the preferred status line position, "top" or
+	// "bottom". Empty leaves the session's status-position unchanged.
+	StatusPosition string `json:"status_position,omitempty"`
+
+	// StatusCenter adds a centered status segment showing one of
+	// StatusCenterTokens, for a three-part status bar on wide terminals.
+	// Empty keeps the usual left/right layout.
+	StatusCenter string `json:"status_center,omitempty"`
 }
 
 // DefaultPalette is the curated set of distinct, professional color themes.
 // Each theme has good contrast and is visually distinct from others.
+// Treat it as read-only: Palette, GetThemeByName and AssignTheme hand out
+// copies, and WithName/WithOverrides derive new themes from them.
 var DefaultPalette = []Theme{
 	{Name: "ocean", BG: "#1e3a5f", FG: "#e0e0e0"},    // Deep blue
 	{Name: "forest", BG: "#2d5a3d", FG: "#e0e0e0"},   // Forest green
@@ -40,13 +56,26 @@ func DeaconTheme() Theme {
 	return Theme{Name: "deacon", BG: "#2d1f3d", FG: "#c0b0d0"}
 }
 
+// UnknownTheme returns the theme for sessions whose rig couldn't be
+// detected. Muted gray, so a detection failure is visible at a glance
+// instead of masquerading as a hash-assigned rig color.
+func UnknownTheme() Theme {
+	return Theme{Name: "unknown", BG: "#3a3a3a", FG: "#a0a0a0"}
+}
+
 // DogTheme returns the theme for Dog sessions.
 // Brown/tan - earthy, loyal worker aesthetic.
 func DogTheme() Theme {
 	return Theme{Name: "dog", BG: "#3d2f1f", FG: "#d0c0a0"}
 }
 
+// Palette returns a copy of DefaultPalette that callers may modify.
+func Palette() []Theme {
+	return append([]Theme(nil), DefaultPalette...)
+}
+
 // GetThemeByName finds a theme by name from the default palette.
+// It returns a pointer to a copy, so changes don't leak into the palette.
 // Returns nil if not found.
 func GetThemeByName(name string) *Theme {
 	for _, t := range DefaultPalette {
@@ -57,8 +86,25 @@ func GetThemeByName(name string) *Theme {
 	return nil
 }
 
+// ThemeSeedEnv sets the seed mixed into AssignTheme's hash, overriding
+// theme.seed in mayor/config.json.
+const ThemeSeedEnv = "GT_THEME_SEED"
+
+// themeSeed is the current assignment seed; see SetThemeSeed.
+var themeSeed = os.Getenv(ThemeSeedEnv)
+
+// SetThemeSeed sets the seed mixed into the rig name hash by AssignTheme.
+// Changing it reshuffles the default theme of every rig without a
+// configured theme; the empty seed (the default) gives the original
+// assignments. gt sets it at startup from GT_THEME_SEED or the town's
+// theme.seed.
+func SetThemeSeed(seed string) {
+	themeSeed = seed
+}
+
 // AssignTheme picks a theme for a rig based on its name.
-// Uses consistent hashing so the same rig always gets the same color.
+// Uses consistent hashing so the same rig always gets the same color for
+// a given seed (see SetThemeSeed).
 func AssignTheme(rigName string) Theme {
 	return AssignThemeFromPalette(rigName, DefaultPalette)
 }
@@ -70,15 +116,194 @@ func AssignThemeFromPalette(rigName string, palette []Theme) Theme {
 	}
 	h := fnv.New32a()
 	_, _ = h.Write([]byte(rigName))
+	if themeSeed != "" {
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(themeSeed))
+	}
 	idx := int(h.Sum32()) % len(palette)
 	return palette[idx]
 }
 
+// WithName returns a copy of the theme under a different name, e.g. for
+// exporting a built-in theme as a custom one.
+func (t Theme) WithName(name string) Theme {
+	t.Name = name
+	return t
+}
+
+// WithOverrides returns a copy of the theme with every non-empty field of o
+// replacing the corresponding field of t.
+func (t Theme) WithOverrides(o Theme) Theme {
+	if o.Name != "" {
+		t.Name = o.Name
+	}
+	if o.BG != "" {
+		t.BG = o.BG
+	}
+	if o.FG != "" {
+		t.FG = o.FG
+	}
+	if o.StatusPosition != "" {
+		t.StatusPosition = o.StatusPosition
+	}
+	if o.StatusCenter != "" {
+		t.StatusCenter = o.StatusCenter
+	}
+	return t
+}
+
 // Style returns the tmux status-style string for this theme.
 func (t Theme) Style() string {
 	return fmt.Sprintf("bg=%s,fg=%s", t.BG, t.FG)
 }
 
+// Equal reports whether two themes produce the same status bar colors.
+// Names are ignored and colors compare case-insensitively.
+func (t Theme) Equal(o Theme) bool {
+	return strings.EqualFold(t.BG, o.BG) && strings.EqualFold(t.FG, o.FG)
+}
+
+// ParseStyle extracts the bg/fg colors from a tmux style string such as
+// "bg=#1e3a5f,fg=#e0e0e0,bold". Attributes other than colors are ignored.
+func ParseStyle(style string) Theme {
+	var theme Theme
+	for _, part := range strings.Split(style, ",") {
+		key, val, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "bg":
+			theme.BG = val
+		case "fg":
+			theme.FG = val
+		}
+	}
+	return theme
+}
+
+// Dimmed returns a muted variant of the theme for idle sessions: the
+// foreground is blended halfway toward the background. Tmux color names
+// can't be blended, so they fall back to a fixed grey foreground.
+func (t Theme) Dimmed() Theme {
+	fg := "colour244"
+	if blended, ok := blendHex(t.FG, t.BG); ok {
+		fg = blended
+	}
+	return t.WithOverrides(Theme{Name: t.Name + "-dim", FG: fg})
+}
+
+// MaxGradientPanes bounds the pane accent gradient: panes from this index
+// on share the dimmest accent, so many-pane windows don't fade to black.
+const MaxGradientPanes = 8
+
+// gradientDim is how far the last pane's accent is dimmed: its lightness is
+// this fraction below pane 0's, with half as much saturation lost.
+const gradientDim = 0.5
+
+// PaneAccents returns an accent color for each of n panes, from the theme's
+// background at pane 0 down to the dimmest at pane MaxGradientPanes-1 (or
+// the last pane, if fewer). A background that isn't "#rrggbb" can't be
+// dimmed and is returned for every pane.
+func (t Theme) PaneAccents(n int) []string {
+	accents := make([]string, n)
+	base, err := ParseHexColor(t.BG)
+	steps := min(n, MaxGradientPanes) - 1
+	for i := range accents {
+		if err != nil || steps <= 0 {
+			accents[i] = t.BG
+			continue
+		}
+		f := gradientDim * float64(min(i, steps)) / float64(steps)
+		accents[i] = HSL{H: base.H, S: base.S * (1 - f/2), L: base.L * (1 - f)}.Hex()
+	}
+	return accents
+}
+
+// blendHex returns the midpoint of two "#rrggbb" colors.
+func blendHex(a, b string) (string, bool) {
+	var ar, ag, ab, br, bg, bb int
+	if _, err := fmt.Sscanf(a, "#%02x%02x%02x", &ar, &ag, &ab); err != nil || len(a) != 7 {
+		return "", false
+	}
+	if _, err := fmt.Sscanf(b, "#%02x%02x%02x", &br, &bg, &bb); err != nil || len(b) != 7 {
+		return "", false
+	}
+	return fmt.Sprintf("#%02x%02x%02x", (ar+br)/2, (ag+bg)/2, (ab+bb)/2), true
+}
+
+// WindowStatusStyle returns the tmux window-status-style string for
+// inactive window tabs. It matches the status bar so tabs blend in.
+func (t Theme) WindowStatusStyle() string {
+	return t.Style()
+}
+
+// WindowStatusCurrentStyle returns the tmux window-status-current-style
+// string for the active window tab: the theme colors inverted, in bold.
+func (t Theme) WindowStatusCurrentStyle() string {
+	return fmt.Sprintf("bg=%s,fg=%s,bold", t.FG, t.BG)
+}
+
+// MessageStyle returns the tmux message-style string for status messages
+// and the command prompt: the theme's accent background, like the status bar.
+func (t Theme) MessageStyle() string {
+	return t.Style()
+}
+
+// MessageCommandStyle returns the tmux message-command-style string for the
+// prompt in vi command mode: the theme colors inverted, so the mode stands out.
+func (t Theme) MessageCommandStyle() string {
+	return fmt.Sprintf("bg=%s,fg=%s", t.FG, t.BG)
+}
+
+// ModeStyle returns the tmux mode-style string for copy-mode selections
+// and the copy-mode position indicator: the theme's accent background.
+func (t Theme) ModeStyle() string {
+	return fmt.Sprintf("bg=%s,fg=%s", t.BG, t.FG)
+}
+
+// inactivePaneShade is the fraction of the theme background's lightness
+// kept for inactive panes, so they are a dark tint of the rig's color.
+const inactivePaneShade = 0.3
+
+// InactivePaneStyle returns the tmux window-style string for inactive
+// panes: a dark, desaturated shade of the theme's background. A background
+// that isn't "#rrggbb" can't be shaded and falls back to a fixed dark grey.
+func (t Theme) InactivePaneStyle() string {
+	bg := "colour235"
+	if base, err := ParseHexColor(t.BG); err == nil {
+		bg = HSL{H: base.H, S: base.S / 2, L: base.L * inactivePaneShade}.Hex()
+	}
+	return "bg=" + bg
+}
+
+// ActivePaneStyle returns the tmux window-active-style string for the
+// active pane: the terminal's own background, so it stands out against the
+// dimmed inactive panes.
+func (t Theme) ActivePaneStyle() string {
+	return "bg=default"
+}
+
+// ClockModeColour returns the tmux clock-mode-colour for the big clock
+// (prefix t): the theme's accent background.
+func (t Theme) ClockModeColour() string {
+	return t.BG
+}
+
+// WindowStatusActivityStyle returns the tmux window-status-activity-style
+// string for tabs of windows with activity: the status bar colors,
+// underlined, instead of tmux's default reverse video.
+func (t Theme) WindowStatusActivityStyle() string {
+	return fmt.Sprintf("bg=%s,fg=%s,underscore", t.BG, t.FG)
+}
+
+// WindowStatusBellStyle returns the tmux window-status-bell-style string
+// for tabs of windows that rang the bell: the theme colors inverted and
+// blinking, so a bell stands out from the bold current-window tab.
+func (t Theme) WindowStatusBellStyle() string {
+	return fmt.Sprintf("bg=%s,fg=%s,blink", t.FG, t.BG)
+}
+
 // ListThemeNames returns the names of all themes in the default palette.
 func ListThemeNames() []string {
 	names := make([]string, len(DefaultPalette))
@@ -0,0 +1,65 @@
+package tmux
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// SourcedTheme is a theme together with the file that defined it.
+type SourcedTheme struct {
+	Theme
+	Source string // path of the themes file
+}
+
+// ThemeFiles returns the *.json files in dir in name order. A missing
+// directory has none.
+func ThemeFiles(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// LoadThemeSources loads the themes files at paths, in order, and returns
+// the merged themes sorted by name. Each file maps theme names to their
+// colors, in the 'gt theme validate' format:
+//
+//	{"sunset": {"bg": "#b33a00", "fg": "#f5f5dc", "status_position": "top"}}
+//
+// A theme defined in more than one file comes from the last, so callers
+// list paths from the most general layer to the most specific. Missing
+// files are skipped; a file that can't be read or parsed fails the load,
+// naming it. Colors are not validated here.
+func LoadThemeSources(paths []string) ([]SourcedTheme, error) {
+	merged := make(map[string]SourcedTheme)
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading themes file %s: %w", path, err)
+		}
+		var defs map[string]Theme
+		if err := json.Unmarshal(data, &defs); err != nil {
+			return nil, fmt.Errorf("parsing themes file %s: %w", path, err)
+		}
+		for name, theme := range defs {
+			theme.Name = name
+			merged[name] = SourcedTheme{Theme: theme, Source: path}
+		}
+	}
+
+	themes := make([]SourcedTheme, 0, len(merged))
+	for _, theme := range merged {
+		themes = append(themes, theme)
+	}
+	sort.Slice(themes, func(i, j int) bool { return themes[i].Name < themes[j].Name })
+	return themes, nil
+}
@@ -0,0 +1,61 @@
+package tmux
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeThemesFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadThemeSources(t *testing.T) {
+	dir := t.TempDir()
+	user := writeThemesFile(t, dir, "user.json", `{"sunset": {"bg": "#b33a00", "fg": "#f5f5dc"}, "ocean": {"bg": "#000080", "fg": "#ffffff"}}`)
+	town := writeThemesFile(t, dir, "town.json", `{"sunset": {"bg": "#ff4500", "fg": "#000000", "status_position": "top"}}`)
+
+	themes, err := LoadThemeSources([]string{user, filepath.Join(dir, "missing.json"), town})
+	if err != nil {
+		t.Fatalf("LoadThemeSources() error = %v", err)
+	}
+	if len(themes) != 2 || themes[0].Name != "ocean" || themes[1].Name != "sunset" {
+		t.Fatalf("LoadThemeSources() = %+v, want ocean and sunset in name order", themes)
+	}
+	if themes[0].Source != user {
+		t.Errorf("ocean source = %s, want %s", themes[0].Source, user)
+	}
+	if sunset := themes[1]; sunset.BG != "#ff4500" || sunset.StatusPosition != "top" || sunset.Source != town {
+		t.Errorf("sunset = %+v, want the later file's definition", sunset)
+	}
+}
+
+func TestLoadThemeSourcesBadFile(t *testing.T) {
+	bad := writeThemesFile(t, t.TempDir(), "bad.json", `{"sunset": `)
+	if _, err := LoadThemeSources([]string{bad}); err == nil || !strings.Contains(err.Error(), bad) {
+		t.Errorf("LoadThemeSources() error = %v, want one naming %s", err, bad)
+	}
+}
+
+func TestThemeFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.json", "a.json", "notes.txt"} {
+		writeThemesFile(t, dir, name, "{}")
+	}
+	files, err := ThemeFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 || filepath.Base(files[0]) != "a.json" || filepath.Base(files[1]) != "b.json" {
+		t.Errorf("ThemeFiles() = %v, want a.json, b.json", files)
+	}
+	if files, err := ThemeFiles(filepath.Join(dir, "missing")); err != nil || len(files) != 0 {
+		t.Errorf("ThemeFiles(missing) = %v, %v; want none", files, err)
+	}
+}
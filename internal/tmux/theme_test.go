@@ -1,7 +1,12 @@
 package tmux
 
 import (
+	"encoding/json"
+	"math"
+	"strings"
 	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
 )
 
 func TestAssignTheme_Deterministic(t *testing.T) {
@@ -31,6 +36,27 @@ func TestAssignTheme_Distribution(t *testing.T) {
 	}
 }
 
+func TestAssignThemeSeed(t *testing.T) {
+	t.Cleanup(func() { SetThemeSeed("") })
+
+	// The empty seed keeps the original, unseeded assignments
+	SetThemeSeed("")
+	unseeded := map[string]string{"gastown": "teal", "beads": "ocean", "frontend": "ember", "api": "wine"}
+	for rig, want := range unseeded {
+		if got := AssignTheme(rig).Name; got != want {
+			t.Errorf("AssignTheme(%q) = %q, want %q", rig, got, want)
+		}
+	}
+
+	SetThemeSeed("spring")
+	seeded := map[string]string{"gastown": "copper", "beads": "copper", "frontend": "slate", "api": "rust"}
+	for rig, want := range seeded {
+		if got := AssignTheme(rig).Name; got != want {
+			t.Errorf("seeded AssignTheme(%q) = %q, want %q", rig, got, want)
+		}
+	}
+}
+
 func TestGetThemeByName(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -61,6 +87,148 @@ func TestThemeStyle(t *testing.T) {
 	}
 }
 
+func TestThemeWindowStatusStyles(t *testing.T) {
+	theme := Theme{Name: "test", BG: "#1e3a5f", FG: "#e0e0e0"}
+
+	if got := theme.WindowStatusStyle(); got != theme.Style() {
+		t.Errorf("WindowStatusStyle() = %q, want %q", got, theme.Style())
+	}
+	want := "bg=#e0e0e0,fg=#1e3a5f,bold"
+	if got := theme.WindowStatusCurrentStyle(); got != want {
+		t.Errorf("WindowStatusCurrentStyle() = %q, want %q", got, want)
+	}
+}
+
+func TestThemeMessageStyles(t *testing.T) {
+	theme := Theme{Name: "test", BG: "#1e3a5f", FG: "#e0e0e0"}
+
+	if got := theme.MessageStyle(); got != "bg=#1e3a5f,fg=#e0e0e0" {
+		t.Errorf("MessageStyle() = %q", got)
+	}
+	if got := theme.MessageCommandStyle(); got != "bg=#e0e0e0,fg=#1e3a5f" {
+		t.Errorf("MessageCommandStyle() = %q", got)
+	}
+}
+
+func TestThemeIndicatorStyles(t *testing.T) {
+	theme := Theme{Name: "test", BG: "#1e3a5f", FG: "#e0e0e0"}
+
+	if got := theme.ClockModeColour(); got != "#1e3a5f" {
+		t.Errorf("ClockModeColour() = %q", got)
+	}
+	if got := theme.WindowStatusActivityStyle(); got != "bg=#1e3a5f,fg=#e0e0e0,underscore" {
+		t.Errorf("WindowStatusActivityStyle() = %q", got)
+	}
+	if got := theme.WindowStatusBellStyle(); got != "bg=#e0e0e0,fg=#1e3a5f,blink" {
+		t.Errorf("WindowStatusBellStyle() = %q", got)
+	}
+	if got := theme.ModeStyle(); got != "bg=#1e3a5f,fg=#e0e0e0" {
+		t.Errorf("ModeStyle() = %q", got)
+	}
+}
+
+func TestInactivePaneStyle(t *testing.T) {
+	theme := Theme{Name: "test", BG: "#1e3a5f", FG: "#e0e0e0"}
+	style := theme.InactivePaneStyle()
+	bg, ok := strings.CutPrefix(style, "bg=")
+	if !ok {
+		t.Fatalf("InactivePaneStyle() = %q, want bg=", style)
+	}
+	got, err := ParseHexColor(bg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base, _ := ParseHexColor(theme.BG)
+	if math.Abs(got.L-base.L*inactivePaneShade) > 1 || math.Abs(got.H-base.H) > 5 {
+		t.Errorf("InactivePaneStyle() = %q (%+v), want a dark shade of %s (%+v)", style, got, theme.BG, base)
+	}
+
+	if got := (Theme{BG: "colour25", FG: "colour255"}).InactivePaneStyle(); got != "bg=colour235" {
+		t.Errorf("InactivePaneStyle() for a colour name = %q, want the grey fallback", got)
+	}
+	if got := theme.ActivePaneStyle(); got != "bg=default" {
+		t.Errorf("ActivePaneStyle() = %q", got)
+	}
+}
+
+func TestThemeDimmed(t *testing.T) {
+	tests := []struct {
+		theme  Theme
+		wantFG string
+	}{
+		{Theme{Name: "ocean", BG: "#000000", FG: "#ffffff"}, "#7f7f7f"},
+		{Theme{Name: "forest", BG: "#2d5a3d", FG: "#e0e0e0"}, "#869d8e"},
+		{Theme{Name: "named", BG: "black", FG: "white"}, "colour244"},
+	}
+
+	for _, tt := range tests {
+		dim := tt.theme.Dimmed()
+		if dim.FG != tt.wantFG || dim.BG != tt.theme.BG {
+			t.Errorf("%s.Dimmed() = %+v, want FG %s on BG %s", tt.theme.Name, dim, tt.wantFG, tt.theme.BG)
+		}
+		if dim.Name != tt.theme.Name+"-dim" {
+			t.Errorf("%s.Dimmed().Name = %q", tt.theme.Name, dim.Name)
+		}
+	}
+}
+
+func TestThemePaneAccents(t *testing.T) {
+	theme := Theme{Name: "forest", BG: "#2d5a3d", FG: "#e0e0e0"}
+	base, _ := ParseHexColor(theme.BG)
+
+	accents := theme.PaneAccents(3)
+	if len(accents) != 3 || accents[0] != theme.BG {
+		t.Fatalf("PaneAccents(3) = %v, want pane 0 = %s", accents, theme.BG)
+	}
+	prev := base.L + 1
+	for i, a := range accents {
+		c, err := ParseHexColor(a)
+		if err != nil {
+			t.Fatalf("accent %d = %q: %v", i, a, err)
+		}
+		if c.L >= prev {
+			t.Errorf("accent %d lightness %.1f, want below %.1f", i, c.L, prev)
+		}
+		prev = c.L
+	}
+	if last, _ := ParseHexColor(accents[2]); math.Abs(last.L-base.L*(1-gradientDim)) > 1 {
+		t.Errorf("last accent lightness %.1f, want %.1f", last.L, base.L*(1-gradientDim))
+	}
+
+	// Bounded: panes past MaxGradientPanes share the dimmest accent
+	many := theme.PaneAccents(MaxGradientPanes + 4)
+	for i := MaxGradientPanes; i < len(many); i++ {
+		if many[i] != many[MaxGradientPanes-1] {
+			t.Errorf("accent %d = %s, want dimmest %s", i, many[i], many[MaxGradientPanes-1])
+		}
+	}
+
+	if got := theme.PaneAccents(1); len(got) != 1 || got[0] != theme.BG {
+		t.Errorf("PaneAccents(1) = %v", got)
+	}
+	named := Theme{Name: "named", BG: "blue", FG: "white"}
+	if got := named.PaneAccents(2); got[0] != "blue" || got[1] != "blue" {
+		t.Errorf("named PaneAccents(2) = %v, want blue for every pane", got)
+	}
+}
+
+func TestThemeEqualAndParseStyle(t *testing.T) {
+	theme := Theme{Name: "ocean", BG: "#1e3a5f", FG: "#e0e0e0"}
+
+	if !theme.Equal(ParseStyle(theme.Style())) {
+		t.Errorf("ParseStyle(Style()) should round-trip: %+v", ParseStyle(theme.Style()))
+	}
+	if !theme.Equal(ParseStyle("fg=#E0E0E0,bg=#1E3A5F,bold")) {
+		t.Error("Equal should ignore case, order and attributes")
+	}
+	if theme.Equal(Theme{}) {
+		t.Error("zero theme should not equal a real theme")
+	}
+	if theme.Equal(Theme{Name: "ocean", BG: "#1e3a5f", FG: "#ffffff"}) {
+		t.Error("different FG should not be equal")
+	}
+}
+
 func TestMayorTheme(t *testing.T) {
 	theme := MayorTheme()
 
@@ -125,3 +293,76 @@ func TestAssignThemeFromPalette_CustomPalette(t *testing.T) {
 		t.Errorf("AssignThemeFromPalette returned %q, want one of custom themes", theme.Name)
 	}
 }
+
+func TestThemeWithNameAndOverrides(t *testing.T) {
+	base := *GetThemeByName("ocean")
+
+	renamed := base.WithName("my-ocean")
+	if renamed.Name != "my-ocean" || renamed.BG != base.BG || renamed.FG != base.FG {
+		t.Errorf("WithName = %+v, want ocean colors named my-ocean", renamed)
+	}
+
+	over := base.WithOverrides(Theme{FG: "#ffffff"})
+	if over.Name != "ocean" || over.BG != base.BG || over.FG != "#ffffff" {
+		t.Errorf("WithOverrides = %+v, want ocean with FG #ffffff", over)
+	}
+
+	if got := base.WithOverrides(Theme{}); got != base {
+		t.Errorf("WithOverrides(empty) = %+v, want %+v", got, base)
+	}
+}
+
+func TestBuiltinThemesNotMutated(t *testing.T) {
+	want := DefaultPalette[0]
+
+	if theme := GetThemeByName(want.Name); theme != nil {
+		theme.BG = "#000000"
+		_ = theme.WithName("changed")
+	}
+	palette := Palette()
+	palette[0].FG = "#000000"
+	_ = AssignTheme("gastown").WithOverrides(Theme{BG: "#000000"})
+
+	if DefaultPalette[0] != want {
+		t.Errorf("DefaultPalette[0] = %+v, want unchanged %+v", DefaultPalette[0], want)
+	}
+	if got := GetThemeByName(want.Name); *got != want {
+		t.Errorf("GetThemeByName(%s) = %+v, want unchanged %+v", want.Name, *got, want)
+	}
+}
+
+func TestThemeJSONRoundTrip(t *testing.T) {
+	for _, theme := range append(Palette(), Theme{Name: "top", BG: "colour24", FG: "white", StatusPosition: "top"}) {
+		data, err := json.Marshal(theme)
+		if err != nil {
+			t.Fatalf("Marshal(%s): %v", theme.Name, err)
+		}
+		var got Theme
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", data, err)
+		}
+		if got != theme {
+			t.Errorf("round trip of %s = %+v, want %+v", data, got, theme)
+		}
+	}
+}
+
+func TestThemeJSONFieldNames(t *testing.T) {
+	data, err := json.Marshal(Theme{Name: "ocean", BG: "#1e3a5f", FG: "#e0e0e0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"name":"ocean","bg":"#1e3a5f","fg":"#e0e0e0"}`; string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	// A marshaled Theme decodes as a config.CustomTheme
+	data, _ = json.Marshal(Theme{Name: "x", BG: "#112233", FG: "#ddeeff", StatusPosition: "bottom"})
+	var custom config.CustomTheme
+	if err := json.Unmarshal(data, &custom); err != nil {
+		t.Fatal(err)
+	}
+	if custom.BG != "#112233" || custom.FG != "#ddeeff" || custom.StatusPosition != "bottom" {
+		t.Errorf("CustomTheme from Theme JSON = %+v", custom)
+	}
+}
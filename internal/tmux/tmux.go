@@ -3,6 +3,7 @@ package tmux
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -13,6 +14,7 @@ import (
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
@@ -31,29 +33,189 @@ var (
 	ErrNoServer        = errors.New("no tmux server running")
 	ErrSessionExists   = errors.New("session already exists")
 	ErrSessionNotFound = errors.New("session not found")
+
+	// ErrServerBusy marks a transient server failure (the server is
+	// starting, restarting or overloaded). Commands failing with it are
+	// retried according to the Tmux's RetryPolicy.
+	ErrServerBusy = errors.New("tmux server temporarily unavailable")
+
+	// ErrTmuxTimeout is returned when a tmux command doesn't finish within
+	// the Tmux's timeout, typically because the server is wedged. The
+	// command was killed and is not retried.
+	ErrTmuxTimeout = errors.New("tmux command timed out")
+
+	// ErrNotInstalled is returned when the tmux binary can't be found.
+	ErrNotInstalled = errors.New("tmux not installed")
+
+	// ErrNoSession is returned by teardown when the session is already gone.
+	// It is the same sentinel as ErrSessionNotFound, so callers that treat a
+	// missing session as success can match either.
+	ErrNoSession = ErrSessionNotFound
 )
 
+// SocketEnv is the environment variable that selects a tmux server other
+// than the default, e.g. a socket forwarded over SSH.
+const SocketEnv = "GT_TMUX_SOCKET"
+
+// TimeoutEnv overrides DefaultTimeout for every tmux command, as a Go
+// duration ("10s"); "0" disables the timeout.
+const TimeoutEnv = "GT_TMUX_TIMEOUT"
+
+// DefaultTimeout bounds each tmux invocation so a wedged server can't hang
+// gt. See SetTimeout and TimeoutEnv.
+const DefaultTimeout = 5 * time.Second
+
+// untimedCommands are tmux commands that legitimately run for as long as
+// the user wants and are never timed out.
+var untimedCommands = map[string]bool{"attach-session": true}
+
+// RetryPolicy bounds how tmux commands failing with ErrServerBusy are
+// retried. The delay doubles after each retry. Other errors (no server,
+// missing session, bad option) are never retried.
+type RetryPolicy struct {
+	Attempts int           // total tries including the first; <= 1 disables retries
+	Delay    time.Duration // wait before the first retry
+}
+
+// DefaultRetryPolicy is used by NewTmux and NewTmuxWithSocket.
+var DefaultRetryPolicy = RetryPolicy{Attempts: 3, Delay: 100 * time.Millisecond}
+
 // Tmux wraps tmux operations.
-type Tmux struct{}
+type Tmux struct {
+	socket  string        // "" for the default server; see NewTmuxWithSocket
+	retry   RetryPolicy   // see SetRetryPolicy
+	timeout time.Duration // per invocation, 0 for none; see SetTimeout
+
+	// exec runs one tmux invocation and returns its stdout and stderr; it
+	// must give up when ctx is done. nil runs the tmux binary; tests
+	// substitute a fake.
+	exec func(ctx context.Context, args []string) (stdout, stderr string, err error)
 
-// NewTmux creates a new Tmux wrapper.
+	// tmux version, detected on first use; see Version
+	versionOnce sync.Once
+	version     Version
+	versionErr  error
+}
+
+// NewTmux creates a new Tmux wrapper. It talks to the server named by
+// GT_TMUX_SOCKET when set, and the default server otherwise.
 func NewTmux() *Tmux {
-	return &Tmux{}
+	return NewTmuxWithSocket(os.Getenv(SocketEnv))
+}
+
+// NewTmuxWithSocket creates a Tmux wrapper bound to a specific server.
+// A socket containing a path separator is passed to tmux as a socket path
+// (-S); anything else is a socket name (-L). Empty uses the default server.
+func NewTmuxWithSocket(socket string) *Tmux {
+	return &Tmux{socket: strings.TrimSpace(socket), retry: DefaultRetryPolicy, timeout: timeoutFromEnv()}
+}
+
+// timeoutFromEnv returns the TimeoutEnv duration, or DefaultTimeout if it
+// is unset or invalid.
+func timeoutFromEnv() time.Duration {
+	if v := strings.TrimSpace(os.Getenv(TimeoutEnv)); v != "" {
+		if v == "0" {
+			return 0
+		}
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			return d
+		}
+	}
+	return DefaultTimeout
+}
+
+// SetTimeout changes how long each tmux invocation may run before it is
+// killed and fails with ErrTmuxTimeout. 0 disables the timeout.
+func (t *Tmux) SetTimeout(d time.Duration) {
+	t.timeout = d
 }
 
-// run executes a tmux command and returns stdout.
+// SetRetryPolicy changes how transient server errors are retried.
+func (t *Tmux) SetRetryPolicy(p RetryPolicy) {
+	t.retry = p
+}
+
+// Socket returns the socket path or name this wrapper targets, or "" for
+// the default server.
+func (t *Tmux) Socket() string {
+	return t.socket
+}
+
+// socketArgs returns the global tmux flags selecting t's server.
+func (t *Tmux) socketArgs() []string {
+	switch {
+	case t.socket == "":
+		return nil
+	case strings.ContainsRune(t.socket, filepath.Separator):
+		return []string{"-S", t.socket}
+	default:
+		return []string{"-L", t.socket}
+	}
+}
+
+// CheckServer verifies that the targeted tmux server is reachable, so
+// callers can fail early with a clear message instead of on the first
+// command.
+func (t *Tmux) CheckServer() error {
+	if strings.ContainsRune(t.socket, filepath.Separator) {
+		if _, err := os.Stat(t.socket); err != nil {
+			return fmt.Errorf("tmux socket %s: %w", t.socket, err)
+		}
+	}
+	if _, err := t.run("list-sessions"); err != nil {
+		if errors.Is(err, ErrNoServer) && t.socket != "" {
+			return fmt.Errorf("no tmux server reachable on socket %s: %w", t.socket, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// run executes a tmux command and returns stdout. Transient server errors
+// are retried with backoff per the RetryPolicy.
 func (t *Tmux) run(args ...string) (string, error) {
-	cmd := exec.Command("tmux", args...)
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	delay := t.retry.Delay
+	for attempt := 1; ; attempt++ {
+		out, err := t.runOnce(args)
+		if err == nil || attempt >= t.retry.Attempts || !errors.Is(err, ErrServerBusy) {
+			return out, err
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
 
-	err := cmd.Run()
+// runOnce executes a single tmux invocation, bounded by the timeout.
+func (t *Tmux) runOnce(args []string) (string, error) {
+	run := t.exec
+	if run == nil {
+		run = t.execTmux
+	}
+	ctx := context.Background()
+	if t.timeout > 0 && len(args) > 0 && !untimedCommands[args[0]] {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+		defer cancel()
+	}
+	stdout, stderr, err := run(ctx, args)
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("%w: tmux %s after %s", ErrTmuxTimeout, args[0], t.timeout)
+	}
 	if err != nil {
-		return "", t.wrapError(err, stderr.String(), args)
+		return "", t.wrapError(err, stderr, args)
 	}
+	return strings.TrimSpace(stdout), nil
+}
 
-	return strings.TrimSpace(stdout.String()), nil
+// execTmux runs the tmux binary against this Tmux's server, killing it
+// when ctx is done.
+func (t *Tmux) execTmux(ctx context.Context, args []string) (string, string, error) {
+	cmd := exec.CommandContext(ctx, "tmux", append(t.socketArgs(), args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	return stdout.String(), stderr.String(), err
 }
 
 // wrapError wraps tmux errors with context.
@@ -61,6 +223,9 @@ func (t *Tmux) wrapError(err error, stderr string, args []string) error {
 	stderr = strings.TrimSpace(stderr)
 
 	// Detect specific error types
+	if errors.Is(err, exec.ErrNotFound) {
+		return fmt.Errorf("%w: %v", ErrNotInstalled, err)
+	}
 	if strings.Contains(stderr, "no server running") ||
 		strings.Contains(stderr, "error connecting to") ||
 		strings.Contains(stderr, "no current target") {
@@ -73,6 +238,9 @@ func (t *Tmux) wrapError(err error, stderr string, args []string) error {
 		strings.Contains(stderr, "can't find session") {
 		return ErrSessionNotFound
 	}
+	if isTransientStderr(stderr) {
+		return fmt.Errorf("%w: tmux %s: %s", ErrServerBusy, args[0], stderr)
+	}
 
 	if stderr != "" {
 		return fmt.Errorf("tmux %s: %s", args[0], stderr)
@@ -80,6 +248,26 @@ func (t *Tmux) wrapError(err error, stderr string, args []string) error {
 	return fmt.Errorf("tmux %s: %w", args[0], err)
 }
 
+// transientStderr are tmux messages for server hiccups that go away on
+// their own, as opposed to errors in the command or its target.
+var transientStderr = []string{
+	"server exited unexpectedly",
+	"lost server",
+	"server not ready",
+	"resource temporarily unavailable",
+	"interrupted system call",
+}
+
+func isTransientStderr(stderr string) bool {
+	lower := strings.ToLower(stderr)
+	for _, msg := range transientStderr {
+		if strings.Contains(lower, msg) {
+			return true
+		}
+	}
+	return false
+}
+
 // NewSession creates a new detached tmux session.
 func (t *Tmux) NewSession(name, workDir string) error {
 	args := []string{"new-session", "-d", "-s", name}
@@ -141,12 +329,45 @@ func (t *Tmux) EnsureSessionFresh(name, workDir string) error {
 	return t.NewSession(name, workDir)
 }
 
-// KillSession terminates a tmux session.
+// KillSession terminates a tmux session. The name is matched exactly, so
+// killing "gt-foo" never takes down "gt-foobar". Returns ErrNoSession when
+// the session (or the whole tmux server) is already gone.
 func (t *Tmux) KillSession(name string) error {
-	_, err := t.run("kill-session", "-t", name)
+	_, err := t.run("kill-session", "-t", "="+name)
+	if errors.Is(err, ErrNoServer) {
+		return ErrNoSession
+	}
 	return err
 }
 
+// KillSessionsByPrefix terminates every session whose name starts with
+// prefix and returns the names it killed. Sessions that disappear between
+// listing and killing are skipped; the first other error stops the sweep.
+func (t *Tmux) KillSessionsByPrefix(prefix string) ([]string, error) {
+	if prefix == "" {
+		return nil, fmt.Errorf("refusing to kill sessions with empty prefix")
+	}
+	sessions, err := t.ListSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	var killed []string
+	for _, sess := range sessions {
+		if !strings.HasPrefix(sess, prefix) {
+			continue
+		}
+		if err := t.KillSession(sess); err != nil {
+			if errors.Is(err, ErrNoSession) {
+				continue
+			}
+			return killed, fmt.Errorf("killing %s: %w", sess, err)
+		}
+		killed = append(killed, sess)
+	}
+	return killed, nil
+}
+
 // processKillGracePeriod is how long to wait after SIGTERM before sending SIGKILL.
 // 2 seconds gives processes time to clean up gracefully. The previous 100ms was too short
 // and caused Claude processes to become orphans when they couldn't shut down in time.
@@ -1355,10 +1576,499 @@ func (t *Tmux) GetSessionInfo(name string) (*SessionInfo, error) {
 
 // ApplyTheme sets the status bar style for a session.
 func (t *Tmux) ApplyTheme(session string, theme Theme) error {
-	_, err := t.run("set-option", "-t", session, "status-style", theme.Style())
+	if _, err := t.run("set-option", "-t", session, "status-style", theme.Style()); err != nil {
+		return err
+	}
+	if theme.StatusPosition == "" {
+		return nil
+	}
+	_, err := t.run("set-option", "-t", session, "status-position", theme.StatusPosition)
+	return err
+}
+
+// SetMessageStyle colors the session's message line and command prompt
+// (message-style and message-command-style) to match the theme.
+func (t *Tmux) SetMessageStyle(session string, theme Theme) error {
+	if _, err := t.run("set-option", "-t", session, "message-style", theme.MessageStyle()); err != nil {
+		return err
+	}
+	_, err := t.run("set-option", "-t", session, "message-command-style", theme.MessageCommandStyle())
+	return err
+}
+
+// SessionActivity returns the time of the session's last activity, as
+// tracked by tmux's session_activity format variable.
+func (t *Tmux) SessionActivity(session string) (time.Time, error) {
+	out, err := t.run("display-message", "-p", "-t", "="+session+":", "#{session_activity}")
+	if err != nil {
+		return time.Time{}, err
+	}
+	if out == "" {
+		return time.Time{}, ErrSessionNotFound
+	}
+	secs, err := strconv.ParseInt(out, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing session_activity %q: %w", out, err)
+	}
+	return time.Unix(secs, 0), nil
+}
+
+// statusOptions are the session options written when theming a session.
+var statusOptions = []string{
+	"status-style",
+	"status-left",
+	"status-left-length",
+	"status-right",
+	"status-right-length",
+	"status-interval",
+	"message-style",
+	"message-command-style",
+	"status-position",
+}
+
+// StatusSnapshot holds a session's locally set status-bar options, keyed by
+// option name. Options absent from the map were inherited from the global
+// defaults when the snapshot was taken.
+type StatusSnapshot map[string]string
+
+// SnapshotStatus records the session-level status-bar options so a later
+// RestoreStatus can undo ApplyTheme, SetStatusFormat, SetStatusTemplate,
+// SetDynamicStatus and SetMessageStyle.
+func (t *Tmux) SnapshotStatus(session string) (StatusSnapshot, error) {
+	set, err := t.setOptionNames("-t", session)
+	if err != nil {
+		return nil, err
+	}
+
+	snap := make(StatusSnapshot)
+	for _, opt := range t.snapshotOptions() {
+		if !set[opt] {
+			continue
+		}
+		val, err := t.run("show-options", "-v", "-t", session, statusOptionEntry(opt))
+		if err != nil {
+			return nil, err
+		}
+		snap[opt] = val
+	}
+	return snap, nil
+}
+
+// RestoreStatus puts a session's status-bar options back to a snapshot.
+// Options that were inherited at snapshot time are unset again.
+func (t *Tmux) RestoreStatus(session string, snap StatusSnapshot) error {
+	for _, opt := range t.snapshotOptions() {
+		var err error
+		if val, ok := snap[opt]; ok {
+			_, err = t.run("set-option", "-t", session, statusOptionEntry(opt), val)
+		} else {
+			_, err = t.run("set-option", "-u", "-t", session, opt)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshotOptions are the session options SnapshotStatus records on this
+// tmux: statusOptions, and the centered segment where tmux has it.
+func (t *Tmux) snapshotOptions() []string {
+	version, _ := t.Version()
+	return append(append([]string{}, statusOptions...), statusCenterOptions(version)...)
+}
+
+// CurrentTheme returns the colors of the session's own status-style. A
+// session that inherits the global style (never themed) returns a zero
+// Theme.
+func (t *Tmux) CurrentTheme(session string) (Theme, error) {
+	out, err := t.run("show-options", "-v", "-t", session, "status-style")
+	if err != nil {
+		return Theme{}, err
+	}
+	return ParseStyle(out), nil
+}
+
+// SetWindowStatusStyle themes the window tabs of every window in a session.
+// Only the window-status styles are touched, so it composes with the
+// status-style set by ApplyTheme and is safe to call repeatedly.
+func (t *Tmux) SetWindowStatusStyle(session string, theme Theme) error {
+	targets, err := t.windowTargets(session)
+	if err != nil {
+		return err
+	}
+	for _, target := range targets {
+		if _, err := t.run("set-option", "-w", "-t", target, "window-status-style", theme.WindowStatusStyle()); err != nil {
+			return err
+		}
+		if _, err := t.run("set-option", "-w", "-t", target, "window-status-current-style", theme.WindowStatusCurrentStyle()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetPaneAccents gives the panes of every window in a session a gradient
+// of the theme's accent (Theme.PaneAccents): each pane's border status line
+// shows its index and title on an accent that is the theme background for
+// the first pane and progressively dimmer for later ones. Border colors are
+// per window in tmux, so the active pane's border gets the brightest accent
+// and the others the dimmest. Pane border status lines are turned on at the
+// top of each window.
+//
+// Option names follow the tmux version: tmux before 2.3 has no border
+// status line, so only the border colors are set, and tmux before 1.9
+// names those pane-border-fg and pane-active-border-fg.
+func (t *Tmux) SetPaneAccents(session string, theme Theme) error {
+	targets, err := t.windowTargets(session)
+	if err != nil {
+		return err
+	}
+	version, _ := t.Version() // unknown: assume a current tmux
+	for _, target := range targets {
+		out, err := t.run("list-panes", "-t", target, "-F", "#{pane_index}")
+		if err != nil {
+			return err
+		}
+		panes := strings.Fields(out)
+		accents := theme.PaneAccents(len(panes))
+		if len(accents) == 0 {
+			continue
+		}
+		var opts []sessionOption
+		if version.AtLeast(2, 3) {
+			opts = append(opts,
+				sessionOption{"pane-border-status", "top"},
+				sessionOption{"pane-border-format", paneAccentFormat(panes, accents, theme.FG)})
+		}
+		opts = append(opts, paneBorderOptions(version, accents[len(accents)-1], accents[0])...)
+		for _, opt := range opts {
+			if _, err := t.run("set-option", "-w", "-t", target, opt.name, opt.value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SetIndicatorStyle colors the indicators tmux otherwise draws in its own
+// defaults - the clock-mode clock and the tabs of windows flagged for
+// activity or a bell - from the theme, in every window of a session. The
+// options for all windows go out in a single tmux invocation.
+func (t *Tmux) SetIndicatorStyle(session string, theme Theme) error {
+	targets, err := t.windowTargets(session)
+	if err != nil {
+		return err
+	}
+	opts := []sessionOption{
+		{"clock-mode-colour", theme.ClockModeColour()},
+		{"window-status-activity-style", theme.WindowStatusActivityStyle()},
+		{"window-status-bell-style", theme.WindowStatusBellStyle()},
+	}
+	var args []string
+	for _, target := range targets {
+		for _, opt := range opts {
+			if len(args) > 0 {
+				args = append(args, ";")
+			}
+			args = append(args, "set-option", "-w", "-t", target, opt.name, opt.value)
+		}
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	_, err = t.run(args...)
+	return err
+}
+
+// SetModeStyle colors copy-mode (the selection and position indicator,
+// mode-style) from the theme in every window of a session, in a single
+// tmux invocation. tmux before 1.9 names the option mode-bg and mode-fg.
+func (t *Tmux) SetModeStyle(session string, theme Theme) error {
+	targets, err := t.windowTargets(session)
+	if err != nil {
+		return err
+	}
+	version, _ := t.Version() // unknown: assume a current tmux
+	var args []string
+	for _, target := range targets {
+		for _, opt := range modeStyleOptionValues(version, theme) {
+			if len(args) > 0 {
+				args = append(args, ";")
+			}
+			args = append(args, "set-option", "-w", "-t", target, opt.name, opt.value)
+		}
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	_, err = t.run(args...)
+	return err
+}
+
+// modeStyleOptionValues are the window options SetModeStyle writes for
+// theme on tmux v.
+func modeStyleOptionValues(v Version, theme Theme) []sessionOption {
+	if !v.AtLeast(1, 9) {
+		return []sessionOption{
+			{"mode-bg", theme.BG},
+			{"mode-fg", theme.FG},
+		}
+	}
+	return []sessionOption{{"mode-style", theme.ModeStyle()}}
+}
+
+// modeStyleOptions are the window options SetModeStyle writes on tmux v.
+func modeStyleOptions(v Version) []string {
+	var names []string
+	for _, opt := range modeStyleOptionValues(v, Theme{}) {
+		names = append(names, opt.name)
+	}
+	return names
+}
+
+// SetWindowStyles dims inactive panes in every window of a session, in a
+// single tmux invocation: window-style gets a dark shade of the theme's
+// background and window-active-style the terminal default. tmux before 2.1
+// has neither option, so nothing is set there.
+func (t *Tmux) SetWindowStyles(session string, theme Theme) error {
+	targets, err := t.windowTargets(session)
+	if err != nil {
+		return err
+	}
+	version, _ := t.Version() // unknown: assume a current tmux
+	var args []string
+	for _, target := range targets {
+		for _, opt := range paneStyleOptionValues(version, theme) {
+			if len(args) > 0 {
+				args = append(args, ";")
+			}
+			args = append(args, "set-option", "-w", "-t", target, opt.name, opt.value)
+		}
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	_, err = t.run(args...)
 	return err
 }
 
+// paneStyleOptionValues are the window options SetWindowStyles writes for
+// theme on tmux v.
+func paneStyleOptionValues(v Version, theme Theme) []sessionOption {
+	if !v.AtLeast(2, 1) {
+		return nil
+	}
+	return []sessionOption{
+		{"window-style", theme.InactivePaneStyle()},
+		{"window-active-style", theme.ActivePaneStyle()},
+	}
+}
+
+// paneStyleOptions are the window options SetWindowStyles writes on tmux v.
+func paneStyleOptions(v Version) []string {
+	var names []string
+	for _, opt := range paneStyleOptionValues(v, Theme{}) {
+		names = append(names, opt.name)
+	}
+	return names
+}
+
+// paneAccentFormat builds a pane-border-format that picks each pane's accent
+// by its index: panes[i] gets accents[i], and panes past MaxGradientPanes
+// or added later get the last (dimmest) accent, which bounds the format's
+// size.
+func paneAccentFormat(panes, accents []string, fg string) string {
+	// Commas inside a conditional's branches must be escaped as #,
+	style := func(bg string) string { return "#[bg=" + bg + "#,fg=" + fg + "]" }
+	format := style(accents[len(accents)-1])
+	for i := min(len(panes), MaxGradientPanes) - 2; i >= 0; i-- {
+		format = "#{?#{==:#{pane_index}," + panes[i] + "}," + style(accents[i]) + "," + format + "}"
+	}
+	return format + " #{pane_index}: #{pane_title} #[default]"
+}
+
+// paneBorderOptions colors pane borders on tmux v: inactive panes with the
+// inactive color and the active pane with active. The -style options
+// replaced the -fg ones in tmux 1.9.
+func paneBorderOptions(v Version, inactive, active string) []sessionOption {
+	if !v.AtLeast(1, 9) {
+		return []sessionOption{
+			{"pane-border-fg", inactive},
+			{"pane-active-border-fg", active},
+		}
+	}
+	return []sessionOption{
+		{"pane-border-style", "fg=" + inactive},
+		{"pane-active-border-style", "fg=" + active},
+	}
+}
+
+// paneAccentOptions are the window options SetPaneAccents writes on tmux v.
+func paneAccentOptions(v Version) []string {
+	var names []string
+	if v.AtLeast(2, 3) {
+		names = append(names, "pane-border-status", "pane-border-format")
+	}
+	for _, opt := range paneBorderOptions(v, "", "") {
+		names = append(names, opt.name)
+	}
+	return names
+}
+
+// indicatorOptions are the window options written by SetIndicatorStyle.
+var indicatorOptions = []string{
+	"clock-mode-colour",
+	"window-status-activity-style",
+	"window-status-bell-style",
+}
+
+// windowStyleOptions are the window options written by SetWindowStatusStyle.
+var windowStyleOptions = []string{
+	"window-status-style",
+	"window-status-current-style",
+}
+
+// windowTargets returns a "session:index" target for each window in session.
+func (t *Tmux) windowTargets(session string) ([]string, error) {
+	out, err := t.run("list-windows", "-t", session, "-F", "#{window_index}")
+	if err != nil {
+		return nil, err
+	}
+	var targets []string
+	for _, idx := range strings.Split(out, "\n") {
+		if idx != "" {
+			targets = append(targets, session+":"+idx)
+		}
+	}
+	return targets, nil
+}
+
+// setOptionNames returns the names of the options set locally on the
+// show-options target given by scope (e.g. "-t", session or "-w", "-t",
+// window), excluding inherited values. Array options are named without
+// their index.
+func (t *Tmux) setOptionNames(scope ...string) (map[string]bool, error) {
+	out, err := t.run(append([]string{"show-options"}, scope...)...)
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[string]bool)
+	for _, line := range strings.Split(out, "\n") {
+		name, _, _ := strings.Cut(line, " ")
+		if name, _, _ = strings.Cut(name, "["); name != "" {
+			set[name] = true
+		}
+	}
+	return set, nil
+}
+
+// ownedSessionOptionsFor are the session options gt theming may set on
+// tmux v.
+func ownedSessionOptionsFor(v Version) []string {
+	opts := append(append([]string{}, statusOptions...), statusCenterOptions(v)...)
+	return append(opts, titleOptions...)
+}
+
+// ownedSessionOptions are the session options gt theming may set on this
+// tmux.
+func (t *Tmux) ownedSessionOptions() []string {
+	version, _ := t.Version()
+	return ownedSessionOptionsFor(version)
+}
+
+// ownedWindowOptionsFor are the window options gt theming may set on tmux v.
+func ownedWindowOptionsFor(v Version) []string {
+	opts := append(append([]string{}, windowStyleOptions...), indicatorOptions...)
+	opts = append(opts, windowTitleOptions...)
+	opts = append(opts, modeStyleOptions(v)...)
+	opts = append(opts, paneStyleOptions(v)...)
+	return append(opts, paneAccentOptions(v)...)
+}
+
+// ownedWindowOptions are the window options gt theming may set on this
+// tmux.
+func (t *Tmux) ownedWindowOptions() []string {
+	version, _ := t.Version()
+	return ownedWindowOptionsFor(version)
+}
+
+// ThemedOptions returns the gt-owned theme options (status bar, message
+// and window tab styles, indicators, titles, copy-mode, pane accents) currently set on
+// the session or any of its windows, in a stable order. These are what ResetTheme would clear.
+func (t *Tmux) ThemedOptions(session string) ([]string, error) {
+	sessionSet, windowSet, err := t.themedOptions(session)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, opt := range t.ownedSessionOptions() {
+		if sessionSet[opt] {
+			names = append(names, opt)
+		}
+	}
+	for _, opt := range t.ownedWindowOptions() {
+		if windowSet[opt] {
+			names = append(names, opt)
+		}
+	}
+	return names, nil
+}
+
+// themedOptions returns the owned options set on the session, and the union
+// of owned options set on its windows.
+func (t *Tmux) themedOptions(session string) (sessionSet, windowSet map[string]bool, err error) {
+	sessionSet, err = t.setOptionNames("-t", session)
+	if err != nil {
+		return nil, nil, err
+	}
+	targets, err := t.windowTargets(session)
+	if err != nil {
+		return nil, nil, err
+	}
+	windowSet = make(map[string]bool)
+	for _, target := range targets {
+		set, err := t.setOptionNames("-w", "-t", target)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, opt := range t.ownedWindowOptions() {
+			if set[opt] {
+				windowSet[opt] = true
+			}
+		}
+	}
+	return sessionSet, windowSet, nil
+}
+
+// ResetTheme unsets every gt-owned theme option on the session and its
+// windows, returning them to the tmux defaults. It returns the names of
+// the options that were cleared (see ThemedOptions).
+func (t *Tmux) ResetTheme(session string) ([]string, error) {
+	names, err := t.ThemedOptions(session)
+	if err != nil || len(names) == 0 {
+		return nil, err
+	}
+
+	for _, opt := range t.ownedSessionOptions() {
+		if _, err := t.run("set-option", "-u", "-t", session, opt); err != nil {
+			return nil, err
+		}
+	}
+	targets, err := t.windowTargets(session)
+	if err != nil {
+		return nil, err
+	}
+	for _, target := range targets {
+		for _, opt := range t.ownedWindowOptions() {
+			if _, err := t.run("set-option", "-w", "-u", "-t", target, opt); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return names, nil
+}
+
 // roleIcons maps role names to display icons for the status bar.
 // Uses centralized emojis from constants package.
 // Includes legacy keys ("coordinator", "health-check") for backwards compatibility.
@@ -1376,8 +2086,52 @@ var roleIcons = map[string]string{
 }
 
 // SetStatusFormat configures the left side of the status bar.
-// Shows compact identity: icon + minimal context
-func (t *Tmux) SetStatusFormat(session, rig, worker, role string) error {
+// Shows compact identity: icon + minimal context. rig is shown as given,
+// so callers pass the rig's display name (rig.Rig.DisplayName).
+//
+// center is a Theme.StatusCenter token: when set, and tmux is 3.0 or newer
+// (StatusCenterSupported), the status bar also gets a centered segment
+// between the window list and the right side. An empty center removes a
+// segment set earlier.
+func (t *Tmux) SetStatusFormat(session, rig, worker, role, center string) error {
+	for _, opt := range statusFormatOptions(rig, worker, role) {
+		if _, err := t.run("set-option", "-t", session, opt.name, opt.value); err != nil {
+			return err
+		}
+	}
+	version, _ := t.Version() // unknown: assume a current tmux
+	if args := statusCenterArgs(version, session, center, rig, worker, role); args != nil {
+		if _, err := t.run(args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sessionOption is a session option name and value to set.
+type sessionOption struct {
+	name, value string
+}
+
+// statusFormatOptions returns the status-left options for an agent identity.
+func statusFormatOptions(rig, worker, role string) []sessionOption {
+	left := statusLeft(rig, worker, role)
+	return []sessionOption{
+		{"status-left-length", strconv.Itoa(statusLeftLength(left))},
+		{"status-left", left},
+	}
+}
+
+// BuildStatus returns the status-left segment SetStatusFormat gives an
+// agent identity, in the theme's status bar colors, as a tmux format
+// string (e.g. "#[bg=#1e3a5f,fg=#e0e0e0]😺 gastown/Toast #[default]") that
+// can be embedded in another status bar.
+func BuildStatus(rig, worker, role string, theme Theme) string {
+	return "#[" + theme.Style() + "]" + statusLeft(rig, worker, role) + "#[default]"
+}
+
+// statusLeft returns the status-left text for an agent identity.
+func statusLeft(rig, worker, role string) string {
 	// Get icon for role (empty string if not found)
 	icon := roleIcons[role]
 
@@ -1396,44 +2150,259 @@ func (t *Tmux) SetStatusFormat(session, rig, worker, role string) error {
 		// Rig-level agent - show rig/worker
 		left = fmt.Sprintf("%s %s/%s ", icon, rig, worker)
 	}
+	return left
+}
+
+// DefaultStatusLeftLength is the minimum status-left-length gt sets, so
+// short identities keep the width they always had.
+const DefaultStatusLeftLength = 25
+
+// statusLeftLength returns a status-left-length that fits left without
+// clipping: one cell per rune plus one for the double-width role icon,
+// and at least DefaultStatusLeftLength.
+func statusLeftLength(left string) int {
+	return max(utf8.RuneCountInString(left)+1, DefaultStatusLeftLength)
+}
+
+// SetStatusLengths sets the session's status-left-length and
+// status-right-length, the most cells tmux draws of each side. A length
+// of 0 leaves that side's length unchanged.
+func (t *Tmux) SetStatusLengths(session string, left, right int) error {
+	for _, opt := range []struct {
+		name   string
+		length int
+	}{{"status-left-length", left}, {"status-right-length", right}} {
+		if opt.length <= 0 {
+			continue
+		}
+		if _, err := t.run("set-option", "-t", session, opt.name, strconv.Itoa(opt.length)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// statusEnvRe matches ${VAR} tokens in a status template.
+var statusEnvRe = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// ExpandStatusEnv replaces ${VAR} tokens in a status-bar template with values
+// from env. Unknown variables expand to the empty string.
+//
+// Expansion happens in gt at apply time, not in tmux: tmux status formats have
+// no access to session environment, and would execute #(...) if we let it
+// interpret values. Every '#' in an expanded value is therefore escaped to
+// '##' so environment contents render literally and can never inject tmux
+// format sequences or shell commands into the status bar.
+func ExpandStatusEnv(template string, env map[string]string) string {
+	return statusEnvRe.ReplaceAllStringFunc(template, func(tok string) string {
+		name := statusEnvRe.FindStringSubmatch(tok)[1]
+		return strings.ReplaceAll(env[name], "#", "##")
+	})
+}
+
+// SetStatusTemplate sets the left side of the status bar from a custom template.
+// ${VAR} tokens are expanded from the session's tmux environment (GT_RIG,
+// GT_ROLE, etc.) via ExpandStatusEnv; the template text itself is passed to
+// tmux unchanged, so it may use tmux formats like #{session_name}.
+func (t *Tmux) SetStatusTemplate(session, template string) error {
+	env, err := t.GetAllEnvironment(session)
+	if err != nil {
+		return fmt.Errorf("reading session environment: %w", err)
+	}
+
+	left := ExpandStatusEnv(template, env)
+	length := max(len(left), DefaultStatusLeftLength)
+	if _, err := t.run("set-option", "-t", session, "status-left-length", strconv.Itoa(length)); err != nil {
+		return err
+	}
+	_, err = t.run("set-option", "-t", session, "status-left", left)
+	return err
+}
+
+// paneTitleTemplates are the title formats used by SetPaneTitleFormat, by
+// kind of agent. They use the same ${VAR} tokens as status templates and
+// give the same identity as the status bar's left side.
+var paneTitleTemplates = map[string]string{
+	"town":    "${GT_ICON} ${GT_WORKER}",
+	"crew":    "${GT_ICON} ${GT_RIG}/crew/${GT_WORKER}",
+	"default": "${GT_ICON} ${GT_RIG}/${GT_WORKER}",
+}
+
+// paneTitle returns the title for an agent identity.
+func paneTitle(rig, worker, role string) string {
+	kind := "default"
+	if rig == "" {
+		kind = "town"
+	} else if role == "crew" {
+		kind = "crew"
+	}
+	return strings.TrimSpace(ExpandStatusEnv(paneTitleTemplates[kind], map[string]string{
+		"GT_ICON":   roleIcons[role],
+		"GT_RIG":    rig,
+		"GT_WORKER": worker,
+		"GT_ROLE":   role,
+	}))
+}
 
-	if _, err := t.run("set-option", "-t", session, "status-left-length", "25"); err != nil {
+// titleOptions are the session options written by SetPaneTitleFormat.
+var titleOptions = []string{
+	"set-titles",
+	"set-titles-string",
+}
+
+// windowTitleOptions are the window options written by SetPaneTitleFormat.
+var windowTitleOptions = []string{
+	"automatic-rename-format",
+}
+
+// SetPaneTitleFormat names a session's windows and the attached terminal's
+// title after the agent identity (e.g. "😺 gastown/Toast"), matching the
+// status bar. The terminal title (set-titles-string) shows in terminal tabs;
+// automatic-rename-format names every window whose automatic-rename is on,
+// which is the default for windows gt creates.
+func (t *Tmux) SetPaneTitleFormat(session, rig, worker, role string) error {
+	title := paneTitle(rig, worker, role)
+	if _, err := t.run("set-option", "-t", session, "set-titles", "on"); err != nil {
+		return err
+	}
+	if _, err := t.run("set-option", "-t", session, "set-titles-string", title); err != nil {
 		return err
 	}
-	_, err := t.run("set-option", "-t", session, "status-left", left)
+	targets, err := t.windowTargets(session)
+	if err != nil {
+		return err
+	}
+	for _, target := range targets {
+		if _, err := t.run("set-option", "-w", "-t", target, "automatic-rename-format", title); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SelectLayout arranges the panes of a session's current window with a
+// tmux layout: a preset name (e.g. "main-vertical") or a #{window_layout}
+// string, which tmux rejects if the window's pane count doesn't match.
+func (t *Tmux) SelectLayout(session, layout string) error {
+	_, err := t.run("select-layout", "-t", "="+session+":", layout)
+	return err
+}
+
+// DefaultStatusInterval is the status-interval, in seconds, set with the
+// dynamic status; SetStatusInterval overrides it per session.
+const DefaultStatusInterval = 5
+
+// SetStatusInterval sets how often, in seconds, tmux redraws the session's
+// status bar and re-runs its dynamic status command.
+func (t *Tmux) SetStatusInterval(session string, seconds int) error {
+	if seconds <= 0 {
+		return fmt.Errorf("invalid status interval %d: must be a positive number of seconds", seconds)
+	}
+	_, err := t.run("set-option", "-t", session, "status-interval", strconv.Itoa(seconds))
 	return err
 }
 
 // SetDynamicStatus configures the right side with dynamic content.
 // Uses a shell command that tmux calls periodically to get current status.
 func (t *Tmux) SetDynamicStatus(session string) error {
+	opts, err := dynamicStatusOptions(session)
+	if err != nil {
+		return err
+	}
+	for _, opt := range opts {
+		if _, err := t.run("set-option", "-t", session, opt.name, opt.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dynamicStatusOptions returns the status-right options for a session.
+func dynamicStatusOptions(session string) ([]sessionOption, error) {
 	// Validate session name to prevent shell injection
 	if !validSessionNameRe.MatchString(session) {
-		return fmt.Errorf("invalid session name %q: must match %s", session, validSessionNameRe.String())
+		return nil, fmt.Errorf("invalid session name %q: must match %s", session, validSessionNameRe.String())
 	}
 
 	// tmux calls this command every status-interval seconds
 	// gt status-line reads env vars and mail to build the status
 	right := fmt.Sprintf(`#(gt status-line --session=%s 2>/dev/null) %%H:%%M`, session)
 
-	if _, err := t.run("set-option", "-t", session, "status-right-length", "80"); err != nil {
+	return []sessionOption{
+		{"status-right-length", "80"},
+		// Set faster refresh for more responsive status
+		{"status-interval", strconv.Itoa(DefaultStatusInterval)},
+		{"status-right", right},
+	}, nil
+}
+
+// ApplyAll themes a session in a single tmux invocation: the theme colors
+// and message styles (ApplyTheme, SetMessageStyle), the identity and
+// centered segments (SetStatusFormat) and the dynamic right side (SetDynamicStatus) are sent
+// as one chain of set-option commands instead of one process per option.
+// Like ConfigureGasTownSession it works on detached sessions.
+func (t *Tmux) ApplyAll(session string, theme Theme, rig, worker, role string) error {
+	args, err := applyAllArgs(session, theme, rig, worker, role)
+	if err != nil {
 		return err
 	}
-	// Set faster refresh for more responsive status
-	if _, err := t.run("set-option", "-t", session, "status-interval", "5"); err != nil {
-		return err
+	version, _ := t.Version() // unknown: assume a current tmux
+	if center := statusCenterArgs(version, session, theme.StatusCenter, rig, worker, role); center != nil {
+		args = append(append(args, ";"), center...)
 	}
-	_, err := t.run("set-option", "-t", session, "status-right", right)
+	_, err = t.run(args...)
 	return err
 }
 
+// applyAllArgs builds the chained tmux command line used by ApplyAll.
+func applyAllArgs(session string, theme Theme, rig, worker, role string) ([]string, error) {
+	dynamic, err := dynamicStatusOptions(session)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []sessionOption{
+		{"status-style", theme.Style()},
+		{"message-style", theme.MessageStyle()},
+		{"message-command-style", theme.MessageCommandStyle()},
+	}
+	if theme.StatusPosition != "" {
+		opts = append(opts, sessionOption{"status-position", theme.StatusPosition})
+	}
+	opts = append(opts, statusFormatOptions(rig, worker, role)...)
+	opts = append(opts, dynamic...)
+
+	var args []string
+	for i, opt := range opts {
+		if i > 0 {
+			args = append(args, ";")
+		}
+		args = append(args, "set-option", "-t", session, opt.name, escapeChainArg(opt.value))
+	}
+	return args, nil
+}
+
+// escapeChainArg escapes a trailing ';', which tmux would otherwise treat
+// as a command separator when commands are chained in one invocation.
+func escapeChainArg(arg string) string {
+	if strings.HasSuffix(arg, ";") {
+		return strings.TrimSuffix(arg, ";") + `\;`
+	}
+	return arg
+}
+
 // ConfigureGasTownSession applies full Gas Town theming to a session.
 // This is a convenience method that applies theme, status format, and dynamic status.
+//
+// Everything here is a session option or key binding, so it works the same
+// on a detached session: tmux stores the options and draws them when a
+// client attaches, and the status-right job first runs on that draw. No
+// step needs to be deferred to a client-attached hook.
 func (t *Tmux) ConfigureGasTownSession(session string, theme Theme, rig, worker, role string) error {
 	if err := t.ApplyTheme(session, theme); err != nil {
 		return fmt.Errorf("applying theme: %w", err)
 	}
-	if err := t.SetStatusFormat(session, rig, worker, role); err != nil {
+	if err := t.SetStatusFormat(session, rig, worker, role, theme.StatusCenter); err != nil {
 		return fmt.Errorf("setting status format: %w", err)
 	}
 	if err := t.SetDynamicStatus(session); err != nil {
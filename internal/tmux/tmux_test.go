@@ -1,12 +1,19 @@
 package tmux
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
 )
 
 func hasTmux() bool {
@@ -212,6 +219,11 @@ func TestWrapError(t *testing.T) {
 			t.Errorf("wrapError(%q) = %v, want %v", tt.stderr, err, tt.want)
 		}
 	}
+
+	missing := &exec.Error{Name: "tmux", Err: exec.ErrNotFound}
+	if err := tm.wrapError(missing, "", []string{"test"}); !errors.Is(err, ErrNotInstalled) {
+		t.Errorf("wrapError(binary missing) = %v, want ErrNotInstalled", err)
+	}
 }
 
 func TestEnsureSessionFresh_NoExistingSession(t *testing.T) {
@@ -1081,3 +1093,993 @@ func TestKillPaneProcessesExcluding_FiltersPIDs(t *testing.T) {
 		}
 	}
 }
+
+func TestExpandStatusEnv(t *testing.T) {
+	env := map[string]string{
+		"GT_RIG":     "gastown",
+		"GT_CLUSTER": "prod-east",
+		"EVIL":       "#(rm -rf ~)",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"single var", "${GT_RIG} ", "gastown "},
+		{"multiple vars", "${GT_RIG}@${GT_CLUSTER}", "gastown@prod-east"},
+		{"unknown var is empty", "[${GT_MISSING}]", "[]"},
+		{"tmux formats untouched", "#{session_name} ${GT_RIG}", "#{session_name} gastown"},
+		{"bare dollar untouched", "$GT_RIG ${", "$GT_RIG ${"},
+		{"values cannot inject formats", "${EVIL}", "##(rm -rf ~)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExpandStatusEnv(tt.template, env); got != tt.want {
+				t.Errorf("ExpandStatusEnv(%q) = %q, want %q", tt.template, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetWindowStatusStyle(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	tm := NewTmux()
+	sessionName := "gt-test-winstyle-" + t.Name()
+	_ = tm.KillSession(sessionName)
+	if err := tm.NewSession(sessionName, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+	if _, err := tm.run("new-window", "-d", "-t", sessionName); err != nil {
+		t.Fatalf("new-window: %v", err)
+	}
+
+	theme := Theme{Name: "test", BG: "#1e3a5f", FG: "#e0e0e0"}
+	// Applying twice must be harmless
+	for i := 0; i < 2; i++ {
+		if err := tm.SetWindowStatusStyle(sessionName, theme); err != nil {
+			t.Fatalf("SetWindowStatusStyle: %v", err)
+		}
+	}
+
+	out, err := tm.run("list-windows", "-t", sessionName, "-F", "#{window-status-current-style}")
+	if err != nil {
+		t.Fatalf("list-windows: %v", err)
+	}
+	lines := strings.Split(out, "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 windows, got %q", out)
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, "#1e3a5f") {
+			t.Errorf("window-status-current-style = %q, want theme colors", line)
+		}
+	}
+}
+
+func TestSetIndicatorStyle(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	tm := NewTmux()
+	sessionName := "gt-test-indicators-" + t.Name()
+	_ = tm.KillSession(sessionName)
+	if err := tm.NewSession(sessionName, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+	if _, err := tm.run("new-window", "-d", "-t", sessionName); err != nil {
+		t.Fatalf("new-window: %v", err)
+	}
+
+	theme := Theme{Name: "test", BG: "#1e3a5f", FG: "#e0e0e0"}
+	if err := tm.SetIndicatorStyle(sessionName, theme); err != nil {
+		t.Fatalf("SetIndicatorStyle: %v", err)
+	}
+	out, err := tm.run("list-windows", "-t", sessionName, "-F", "#{clock-mode-colour}|#{window-status-bell-style}")
+	if err != nil {
+		t.Fatalf("list-windows: %v", err)
+	}
+	lines := strings.Split(out, "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 windows, got %q", out)
+	}
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "#1e3a5f|") || !strings.Contains(line, "blink") {
+			t.Errorf("window indicators = %q, want theme colors", line)
+		}
+	}
+
+	got, err := tm.ResetTheme(sessionName)
+	if err != nil {
+		t.Fatalf("ResetTheme: %v", err)
+	}
+	if strings.Join(got, " ") != strings.Join(indicatorOptions, " ") {
+		t.Errorf("ResetTheme() = %v, want %v", got, indicatorOptions)
+	}
+}
+
+func TestSetModeStyle(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	tm := NewTmux()
+	sessionName := "gt-test-modestyle-" + t.Name()
+	_ = tm.KillSession(sessionName)
+	if err := tm.NewSession(sessionName, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+	if _, err := tm.run("new-window", "-d", "-t", sessionName); err != nil {
+		t.Fatalf("new-window: %v", err)
+	}
+
+	theme := Theme{Name: "test", BG: "#1e3a5f", FG: "#e0e0e0"}
+	if err := tm.SetModeStyle(sessionName, theme); err != nil {
+		t.Fatalf("SetModeStyle: %v", err)
+	}
+	for _, target := range []string{sessionName + ":0", sessionName + ":1"} {
+		got, err := tm.run("show-options", "-w", "-v", "-t", target, "mode-style")
+		if err != nil {
+			t.Fatalf("show-options: %v", err)
+		}
+		if got != "bg=#1e3a5f,fg=#e0e0e0" {
+			t.Errorf("%s mode-style = %q, want theme colors", target, got)
+		}
+	}
+
+	got, err := tm.ResetTheme(sessionName)
+	if err != nil {
+		t.Fatalf("ResetTheme: %v", err)
+	}
+	if strings.Join(got, " ") != "mode-style" {
+		t.Errorf("ResetTheme() = %v, want [mode-style]", got)
+	}
+}
+
+func TestSetWindowStyles(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	tm := NewTmux()
+	sessionName := "gt-test-panestyle-" + t.Name()
+	_ = tm.KillSession(sessionName)
+	if err := tm.NewSession(sessionName, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+	if _, err := tm.run("new-window", "-d", "-t", sessionName); err != nil {
+		t.Fatalf("new-window: %v", err)
+	}
+
+	theme := Theme{Name: "test", BG: "#1e3a5f", FG: "#e0e0e0"}
+	if err := tm.SetWindowStyles(sessionName, theme); err != nil {
+		t.Fatalf("SetWindowStyles: %v", err)
+	}
+	for _, target := range []string{sessionName + ":0", sessionName + ":1"} {
+		got, err := tm.run("show-options", "-w", "-v", "-t", target, "window-style")
+		if err != nil {
+			t.Fatalf("show-options: %v", err)
+		}
+		if got != theme.InactivePaneStyle() {
+			t.Errorf("%s window-style = %q, want %q", target, got, theme.InactivePaneStyle())
+		}
+	}
+
+	got, err := tm.ResetTheme(sessionName)
+	if err != nil {
+		t.Fatalf("ResetTheme: %v", err)
+	}
+	if strings.Join(got, " ") != "window-style window-active-style" {
+		t.Errorf("ResetTheme() = %v, want [window-style window-active-style]", got)
+	}
+}
+
+func TestSnapshotRestoreStatus(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	tm := NewTmux()
+	sessionName := "gt-test-snapshot-" + t.Name()
+	_ = tm.KillSession(sessionName)
+	if err := tm.NewSession(sessionName, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+
+	if _, err := tm.run("set-option", "-t", sessionName, "status-left", "before #S"); err != nil {
+		t.Fatalf("set status-left: %v", err)
+	}
+	snap, err := tm.SnapshotStatus(sessionName)
+	if err != nil {
+		t.Fatalf("SnapshotStatus: %v", err)
+	}
+	if _, ok := snap["status-style"]; ok {
+		t.Errorf("status-style was inherited, should not be in snapshot: %v", snap)
+	}
+
+	if err := tm.ApplyTheme(sessionName, Theme{BG: "#1e3a5f", FG: "#e0e0e0"}); err != nil {
+		t.Fatalf("ApplyTheme: %v", err)
+	}
+	if err := tm.SetStatusFormat(sessionName, "gastown", "Toast", "polecat", ""); err != nil {
+		t.Fatalf("SetStatusFormat: %v", err)
+	}
+
+	if err := tm.RestoreStatus(sessionName, snap); err != nil {
+		t.Fatalf("RestoreStatus: %v", err)
+	}
+	if got, _ := tm.run("show-options", "-v", "-t", sessionName, "status-left"); got != "before #S" {
+		t.Errorf("status-left after restore = %q, want %q", got, "before #S")
+	}
+	if got, _ := tm.run("show-options", "-t", sessionName); strings.Contains(got, "status-style") {
+		t.Errorf("status-style should be unset after restore, options:\n%s", got)
+	}
+}
+
+func TestSetMessageStyle(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	tm := NewTmux()
+	sessionName := "gt-test-msgstyle-" + t.Name()
+	_ = tm.KillSession(sessionName)
+	if err := tm.NewSession(sessionName, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+
+	snap, err := tm.SnapshotStatus(sessionName)
+	if err != nil {
+		t.Fatalf("SnapshotStatus: %v", err)
+	}
+
+	theme := Theme{Name: "test", BG: "#1e3a5f", FG: "#e0e0e0"}
+	// Applying twice must be harmless
+	for i := 0; i < 2; i++ {
+		if err := tm.SetMessageStyle(sessionName, theme); err != nil {
+			t.Fatalf("SetMessageStyle: %v", err)
+		}
+	}
+	if got, _ := tm.run("show-options", "-v", "-t", sessionName, "message-style"); got != theme.MessageStyle() {
+		t.Errorf("message-style = %q, want %q", got, theme.MessageStyle())
+	}
+	if got, _ := tm.run("show-options", "-v", "-t", sessionName, "message-command-style"); got != theme.MessageCommandStyle() {
+		t.Errorf("message-command-style = %q, want %q", got, theme.MessageCommandStyle())
+	}
+
+	if err := tm.RestoreStatus(sessionName, snap); err != nil {
+		t.Fatalf("RestoreStatus: %v", err)
+	}
+	if got, _ := tm.run("show-options", "-t", sessionName); strings.Contains(got, "message-") {
+		t.Errorf("message styles should be unset after restore, options:\n%s", got)
+	}
+}
+
+func TestResetTheme(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	tm := NewTmux()
+	sessionName := "gt-test-reset-" + t.Name()
+	_ = tm.KillSession(sessionName)
+	if err := tm.NewSession(sessionName, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+
+	if got, err := tm.ResetTheme(sessionName); err != nil || len(got) != 0 {
+		t.Fatalf("ResetTheme(unthemed) = %v, %v; want nothing reset", got, err)
+	}
+
+	theme := Theme{Name: "test", BG: "#1e3a5f", FG: "#e0e0e0"}
+	if err := tm.ApplyTheme(sessionName, theme); err != nil {
+		t.Fatalf("ApplyTheme: %v", err)
+	}
+	if err := tm.SetMessageStyle(sessionName, theme); err != nil {
+		t.Fatalf("SetMessageStyle: %v", err)
+	}
+	if err := tm.SetWindowStatusStyle(sessionName, theme); err != nil {
+		t.Fatalf("SetWindowStatusStyle: %v", err)
+	}
+	// Options gt doesn't own must survive the reset.
+	if _, err := tm.run("set-option", "-t", sessionName, "mouse", "on"); err != nil {
+		t.Fatalf("set mouse: %v", err)
+	}
+
+	got, err := tm.ResetTheme(sessionName)
+	if err != nil {
+		t.Fatalf("ResetTheme: %v", err)
+	}
+	want := "status-style message-style message-command-style window-status-style window-status-current-style"
+	if strings.Join(got, " ") != want {
+		t.Errorf("ResetTheme() = %v, want %s", got, want)
+	}
+
+	if left, _ := tm.ThemedOptions(sessionName); len(left) != 0 {
+		t.Errorf("ThemedOptions after reset = %v, want none", left)
+	}
+	if got, _ := tm.run("show-options", "-v", "-t", sessionName, "mouse"); got != "on" {
+		t.Errorf("mouse = %q after reset, want on", got)
+	}
+}
+
+func TestApplyAllArgs(t *testing.T) {
+	theme := Theme{Name: "test", BG: "#1e3a5f", FG: "#e0e0e0;"}
+	args, err := applyAllArgs("gt-gastown-Toast", theme, "gastown", "Toast", "polecat")
+	if err != nil {
+		t.Fatalf("applyAllArgs: %v", err)
+	}
+
+	// One chained command line: set-option commands separated by lone ";".
+	var names []string
+	for i := 0; i < len(args); i += 6 {
+		if args[i] != "set-option" || args[i+1] != "-t" || args[i+2] != "gt-gastown-Toast" {
+			t.Fatalf("command at %d = %v, want set-option -t gt-gastown-Toast ...", i, args[i:i+5])
+		}
+		names = append(names, args[i+3])
+		if i+5 < len(args) && args[i+5] != ";" {
+			t.Fatalf("separator at %d = %q, want \";\"", i+5, args[i+5])
+		}
+	}
+	want := "status-style message-style message-command-style status-left-length status-left status-right-length status-interval status-right"
+	if strings.Join(names, " ") != want {
+		t.Errorf("options = %v, want %s", names, want)
+	}
+	// A trailing ';' in a value must not split the chain.
+	if args[4] != `bg=#1e3a5f,fg=#e0e0e0\;` {
+		t.Errorf("status-style arg = %q, want trailing ';' escaped", args[4])
+	}
+
+	if _, err := applyAllArgs("bad name;rm", theme, "gastown", "Toast", "polecat"); err == nil {
+		t.Error("applyAllArgs accepted an invalid session name")
+	}
+}
+
+func TestBuildStatus(t *testing.T) {
+	theme := Theme{Name: "ocean", BG: "#1e3a5f", FG: "#e0e0e0"}
+	tests := []struct {
+		rig, worker, role string
+		want              string
+	}{
+		{"gastown", "Toast", "polecat", "#[bg=#1e3a5f,fg=#e0e0e0]😺 gastown/Toast #[default]"},
+		{"gastown", "max", "crew", "#[bg=#1e3a5f,fg=#e0e0e0]👷 gastown/crew/max #[default]"},
+		{"", "Mayor", "coordinator", "#[bg=#1e3a5f,fg=#e0e0e0]🎩 Mayor #[default]"},
+	}
+	for _, tt := range tests {
+		if got := BuildStatus(tt.rig, tt.worker, tt.role, theme); got != tt.want {
+			t.Errorf("BuildStatus(%s/%s) = %q, want %q", tt.rig, tt.worker, got, tt.want)
+		}
+	}
+}
+
+func TestStatusLeftLength(t *testing.T) {
+	tests := []struct {
+		rig, worker, role string
+		want              int
+	}{
+		{"gastown", "Toast", "polecat", DefaultStatusLeftLength},
+		{"", "Mayor", "coordinator", DefaultStatusLeftLength},
+		// "👷 gastown/crew/maximilian-the-great ": 36 runes, icon 2 cells wide
+		{"gastown", "maximilian-the-great", "crew", 37},
+	}
+	for _, tt := range tests {
+		var got string
+		for _, opt := range statusFormatOptions(tt.rig, tt.worker, tt.role) {
+			if opt.name == "status-left-length" {
+				got = opt.value
+			}
+		}
+		if got != strconv.Itoa(tt.want) {
+			t.Errorf("statusFormatOptions(%s/%s) status-left-length = %s, want %d", tt.rig, tt.worker, got, tt.want)
+		}
+	}
+}
+
+func TestApplyAllArgsStatusPosition(t *testing.T) {
+	theme := Theme{Name: "test", BG: "#1e3a5f", FG: "#e0e0e0"}
+	args, err := applyAllArgs("gt-gastown-Toast", theme, "gastown", "Toast", "polecat")
+	if err != nil {
+		t.Fatalf("applyAllArgs: %v", err)
+	}
+	if slices.Contains(args, "status-position") {
+		t.Errorf("status-position set without a preference: %v", args)
+	}
+
+	theme.StatusPosition = "top"
+	args, err = applyAllArgs("gt-gastown-Toast", theme, "gastown", "Toast", "polecat")
+	if err != nil {
+		t.Fatalf("applyAllArgs: %v", err)
+	}
+	i := slices.Index(args, "status-position")
+	if i < 0 || args[i+1] != "top" {
+		t.Errorf("args = %v, want status-position top", args)
+	}
+}
+
+func TestApplyAllMatchesSeparateCalls(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	tm := NewTmux()
+	batched, separate := "gt-test-batched", "gt-test-separate"
+	for _, name := range []string{batched, separate} {
+		_ = tm.KillSession(name)
+		if err := tm.NewSession(name, ""); err != nil {
+			t.Fatalf("NewSession(%s): %v", name, err)
+		}
+		defer func(name string) { _ = tm.KillSession(name) }(name)
+	}
+
+	theme := Theme{Name: "test", BG: "#1e3a5f", FG: "#e0e0e0"}
+	if err := tm.ApplyAll(batched, theme, "gastown", "Toast", "polecat"); err != nil {
+		t.Fatalf("ApplyAll: %v", err)
+	}
+	if err := tm.ApplyTheme(separate, theme); err != nil {
+		t.Fatal(err)
+	}
+	if err := tm.SetMessageStyle(separate, theme); err != nil {
+		t.Fatal(err)
+	}
+	if err := tm.SetStatusFormat(separate, "gastown", "Toast", "polecat", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := tm.SetDynamicStatus(separate); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, opt := range statusOptions {
+		got, _ := tm.run("show-options", "-v", "-t", batched, opt)
+		want, _ := tm.run("show-options", "-v", "-t", separate, opt)
+		want = strings.ReplaceAll(want, separate, batched) // status-right embeds the session name
+		if got != want {
+			t.Errorf("%s = %q, want %q", opt, got, want)
+		}
+	}
+}
+
+func TestKillSessionMissing(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	tm := NewTmux()
+	if err := tm.KillSession("gt-test-nonexistent-xyz"); !errors.Is(err, ErrNoSession) {
+		t.Errorf("KillSession(missing) = %v, want ErrNoSession", err)
+	}
+}
+
+func TestKillSessionsByPrefix(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	tm := NewTmux()
+	prefix := "gt-test-killprefix-"
+	keep := "gt-test-killprefi" // shares a shorter prefix; must survive
+	for _, name := range []string{prefix + "a", prefix + "b", keep} {
+		_ = tm.KillSession(name)
+		if err := tm.NewSession(name, ""); err != nil {
+			t.Fatalf("NewSession(%s): %v", name, err)
+		}
+	}
+	defer func() { _ = tm.KillSession(keep) }()
+
+	killed, err := tm.KillSessionsByPrefix(prefix)
+	if err != nil {
+		t.Fatalf("KillSessionsByPrefix: %v", err)
+	}
+	if len(killed) != 2 {
+		t.Errorf("killed = %v, want 2 sessions", killed)
+	}
+	if has, _ := tm.HasSession(keep); !has {
+		t.Errorf("session %s should not have been killed", keep)
+	}
+
+	if _, err := tm.KillSessionsByPrefix(""); err == nil {
+		t.Error("empty prefix should be rejected")
+	}
+}
+
+func TestKillSessionExactMatch(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	tm := NewTmux()
+	long := "gt-test-exactkill-long"
+	_ = tm.KillSession(long)
+	if err := tm.NewSession(long, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(long) }()
+
+	if err := tm.KillSession("gt-test-exactkill"); !errors.Is(err, ErrNoSession) {
+		t.Errorf("KillSession(prefix) = %v, want ErrNoSession", err)
+	}
+	if has, _ := tm.HasSession(long); !has {
+		t.Error("prefix kill must not match a longer session name")
+	}
+}
+
+func TestSessionActivity(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	tm := NewTmux()
+	sessionName := "gt-test-activity-" + t.Name()
+	_ = tm.KillSession(sessionName)
+	if err := tm.NewSession(sessionName, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+
+	last, err := tm.SessionActivity(sessionName)
+	if err != nil {
+		t.Fatalf("SessionActivity: %v", err)
+	}
+	if since := time.Since(last); since < 0 || since > time.Minute {
+		t.Errorf("SessionActivity = %v, want within the last minute", last)
+	}
+
+	if _, err := tm.SessionActivity(sessionName + "-missing"); err == nil {
+		t.Error("expected error for missing session")
+	}
+}
+
+func TestCurrentTheme(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	tm := NewTmux()
+	sessionName := "gt-test-curtheme-" + t.Name()
+	_ = tm.KillSession(sessionName)
+	if err := tm.NewSession(sessionName, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+
+	cur, err := tm.CurrentTheme(sessionName)
+	if err != nil {
+		t.Fatalf("CurrentTheme (unthemed): %v", err)
+	}
+	if cur != (Theme{}) {
+		t.Errorf("unthemed session CurrentTheme = %+v, want zero", cur)
+	}
+
+	theme := Theme{Name: "ocean", BG: "#1e3a5f", FG: "#e0e0e0"}
+	if err := tm.ApplyTheme(sessionName, theme); err != nil {
+		t.Fatalf("ApplyTheme: %v", err)
+	}
+	cur, err = tm.CurrentTheme(sessionName)
+	if err != nil {
+		t.Fatalf("CurrentTheme: %v", err)
+	}
+	if !cur.Equal(theme) {
+		t.Errorf("CurrentTheme = %+v, want %+v", cur, theme)
+	}
+}
+
+func TestSocketArgs(t *testing.T) {
+	tests := []struct {
+		socket string
+		want   []string
+	}{
+		{"", nil},
+		{"  ", nil},
+		{"gt-remote", []string{"-L", "gt-remote"}},
+		{"/tmp/tmux-fwd.sock", []string{"-S", "/tmp/tmux-fwd.sock"}},
+	}
+	for _, tt := range tests {
+		got := NewTmuxWithSocket(tt.socket).socketArgs()
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("socketArgs(%q) = %v, want %v", tt.socket, got, tt.want)
+		}
+	}
+}
+
+func TestNewTmuxUsesSocketEnv(t *testing.T) {
+	t.Setenv(SocketEnv, "gt-env-sock")
+	if got := NewTmux().Socket(); got != "gt-env-sock" {
+		t.Errorf("Socket() = %q, want %q", got, "gt-env-sock")
+	}
+}
+
+func TestCheckServerSocket(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	missing := NewTmuxWithSocket(filepath.Join(t.TempDir(), "missing.sock"))
+	if err := missing.CheckServer(); err == nil {
+		t.Error("CheckServer() on missing socket path succeeded, want error")
+	}
+
+	tm := NewTmuxWithSocket("gt-test-socket-" + strconv.Itoa(os.Getpid()))
+	if err := tm.CheckServer(); !errors.Is(err, ErrNoServer) {
+		t.Errorf("CheckServer() before start = %v, want ErrNoServer", err)
+	}
+
+	session := "gt-test-socket"
+	if err := tm.NewSession(session, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _, _ = tm.run("kill-server") }()
+
+	if err := tm.CheckServer(); err != nil {
+		t.Errorf("CheckServer() = %v, want nil", err)
+	}
+	// The session lives only on the dedicated server.
+	if has, _ := NewTmuxWithSocket("").HasSession(session); has {
+		t.Errorf("session %s visible on the default server", session)
+	}
+}
+
+// fakeTmuxExec fails with each stderr in failures in turn, then succeeds.
+func fakeTmuxExec(calls *int, failures ...string) func(context.Context, []string) (string, string, error) {
+	return func(context.Context, []string) (string, string, error) {
+		*calls++
+		if *calls <= len(failures) {
+			return "", failures[*calls-1], errors.New("exit status 1")
+		}
+		return "ok\n", "", nil
+	}
+}
+
+func TestRunRetriesTransientErrors(t *testing.T) {
+	calls := 0
+	tm := NewTmux()
+	tm.SetRetryPolicy(RetryPolicy{Attempts: 3, Delay: time.Millisecond})
+	tm.exec = fakeTmuxExec(&calls, "server exited unexpectedly", "lost server")
+
+	out, err := tm.run("list-sessions")
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if out != "ok" || calls != 3 {
+		t.Errorf("run = %q after %d calls, want ok after 3", out, calls)
+	}
+}
+
+func TestRunGivesUpAfterAttempts(t *testing.T) {
+	calls := 0
+	tm := NewTmux()
+	tm.SetRetryPolicy(RetryPolicy{Attempts: 2, Delay: time.Millisecond})
+	tm.exec = fakeTmuxExec(&calls, "server not ready", "server not ready", "server not ready")
+
+	_, err := tm.run("list-sessions")
+	if !errors.Is(err, ErrServerBusy) {
+		t.Errorf("run error = %v, want ErrServerBusy", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestRunDoesNotRetryPermanentErrors(t *testing.T) {
+	tests := []struct {
+		stderr string
+		want   error
+	}{
+		{"can't find session: gt-nope", ErrSessionNotFound},
+		{"no server running on /tmp/tmux-0/default", ErrNoServer},
+		{"invalid option: status-bogus", nil},
+	}
+	for _, tt := range tests {
+		calls := 0
+		tm := NewTmux()
+		tm.SetRetryPolicy(RetryPolicy{Attempts: 5, Delay: time.Millisecond})
+		tm.exec = fakeTmuxExec(&calls, tt.stderr)
+
+		_, err := tm.run("set-option", "-t", "gt-nope", "status-bogus", "x")
+		if err == nil || errors.Is(err, ErrServerBusy) {
+			t.Errorf("%q: error = %v, want a permanent error", tt.stderr, err)
+		}
+		if tt.want != nil && !errors.Is(err, tt.want) {
+			t.Errorf("%q: error = %v, want %v", tt.stderr, err, tt.want)
+		}
+		if calls != 1 {
+			t.Errorf("%q: calls = %d, want 1 (no retry)", tt.stderr, calls)
+		}
+	}
+}
+
+// blockingTmuxExec simulates a wedged server: it never answers until ctx
+// is done.
+func blockingTmuxExec(calls *int) func(context.Context, []string) (string, string, error) {
+	return func(ctx context.Context, _ []string) (string, string, error) {
+		*calls++
+		<-ctx.Done()
+		return "", "", ctx.Err()
+	}
+}
+
+func TestRunTimesOut(t *testing.T) {
+	calls := 0
+	tm := NewTmux()
+	tm.SetTimeout(20 * time.Millisecond)
+	tm.exec = blockingTmuxExec(&calls)
+
+	start := time.Now()
+	_, err := tm.run("list-sessions")
+	if !errors.Is(err, ErrTmuxTimeout) {
+		t.Fatalf("run error = %v, want ErrTmuxTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("run took %s, want it to give up after the timeout", elapsed)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1 (timeouts are not retried)", calls)
+	}
+
+	// Callers see the timeout through the wrappers too
+	tm.exec = blockingTmuxExec(&calls)
+	if _, err := tm.ListSessions(); !errors.Is(err, ErrTmuxTimeout) {
+		t.Errorf("ListSessions error = %v, want ErrTmuxTimeout", err)
+	}
+	if err := tm.ApplyTheme("gt-wedged", Theme{BG: "red", FG: "white"}); !errors.Is(err, ErrTmuxTimeout) {
+		t.Errorf("ApplyTheme error = %v, want ErrTmuxTimeout", err)
+	}
+}
+
+func TestRunTimeoutExemptions(t *testing.T) {
+	tm := NewTmux()
+	tm.SetTimeout(10 * time.Millisecond)
+	var sawDeadline bool
+	tm.exec = func(ctx context.Context, _ []string) (string, string, error) {
+		_, sawDeadline = ctx.Deadline()
+		return "", "", nil
+	}
+	if _, err := tm.run("attach-session", "-t", "gt-x"); err != nil {
+		t.Fatal(err)
+	}
+	if sawDeadline {
+		t.Error("attach-session got a deadline, want no timeout")
+	}
+
+	tm.SetTimeout(0)
+	if _, err := tm.run("list-sessions"); err != nil {
+		t.Fatal(err)
+	}
+	if sawDeadline {
+		t.Error("SetTimeout(0) still set a deadline")
+	}
+}
+
+func TestTimeoutFromEnv(t *testing.T) {
+	tests := map[string]time.Duration{
+		"":      DefaultTimeout,
+		"10s":   10 * time.Second,
+		"0":     0,
+		"bogus": DefaultTimeout,
+		"-1s":   DefaultTimeout,
+	}
+	for v, want := range tests {
+		t.Setenv(TimeoutEnv, v)
+		if got := timeoutFromEnv(); got != want {
+			t.Errorf("%s=%q: timeout = %s, want %s", TimeoutEnv, v, got, want)
+		}
+	}
+}
+
+func TestConfigureGasTownSessionDetached(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	tm := NewTmux()
+	sessionName := "gt-test-detached"
+	_ = tm.KillSession(sessionName)
+	if err := tm.NewSession(sessionName, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+	if tm.IsSessionAttached(sessionName) {
+		t.Skip("session unexpectedly attached")
+	}
+
+	theme := Theme{Name: "test", BG: "#1e3a5f", FG: "#e0e0e0", StatusPosition: "top"}
+	if err := tm.ConfigureGasTownSession(sessionName, theme, "gastown", "Toast", "polecat"); err != nil {
+		t.Fatalf("ConfigureGasTownSession on detached session: %v", err)
+	}
+
+	// Every status option is set on the session itself, ready for the next attach
+	snap, err := tm.SnapshotStatus(sessionName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, opt := range statusOptions {
+		if _, ok := snap[opt]; !ok && opt != "message-style" && opt != "message-command-style" {
+			t.Errorf("%s not set on detached session", opt)
+		}
+	}
+	if cur, err := tm.CurrentTheme(sessionName); err != nil || !cur.Equal(theme) {
+		t.Errorf("CurrentTheme = %+v, %v; want %+v", cur, err, theme)
+	}
+}
+
+func TestSetStatusInterval(t *testing.T) {
+	var got []string
+	tm := NewTmux()
+	tm.exec = func(_ context.Context, args []string) (string, string, error) {
+		got = args
+		return "", "", nil
+	}
+	if err := tm.SetStatusInterval("gt-gastown-Toast", 15); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"set-option", "-t", "gt-gastown-Toast", "status-interval", "15"}; !slices.Equal(got, want) {
+		t.Errorf("args = %q, want %q", got, want)
+	}
+	for _, bad := range []int{0, -5} {
+		if err := tm.SetStatusInterval("gt-gastown-Toast", bad); err == nil {
+			t.Errorf("SetStatusInterval(%d) = nil, want error", bad)
+		}
+	}
+}
+
+func TestPaneTitle(t *testing.T) {
+	tests := []struct {
+		rig, worker, role, want string
+	}{
+		{"gastown", "Toast", "polecat", constants.EmojiPolecat + " gastown/Toast"},
+		{"gastown", "max", "crew", constants.EmojiCrew + " gastown/crew/max"},
+		{"gastown", "witness", "witness", constants.EmojiWitness + " gastown/witness"},
+		{"", "Mayor", "mayor", constants.EmojiMayor + " Mayor"},
+		{"gastown", "a#(rm)", "polecat", constants.EmojiPolecat + " gastown/a##(rm)"}, // no format injection
+		{"gastown", "Toast", "unknown", "gastown/Toast"},
+	}
+	for _, tt := range tests {
+		if got := paneTitle(tt.rig, tt.worker, tt.role); got != tt.want {
+			t.Errorf("paneTitle(%q, %q, %q) = %q, want %q", tt.rig, tt.worker, tt.role, got, tt.want)
+		}
+	}
+}
+
+func TestSetPaneTitleFormat(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	tm := NewTmux()
+	sessionName := "gt-test-titles-" + t.Name()
+	_ = tm.KillSession(sessionName)
+	if err := tm.NewSession(sessionName, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+
+	if err := tm.SetPaneTitleFormat(sessionName, "gastown", "Toast", "polecat"); err != nil {
+		t.Fatalf("SetPaneTitleFormat: %v", err)
+	}
+	want := constants.EmojiPolecat + " gastown/Toast"
+	if got, _ := tm.run("show-options", "-v", "-t", sessionName, "set-titles-string"); got != want {
+		t.Errorf("set-titles-string = %q, want %q", got, want)
+	}
+	if got, _ := tm.run("show-options", "-w", "-v", "-t", sessionName+":0", "automatic-rename-format"); got != want {
+		t.Errorf("automatic-rename-format = %q, want %q", got, want)
+	}
+
+	got, err := tm.ResetTheme(sessionName)
+	if err != nil {
+		t.Fatalf("ResetTheme: %v", err)
+	}
+	if strings.Join(got, " ") != "set-titles set-titles-string automatic-rename-format" {
+		t.Errorf("ResetTheme() = %v, want the title options", got)
+	}
+}
+
+func TestPaneAccentFormat(t *testing.T) {
+	got := paneAccentFormat([]string{"0", "1", "2"}, []string{"#aaaaaa", "#888888", "#666666"}, "#ffffff")
+	want := "#{?#{==:#{pane_index},0},#[bg=#aaaaaa#,fg=#ffffff]," +
+		"#{?#{==:#{pane_index},1},#[bg=#888888#,fg=#ffffff],#[bg=#666666#,fg=#ffffff]}}" +
+		" #{pane_index}: #{pane_title} #[default]"
+	if got != want {
+		t.Errorf("paneAccentFormat =\n%s\nwant\n%s", got, want)
+	}
+
+	// Single pane: no conditional at all
+	if got := paneAccentFormat([]string{"1"}, []string{"#aaaaaa"}, "#ffffff"); strings.Contains(got, "#{?") {
+		t.Errorf("paneAccentFormat(one pane) = %s", got)
+	}
+
+	// Bounded by MaxGradientPanes however many panes there are
+	panes := make([]string, 3*MaxGradientPanes)
+	for i := range panes {
+		panes[i] = strconv.Itoa(i)
+	}
+	theme := Theme{BG: "#2d5a3d", FG: "#e0e0e0"}
+	if n := strings.Count(paneAccentFormat(panes, theme.PaneAccents(len(panes)), theme.FG), "#{?"); n != MaxGradientPanes-1 {
+		t.Errorf("format has %d conditionals, want %d", n, MaxGradientPanes-1)
+	}
+}
+
+func TestSetPaneAccents(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	tm := NewTmux()
+	sessionName := "gt-test-accents-" + t.Name()
+	_ = tm.KillSession(sessionName)
+	if err := tm.NewSession(sessionName, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+	if _, err := tm.run("split-window", "-d", "-t", sessionName); err != nil {
+		t.Fatalf("split-window: %v", err)
+	}
+
+	theme := Theme{Name: "forest", BG: "#2d5a3d", FG: "#e0e0e0"}
+	if err := tm.SetPaneAccents(sessionName, theme); err != nil {
+		t.Fatalf("SetPaneAccents: %v", err)
+	}
+	format, err := tm.run("show-options", "-w", "-v", "-t", sessionName+":0", "pane-border-format")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, accent := range theme.PaneAccents(2) {
+		target := fmt.Sprintf("%s:0.%d", sessionName, i)
+		got, err := tm.run("display-message", "-p", "-t", target, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(got, "bg="+accent) {
+			t.Errorf("pane %d border = %q, want accent %s", i, got, accent)
+		}
+	}
+
+	got, err := tm.ResetTheme(sessionName)
+	if err != nil {
+		t.Fatalf("ResetTheme: %v", err)
+	}
+	version, _ := tm.Version()
+	if want := paneAccentOptions(version); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("ResetTheme() = %v, want %v", got, want)
+	}
+}
+
+func TestSelectLayout(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+
+	tm := NewTmux()
+	sessionName := "gt-test-layout-" + t.Name()
+	_ = tm.KillSession(sessionName)
+	if err := tm.NewSession(sessionName, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+	if _, err := tm.run("split-window", "-h", "-d", "-t", sessionName); err != nil {
+		t.Fatalf("split-window: %v", err)
+	}
+
+	if err := tm.SelectLayout(sessionName, "even-vertical"); err != nil {
+		t.Fatalf("SelectLayout: %v", err)
+	}
+	lefts, err := tm.run("list-panes", "-t", sessionName, "-F", "#{pane_left}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Fields(lefts); len(got) != 2 || got[0] != "0" || got[1] != "0" {
+		t.Errorf("pane_left after even-vertical = %v, want both 0 (stacked)", got)
+	}
+
+	if err := tm.SelectLayout(sessionName, "no-such-layout"); err == nil {
+		t.Error("SelectLayout(no-such-layout) = nil, want error")
+	}
+}
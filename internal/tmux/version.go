@@ -0,0 +1,60 @@
+package tmux
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version is a tmux release version, e.g. 3.3 for "tmux 3.3a". The zero
+// Version means unknown and is treated as the newest tmux.
+type Version struct {
+	Major, Minor int
+}
+
+// versionRe matches `tmux -V` output for releases ("tmux 3.3a",
+// "tmux 2.9") and development builds ("tmux next-3.5").
+var versionRe = regexp.MustCompile(`^tmux (?:next-)?(\d+)\.(\d+)`)
+
+// ParseVersion parses `tmux -V` output. Builds without a tmux release
+// number, such as "tmux master" or OpenBSD's "tmux openbsd-7.4", fail
+// with an error.
+func ParseVersion(out string) (Version, error) {
+	m := versionRe.FindStringSubmatch(strings.TrimSpace(out))
+	if m == nil {
+		return Version{}, fmt.Errorf("unrecognized tmux version %q", out)
+	}
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	return Version{Major: major, Minor: minor}, nil
+}
+
+// AtLeast reports whether v is major.minor or newer. An unknown version is
+// newer than everything.
+func (v Version) AtLeast(major, minor int) bool {
+	if v == (Version{}) {
+		return true
+	}
+	return v.Major > major || v.Major == major && v.Minor >= minor
+}
+
+func (v Version) String() string {
+	if v == (Version{}) {
+		return "unknown"
+	}
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// Version returns the version of tmux, detected once per Tmux. If it can't
+// be determined the zero (newest) Version is returned with the error.
+func (t *Tmux) Version() (Version, error) {
+	t.versionOnce.Do(func() {
+		var out string
+		out, t.versionErr = t.run("-V")
+		if t.versionErr == nil {
+			t.version, t.versionErr = ParseVersion(out)
+		}
+	})
+	return t.version, t.versionErr
+}
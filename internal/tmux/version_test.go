@@ -0,0 +1,114 @@
+package tmux
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		out  string
+		want Version
+	}{
+		{"tmux 3.3a\n", Version{3, 3}},
+		{"tmux 2.9", Version{2, 9}},
+		{"tmux 1.8", Version{1, 8}},
+		{"tmux next-3.5", Version{3, 5}},
+	}
+	for _, tt := range tests {
+		got, err := ParseVersion(tt.out)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseVersion(%q) = %v, %v; want %v", tt.out, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"tmux master", "tmux openbsd-7.4", ""} {
+		if got, err := ParseVersion(bad); err == nil {
+			t.Errorf("ParseVersion(%q) = %v, want error", bad, got)
+		}
+	}
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		v            Version
+		major, minor int
+		want         bool
+	}{
+		{Version{3, 3}, 2, 3, true},
+		{Version{2, 3}, 2, 3, true},
+		{Version{2, 2}, 2, 3, false},
+		{Version{1, 9}, 2, 0, false},
+		{Version{}, 9, 9, true}, // unknown counts as newest
+	}
+	for _, tt := range tests {
+		if got := tt.v.AtLeast(tt.major, tt.minor); got != tt.want {
+			t.Errorf("%v.AtLeast(%d, %d) = %v, want %v", tt.v, tt.major, tt.minor, got, tt.want)
+		}
+	}
+}
+
+func TestPaneAccentOptionsByVersion(t *testing.T) {
+	tests := []struct {
+		v    Version
+		want []string
+	}{
+		{Version{3, 3}, []string{"pane-border-status", "pane-border-format", "pane-border-style", "pane-active-border-style"}},
+		{Version{2, 2}, []string{"pane-border-style", "pane-active-border-style"}},
+		{Version{1, 8}, []string{"pane-border-fg", "pane-active-border-fg"}},
+	}
+	for _, tt := range tests {
+		if got := paneAccentOptions(tt.v); !slices.Equal(got, tt.want) {
+			t.Errorf("paneAccentOptions(%v) = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestModeStyleOptionsByVersion(t *testing.T) {
+	if got := modeStyleOptions(Version{3, 3}); !slices.Equal(got, []string{"mode-style"}) {
+		t.Errorf("modeStyleOptions(3.3) = %v", got)
+	}
+	if got := modeStyleOptions(Version{1, 8}); !slices.Equal(got, []string{"mode-bg", "mode-fg"}) {
+		t.Errorf("modeStyleOptions(1.8) = %v", got)
+	}
+}
+
+func TestPaneStyleOptionsByVersion(t *testing.T) {
+	if got := paneStyleOptions(Version{3, 3}); !slices.Equal(got, []string{"window-style", "window-active-style"}) {
+		t.Errorf("paneStyleOptions(3.3) = %v", got)
+	}
+	if got := paneStyleOptions(Version{2, 0}); len(got) != 0 {
+		t.Errorf("paneStyleOptions(2.0) = %v, want none", got)
+	}
+}
+
+func TestSetPaneAccentsOldTmux(t *testing.T) {
+	var set [][]string
+	tm := NewTmux()
+	tm.exec = func(_ context.Context, args []string) (string, string, error) {
+		switch args[0] {
+		case "-V":
+			return "tmux 1.8\n", "", nil
+		case "list-windows":
+			return "0\n", "", nil
+		case "list-panes":
+			return "0\n1\n", "", nil
+		case "set-option":
+			set = append(set, args)
+		}
+		return "", "", nil
+	}
+
+	theme := Theme{Name: "forest", BG: "#2d5a3d", FG: "#e0e0e0"}
+	if err := tm.SetPaneAccents("gt-gastown-Toast", theme); err != nil {
+		t.Fatal(err)
+	}
+	accents := theme.PaneAccents(2)
+	want := [][]string{
+		{"set-option", "-w", "-t", "gt-gastown-Toast:0", "pane-border-fg", accents[1]},
+		{"set-option", "-w", "-t", "gt-gastown-Toast:0", "pane-active-border-fg", accents[0]},
+	}
+	if !slices.EqualFunc(set, want, slices.Equal[[]string]) {
+		t.Errorf("set-option calls = %q, want %q", set, want)
+	}
+}
@@ -0,0 +1,115 @@
+// Package themepicker is the interactive theme chooser behind 'gt theme --pick'.
+package themepicker
+
+import (
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// Item is one selectable theme.
+type Item struct {
+	Theme  tmux.Theme
+	Source string // e.g. "built-in", "custom", "rig gastown"
+}
+
+// Model is the bubbletea model for the theme picker.
+type Model struct {
+	items  []Item
+	cursor int
+	rig    string // rig being themed, shown in the title and preview
+	chosen int    // index of the selected item, or -1 if none
+
+	// UI state
+	keys     KeyMap
+	help     help.Model
+	showHelp bool
+	width    int
+}
+
+// New creates a picker over items for rig, with the cursor on the theme
+// named current (or the first item if it isn't listed).
+func New(items []Item, rig, current string) Model {
+	m := Model{
+		items:  items,
+		rig:    rig,
+		chosen: -1,
+		keys:   DefaultKeyMap(),
+		help:   help.New(),
+	}
+	for i, item := range items {
+		if item.Theme.Name == current {
+			m.cursor = i
+			break
+		}
+	}
+	return m
+}
+
+// Selected returns the theme chosen with enter. ok is false if the picker
+// was cancelled.
+func (m Model) Selected() (theme tmux.Theme, ok bool) {
+	if m.chosen < 0 {
+		return tmux.Theme{}, false
+	}
+	return m.items[m.chosen].Theme, true
+}
+
+// Init initializes the model.
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.help.Width = msg.Width
+		return m, nil
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+
+		case key.Matches(msg, m.keys.Help):
+			m.showHelp = !m.showHelp
+			return m, nil
+
+		case key.Matches(msg, m.keys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.Down):
+			if m.cursor < len(m.items)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.Top):
+			m.cursor = 0
+			return m, nil
+
+		case key.Matches(msg, m.keys.Bottom):
+			m.cursor = max(len(m.items)-1, 0)
+			return m, nil
+
+		case key.Matches(msg, m.keys.Select):
+			if len(m.items) > 0 {
+				m.chosen = m.cursor
+			}
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+// View renders the model.
+func (m Model) View() string {
+	return m.renderView()
+}
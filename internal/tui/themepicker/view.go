@@ -0,0 +1,114 @@
+package themepicker
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// Styles for the theme picker
+var (
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("12"))
+
+	selectedStyle = lipgloss.NewStyle().
+			Background(lipgloss.Color("236")).
+			Foreground(lipgloss.Color("15"))
+
+	sourceStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("8")) // gray
+
+	helpStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("8"))
+)
+
+// previewWidth bounds the width of the status bar preview.
+const previewWidth = 80
+
+// renderView renders the entire view.
+func (m Model) renderView() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("Pick a theme for " + m.rig))
+	b.WriteString("\n\n")
+
+	if len(m.items) == 0 {
+		b.WriteString("No themes available.\n")
+		return b.String()
+	}
+
+	for i, item := range m.items {
+		swatch := lipgloss.NewStyle().Background(termColor(item.Theme.BG)).Render("   ")
+		line := fmt.Sprintf("%-16s %s", item.Theme.Name, sourceStyle.Render(item.Source))
+		if i == m.cursor {
+			b.WriteString("▸ " + swatch + " " + selectedStyle.Render(line))
+		} else {
+			b.WriteString("  " + swatch + " " + line)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.renderPreview(m.items[m.cursor].Theme))
+	b.WriteString("\n\n")
+
+	if m.showHelp {
+		b.WriteString(m.help.View(m.keys))
+	} else {
+		b.WriteString(helpStyle.Render("j/k:navigate  enter:save and apply  q:cancel  ?:help"))
+	}
+
+	return b.String()
+}
+
+// renderPreview draws a status bar the way tmux would with theme: the
+// identity segment, the current window tab (inverted, bold) and the clock.
+func (m Model) renderPreview(theme tmux.Theme) string {
+	width := previewWidth
+	if m.width > 0 && m.width < width {
+		width = m.width
+	}
+
+	bar := lipgloss.NewStyle().Background(termColor(theme.BG)).Foreground(termColor(theme.FG))
+	tab := lipgloss.NewStyle().Background(termColor(theme.FG)).Foreground(termColor(theme.BG)).Bold(true)
+
+	left := bar.Render(" "+m.rig+" ") + tab.Render(" 0:claude* ")
+	right := bar.Render(" " + time.Now().Format("15:04") + " ")
+	gap := width - lipgloss.Width(left) - lipgloss.Width(right)
+	if gap < 0 {
+		gap = 0
+	}
+	return left + bar.Render(strings.Repeat(" ", gap)) + right
+}
+
+// ansiColors maps tmux color names to ANSI color numbers.
+var ansiColors = map[string]int{
+	"black": 0, "red": 1, "green": 2, "yellow": 3,
+	"blue": 4, "magenta": 5, "cyan": 6, "white": 7,
+}
+
+// termColor converts a tmux color (#rrggbb, colourN/colorN or a color name
+// such as red or brightblue) to a lipgloss color. "default" and anything
+// unrecognized render as the terminal's own color.
+func termColor(c string) lipgloss.TerminalColor {
+	c = strings.ToLower(c)
+	switch {
+	case strings.HasPrefix(c, "#"):
+		return lipgloss.Color(c)
+	case strings.HasPrefix(c, "colour"):
+		return lipgloss.Color(strings.TrimPrefix(c, "colour"))
+	case strings.HasPrefix(c, "color"):
+		return lipgloss.Color(strings.TrimPrefix(c, "color"))
+	}
+	if n, ok := ansiColors[strings.TrimPrefix(c, "bright")]; ok {
+		if strings.HasPrefix(c, "bright") {
+			n += 8
+		}
+		return lipgloss.Color(fmt.Sprint(n))
+	}
+	return lipgloss.NoColor{}
+}
@@ -13,7 +13,14 @@ import (
 )
 
 func init() {
-	if !ShouldUseColor() {
+	SetColorEnabled(ShouldUseColor())
+}
+
+// SetColorEnabled turns styled output on or off for every style built on
+// lipgloss, which is all of gt's CLI output. It is decided once at startup
+// from ShouldUseColor; tests call it to render plain text.
+func SetColorEnabled(enabled bool) {
+	if !enabled {
 		// disable colors when not appropriate (non-TTY, NO_COLOR, etc.)
 		lipgloss.SetColorProfile(termenv.Ascii)
 	} else {
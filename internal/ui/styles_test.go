@@ -0,0 +1,23 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetColorEnabled(t *testing.T) {
+	defer SetColorEnabled(ShouldUseColor())
+
+	SetColorEnabled(false)
+	if got := RenderPass("ok"); got != "ok" {
+		t.Errorf("color disabled: RenderPass = %q, want plain ok", got)
+	}
+	if got := RenderCategory("ready"); got != "READY" {
+		t.Errorf("color disabled: RenderCategory = %q, want plain READY", got)
+	}
+
+	SetColorEnabled(true)
+	if got := RenderPass("ok"); !strings.Contains(got, "\x1b[") {
+		t.Errorf("color enabled: RenderPass = %q, want escape sequences", got)
+	}
+}
@@ -16,12 +16,64 @@ const WispConfigDir = ".beads-wisp"
 // ConfigSubdir is the subdirectory within WispConfigDir for config files.
 const ConfigSubdir = "config"
 
+// CurrentConfigVersion is the current schema version for ConfigFile.
+// Files written before versioning have no version field and read as 0.
+const CurrentConfigVersion = 1
+
 // ConfigFile represents the JSON structure for wisp config storage.
 // Storage location: .beads-wisp/config/<rig>.json
 type ConfigFile struct {
+	Version int                    `json:"version"` // schema version; 0 = unversioned
 	Rig     string                 `json:"rig"`
 	Values  map[string]interface{} `json:"values"`
 	Blocked []string               `json:"blocked"`
+
+	// Extra holds top-level fields this version doesn't know, written by a
+	// newer gt. They are kept as-is and written back on save, so an older
+	// gt sharing the file doesn't strip a newer one's data.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// configFileKeys are the JSON keys of ConfigFile's known fields.
+var configFileKeys = []string{"version", "rig", "values", "blocked"}
+
+// UnmarshalJSON decodes the known fields and collects the rest in Extra.
+func (f *ConfigFile) UnmarshalJSON(data []byte) error {
+	type plain ConfigFile
+	if err := json.Unmarshal(data, (*plain)(f)); err != nil {
+		return err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	for _, key := range configFileKeys {
+		delete(all, key)
+	}
+	f.Extra = nil
+	if len(all) > 0 {
+		f.Extra = all
+	}
+	return nil
+}
+
+// MarshalJSON encodes the known fields plus any preserved Extra fields.
+func (f ConfigFile) MarshalJSON() ([]byte, error) {
+	type plain ConfigFile
+	data, err := json.Marshal(plain(f))
+	if err != nil || len(f.Extra) == 0 {
+		return data, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	for key, val := range f.Extra {
+		if _, known := all[key]; !known {
+			all[key] = val
+		}
+	}
+	return json.Marshal(all)
 }
 
 // Config provides access to wisp-based config storage for a specific rig.
@@ -31,6 +83,8 @@ type Config struct {
 	townRoot string
 	rigName  string
 	filePath string
+
+	warnOnce sync.Once // newer-than-known version warning
 }
 
 // NewConfig creates a new Config for the given rig.
@@ -53,9 +107,10 @@ func (c *Config) ConfigPath() string {
 // load reads the config file from disk.
 // Returns a new empty ConfigFile if the file doesn't exist.
 func (c *Config) load() (*ConfigFile, error) {
-	data, err := os.ReadFile(c.filePath)
+	data, err := fileSystem.ReadFile(c.filePath)
 	if os.IsNotExist(err) {
 		return &ConfigFile{
+			Version: CurrentConfigVersion,
 			Rig:     c.rigName,
 			Values:  make(map[string]interface{}),
 			Blocked: []string{},
@@ -70,6 +125,16 @@ func (c *Config) load() (*ConfigFile, error) {
 		return nil, fmt.Errorf("unmarshal config: %w", err)
 	}
 
+	// Older files (including unversioned v0) share the current layout.
+	// Newer ones are read best-effort: known fields are used, and the rest
+	// are kept in Extra and written back unchanged by save.
+	if cfg.Version > CurrentConfigVersion {
+		c.warnOnce.Do(func() {
+			fmt.Fprintf(os.Stderr, "warning: %s has config version %d, newer than supported %d; unknown fields preserved but not used\n",
+				c.filePath, cfg.Version, CurrentConfigVersion)
+		})
+	}
+
 	// Ensure maps are initialized
 	if cfg.Values == nil {
 		cfg.Values = make(map[string]interface{})
@@ -81,11 +146,17 @@ func (c *Config) load() (*ConfigFile, error) {
 	return &cfg, nil
 }
 
-// save writes the config file to disk atomically.
+// save writes the config file to disk atomically. Older files are
+// upgraded to CurrentConfigVersion; a newer file keeps its version and its
+// unknown fields, so the newer gt that wrote it can still read them.
 func (c *Config) save(cfg *ConfigFile) error {
+	if cfg.Version < CurrentConfigVersion {
+		cfg.Version = CurrentConfigVersion
+	}
+
 	// Ensure directory exists
 	dir := filepath.Dir(c.filePath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	if err := fileSystem.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("create config dir: %w", err)
 	}
 
@@ -96,12 +167,12 @@ func (c *Config) save(cfg *ConfigFile) error {
 
 	// Write atomically via temp file
 	tmp := c.filePath + ".tmp"
-	if err := os.WriteFile(tmp, data, 0644); err != nil { //nolint:gosec // G306: wisp config is non-sensitive operational data
+	if err := fileSystem.WriteFile(tmp, data, 0644); err != nil { //nolint:gosec // G306: wisp config is non-sensitive operational data
 		return fmt.Errorf("write temp: %w", err)
 	}
 
-	if err := os.Rename(tmp, c.filePath); err != nil {
-		_ = os.Remove(tmp) // cleanup on failure
+	if err := fileSystem.Rename(tmp, c.filePath); err != nil {
+		_ = fileSystem.Remove(tmp) // cleanup on failure
 		return fmt.Errorf("rename: %w", err)
 	}
 
@@ -299,6 +370,7 @@ func (c *Config) Clear() error {
 	defer c.mu.Unlock()
 
 	cfg := &ConfigFile{
+		Version: CurrentConfigVersion,
 		Rig:     c.rigName,
 		Values:  make(map[string]interface{}),
 		Blocked: []string{},
@@ -1,9 +1,12 @@
 package wisp
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/steveyegge/gastown/internal/fsys"
 )
 
 func TestConfig_BasicOperations(t *testing.T) {
@@ -222,3 +225,111 @@ func TestConfig_MultipleRigs(t *testing.T) {
 		t.Errorf("rig2 Get(key) = %v, want value2", got)
 	}
 }
+
+func TestConfig_LoadUnversioned(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := NewConfig(tmpDir, "testrig")
+
+	// A v0 file, written before ConfigFile had a version field.
+	v0 := `{"rig": "testrig", "values": {"key1": "value1"}, "blocked": ["key2"]}`
+	if err := os.MkdirAll(filepath.Dir(cfg.ConfigPath()), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(cfg.ConfigPath(), []byte(v0), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := cfg.GetString("key1"); got != "value1" {
+		t.Errorf("GetString(key1) = %q, want value1", got)
+	}
+	if !cfg.IsBlocked("key2") {
+		t.Error("key2 should be blocked")
+	}
+
+	// Writing upgrades the file to the current version.
+	if err := cfg.Set("key3", "value3"); err != nil {
+		t.Fatalf("Set(key3) error: %v", err)
+	}
+	data, err := os.ReadFile(cfg.ConfigPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file ConfigFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatal(err)
+	}
+	if file.Version != CurrentConfigVersion {
+		t.Errorf("Version = %d, want %d", file.Version, CurrentConfigVersion)
+	}
+	if file.Values["key1"] != "value1" {
+		t.Errorf("key1 lost on upgrade: %v", file.Values)
+	}
+}
+
+func TestConfig_NewerVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := NewConfig(tmpDir, "testrig")
+
+	newer := `{"version": 99, "rig": "testrig", "values": {"key1": "value1"}, "ttl": "1h"}`
+	if err := os.MkdirAll(filepath.Dir(cfg.ConfigPath()), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(cfg.ConfigPath(), []byte(newer), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Known fields are still readable.
+	if got := cfg.GetString("key1"); got != "value1" {
+		t.Errorf("GetString(key1) = %q, want value1", got)
+	}
+
+	// Writes keep the newer version and the fields this reader doesn't
+	// know about.
+	if err := cfg.Set("key2", "value2"); err != nil {
+		t.Fatalf("Set on newer-version config: %v", err)
+	}
+	data, err := os.ReadFile(cfg.ConfigPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file map[string]interface{}
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatal(err)
+	}
+	if file["version"] != float64(99) || file["ttl"] != "1h" {
+		t.Errorf("newer-version fields lost on rewrite:\n%s", data)
+	}
+	if values, _ := file["values"].(map[string]interface{}); values["key1"] != "value1" || values["key2"] != "value2" {
+		t.Errorf("values after rewrite = %v, want key1 and key2", file["values"])
+	}
+}
+
+func TestConfig_InMemory(t *testing.T) {
+	mem := fsys.NewMem()
+	old := fileSystem
+	fileSystem = mem
+	t.Cleanup(func() { fileSystem = old })
+
+	cfg := NewConfig("/town", "testrig")
+	if err := cfg.Set("key1", "value1"); err != nil {
+		t.Fatalf("Set(key1) error: %v", err)
+	}
+	if err := cfg.Block("key2"); err != nil {
+		t.Fatalf("Block(key2) error: %v", err)
+	}
+
+	// A fresh Config sees the same store.
+	reread := NewConfig("/town", "testrig")
+	if got := reread.GetString("key1"); got != "value1" {
+		t.Errorf("GetString(key1) = %q, want value1", got)
+	}
+	if !reread.IsBlocked("key2") {
+		t.Error("key2 should be blocked")
+	}
+
+	// The atomic write leaves no temp file behind.
+	want := filepath.Join("/town", WispConfigDir, ConfigSubdir, "testrig.json")
+	if got := mem.Files(); len(got) != 1 || got[0] != want {
+		t.Errorf("files = %v, want only %s", got, want)
+	}
+}
@@ -0,0 +1,161 @@
+package wisp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// Hook dependencies. A hooked bead can list the beads that must be done
+// before it is started, in its depends_on field (gt sling --after). The
+// dependencies are resolved when the hook is picked up:
+//
+//   - a dependency is done when its bead is closed ('gt done' or bd close);
+//     hooked, in-progress and open beads are not done
+//   - only waiting hooks are held back: work already picked up resumes
+//     even if a dependency has since been reopened
+//   - a waiting hook with an undone dependency stays where it is. On an
+//     agent's own hook, PickUpHook returns ErrHookBlocked; on a role hook,
+//     ClaimRoleHook passes over it and claims the next bead that is ready
+//   - a dependency that can't be found blocks the hook, so a typo never
+//     releases work early
+//   - dependencies are followed through beads that are not done, and a
+//     chain that leads back to a bead already in it is ErrDependencyCycle:
+//     none of those beads could ever be picked up
+//
+// Dependencies are looked up in the hook's own beads database; bead IDs
+// are also hook IDs, a hook being the bead on it.
+
+// ErrHookBlocked is returned when a waiting hook's dependencies are not
+// all done.
+var ErrHookBlocked = errors.New("hook blocked")
+
+// ErrDependencyCycle is returned when hook dependencies form a cycle.
+var ErrDependencyCycle = errors.New("dependency cycle")
+
+// HookDependencies returns the bead IDs issue's hook depends on.
+func HookDependencies(issue *beads.Issue) []string {
+	fields := beads.ParseAttachmentFields(issue)
+	if fields == nil {
+		return nil
+	}
+	return fields.DependsOn
+}
+
+// HookBlockers returns the dependencies of issue, looked up in the beads
+// database at workDir, that are not done yet. The error wraps
+// ErrDependencyCycle if the dependencies form a cycle.
+func HookBlockers(workDir string, issue *beads.Issue) ([]string, error) {
+	return hookBlockers(issue, beads.New(workDir).Show)
+}
+
+// CheckHookReady returns nil if issue's hook can be picked up now: it is
+// already in progress, or all its dependencies are done. Otherwise the
+// error wraps ErrHookBlocked, naming the dependencies still open, or
+// ErrDependencyCycle.
+func CheckHookReady(workDir string, issue *beads.Issue) error {
+	if HookInProgress(issue) || len(HookDependencies(issue)) == 0 {
+		return nil
+	}
+	blockers, err := HookBlockers(workDir, issue)
+	if err != nil {
+		return err
+	}
+	if len(blockers) > 0 {
+		return fmt.Errorf("%w: %s is waiting on %s", ErrHookBlocked, issue.ID, strings.Join(blockers, ", "))
+	}
+	return nil
+}
+
+// SetHookDependencies records deps as the beads issue's hook depends on,
+// using b for the lookups and the update. It refuses dependencies that
+// would form a cycle or name beads that don't exist.
+func SetHookDependencies(b *beads.Beads, issue *beads.Issue, deps []string) error {
+	if err := CheckHookDependencies(b, issue.ID, deps); err != nil {
+		return err
+	}
+	fields := beads.ParseAttachmentFields(issue)
+	if fields == nil {
+		fields = &beads.AttachmentFields{}
+	}
+	fields.DependsOn = deps
+
+	desc := beads.SetAttachmentFields(issue, fields)
+	if err := b.Update(issue.ID, beads.UpdateOptions{Description: &desc}); err != nil {
+		return fmt.Errorf("updating %s: %w", issue.ID, err)
+	}
+	issue.Description = desc
+	return nil
+}
+
+// CheckHookDependencies checks that bead beadID could depend on deps,
+// looking them up with b: every dependency exists and none leads back to
+// beadID (ErrDependencyCycle).
+func CheckHookDependencies(b *beads.Beads, beadID string, deps []string) error {
+	for _, dep := range deps {
+		if _, err := b.Show(dep); err != nil {
+			return fmt.Errorf("dependency %s: %w", dep, err)
+		}
+	}
+	issue := &beads.Issue{ID: beadID, Description: beads.FormatAttachmentFields(&beads.AttachmentFields{DependsOn: deps})}
+	_, err := hookBlockers(issue, b.Show)
+	return err
+}
+
+// hookBlockers resolves issue's dependencies with show, returning the
+// direct ones not done. Dependencies that are not done are followed to
+// detect cycles; done ones end the walk, since nothing can block them.
+func hookBlockers(issue *beads.Issue, show func(id string) (*beads.Issue, error)) ([]string, error) {
+	var blockers []string
+	walked := make(map[string]bool)
+	for _, dep := range HookDependencies(issue) {
+		if dep == issue.ID {
+			return nil, fmt.Errorf("%w: %s depends on itself", ErrDependencyCycle, issue.ID)
+		}
+		depIssue, err := show(dep)
+		if err != nil {
+			if !errors.Is(err, beads.ErrNotFound) {
+				return nil, fmt.Errorf("looking up %s's dependency %s: %w", issue.ID, dep, err)
+			}
+			blockers = append(blockers, dep+" (not found)")
+			continue
+		}
+		if depIssue.Status == "closed" {
+			continue
+		}
+		blockers = append(blockers, dep)
+		if err := walkDependencies(depIssue, []string{issue.ID}, walked, show); err != nil {
+			return nil, err
+		}
+	}
+	return blockers, nil
+}
+
+// walkDependencies follows the dependencies of issue, which is not done,
+// returning ErrDependencyCycle if one leads back to a bead on path (the
+// chain of beads depending on issue). Beads in walked were already
+// followed without finding a cycle and are not followed again.
+func walkDependencies(issue *beads.Issue, path []string, walked map[string]bool, show func(id string) (*beads.Issue, error)) error {
+	if walked[issue.ID] {
+		return nil
+	}
+	path = append(path, issue.ID)
+	for _, dep := range HookDependencies(issue) {
+		for i, id := range path {
+			if id == dep {
+				return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(append(path[i:], dep), " → "))
+			}
+		}
+		depIssue, err := show(dep)
+		if err != nil || depIssue.Status == "closed" {
+			continue // reported when the beads depending on it are picked up
+		}
+		if err := walkDependencies(depIssue, path, walked, show); err != nil {
+			return err
+		}
+	}
+	walked[issue.ID] = true
+	return nil
+}
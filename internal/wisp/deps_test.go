@@ -0,0 +1,100 @@
+package wisp
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// depBead returns a bead with the given status depending on deps.
+func depBead(id, status string, deps ...string) *beads.Issue {
+	issue := &beads.Issue{ID: id, Status: status}
+	if len(deps) > 0 {
+		issue.Description = beads.FormatAttachmentFields(&beads.AttachmentFields{DependsOn: deps})
+	}
+	return issue
+}
+
+func TestHookBlockers(t *testing.T) {
+	db := map[string]*beads.Issue{}
+	for _, issue := range []*beads.Issue{
+		depBead("gt-done", "closed"),
+		depBead("gt-open", "open"),
+		depBead("gt-working", "hooked"),
+		depBead("gt-closed-cycle", "closed", "gt-loop"),
+		depBead("gt-loop", "hooked", "gt-closed-cycle"),
+		depBead("gt-left", "open", "gt-base"),
+		depBead("gt-right", "open", "gt-base"),
+		depBead("gt-base", "open"),
+		depBead("gt-c1", "hooked", "gt-c2"),
+		depBead("gt-c2", "open", "gt-c3"),
+		depBead("gt-c3", "open", "gt-c1"),
+		depBead("gt-broken", "open"),
+	} {
+		db[issue.ID] = issue
+	}
+	show := func(id string) (*beads.Issue, error) {
+		if id == "gt-broken" {
+			return nil, errors.New("database locked")
+		}
+		if issue, ok := db[id]; ok {
+			return issue, nil
+		}
+		return nil, beads.ErrNotFound
+	}
+
+	tests := []struct {
+		name      string
+		issue     *beads.Issue
+		want      []string
+		wantErr   error
+		wantInErr string
+	}{
+		{name: "no dependencies", issue: depBead("gt-x", "hooked")},
+		{name: "dependency done", issue: depBead("gt-x", "hooked", "gt-done")},
+		{name: "dependencies open", issue: depBead("gt-x", "hooked", "gt-done", "gt-open", "gt-working"), want: []string{"gt-open", "gt-working"}},
+		{name: "missing dependency blocks", issue: depBead("gt-x", "hooked", "gt-nope"), want: []string{"gt-nope (not found)"}},
+		{name: "closed bead ends the walk", issue: depBead("gt-x", "hooked", "gt-closed-cycle")},
+		{name: "shared dependency is not a cycle", issue: depBead("gt-x", "hooked", "gt-left", "gt-right"), want: []string{"gt-left", "gt-right"}},
+		{name: "depends on itself", issue: depBead("gt-x", "hooked", "gt-x"), wantErr: ErrDependencyCycle},
+		{name: "cycle", issue: db["gt-c1"], wantErr: ErrDependencyCycle, wantInErr: "gt-c1 → gt-c2 → gt-c3 → gt-c1"},
+		{name: "lookup failure", issue: depBead("gt-x", "hooked", "gt-broken"), wantInErr: "database locked"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := hookBlockers(tt.issue, show)
+			if tt.wantErr != nil || tt.wantInErr != "" {
+				if err == nil {
+					t.Fatalf("hookBlockers() = %v, want error", got)
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("hookBlockers() error = %v, want %v", err, tt.wantErr)
+				}
+				if !strings.Contains(err.Error(), tt.wantInErr) {
+					t.Errorf("hookBlockers() error = %v, want it to contain %q", err, tt.wantInErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("hookBlockers() error = %v", err)
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("hookBlockers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckHookReadyInProgress(t *testing.T) {
+	// Work already picked up resumes without looking at its dependencies
+	issue := &beads.Issue{ID: "gt-x", Status: "in_progress", Description: "depends_on: gt-open"}
+	if err := CheckHookReady(t.TempDir(), issue); err != nil {
+		t.Errorf("CheckHookReady() = %v, want nil for in-progress work", err)
+	}
+	if err := CheckHookReady(t.TempDir(), &beads.Issue{ID: "gt-y", Status: beads.StatusHooked}); err != nil {
+		t.Errorf("CheckHookReady() = %v, want nil without dependencies", err)
+	}
+}
@@ -0,0 +1,85 @@
+package wisp
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// HookEdit is the part of a hook 'gt hook edit' lets you change, as the
+// JSON document opened in the editor.
+type HookEdit struct {
+	Bead      string            `json:"bead"`                 // bead on the hook; changing it swaps the hooked bead
+	Args      string            `json:"args,omitempty"`       // gt sling --args
+	Meta      map[string]string `json:"meta,omitempty"`       // gt sling --meta
+	DependsOn []string          `json:"depends_on,omitempty"` // gt sling --after
+	NoMerge   bool              `json:"no_merge,omitempty"`   // gt sling --no-merge
+	Pinned    bool              `json:"pinned,omitempty"`     // gt hook pin
+}
+
+// NewHookEdit returns the editable part of the hook on issue.
+func NewHookEdit(issue *beads.Issue) HookEdit {
+	e := HookEdit{Bead: issue.ID}
+	if fields := beads.ParseAttachmentFields(issue); fields != nil {
+		e.Args = fields.AttachedArgs
+		e.Meta = fields.Metadata
+		e.DependsOn = fields.DependsOn
+		e.NoMerge = fields.NoMerge
+		e.Pinned = fields.Pinned
+	}
+	return e
+}
+
+// Validate checks that an edit can be stored in a bead description: a
+// well-formed bead ID, single-line args and metadata values, metadata keys
+// without ':' or whitespace, and dependencies that are bead IDs other than
+// the bead itself. The error, wrapping ErrInvalidHook, lists every problem.
+func (e HookEdit) Validate() error {
+	problems := beadIDProblems(e.Bead)
+	if strings.ContainsAny(e.Args, "\r\n") {
+		problems = append(problems, "args must be a single line")
+	}
+	for k, v := range e.Meta {
+		switch {
+		case k == "" || strings.ContainsAny(k, ": \t\r\n"):
+			problems = append(problems, fmt.Sprintf("meta key %q must be non-empty, without ':' or whitespace", k))
+		case v == "" || strings.ContainsAny(v, "\r\n"):
+			problems = append(problems, fmt.Sprintf("meta %q must be a non-empty single line", k))
+		}
+	}
+	for _, dep := range e.DependsOn {
+		switch {
+		case dep == "" || strings.ContainsAny(dep, ", \t\r\n"):
+			problems = append(problems, fmt.Sprintf("malformed dependency %q", dep))
+		case dep == e.Bead:
+			problems = append(problems, fmt.Sprintf("%s can't depend on itself", dep))
+		}
+	}
+	return hookError(e.Bead, problems)
+}
+
+// EditedHookDescription returns the description of bead to with edit e
+// applied, stamped with modified_at. from is the bead on the hook before
+// the edit; when e swaps it for another bead (to), the new bead also
+// inherits the hook's dispatcher so completion is still reported. Other
+// fields, and the bead's creation time, are left as they are.
+func EditedHookDescription(from, to *beads.Issue, e HookEdit, now time.Time) string {
+	fields := beads.ParseAttachmentFields(to)
+	if fields == nil {
+		fields = &beads.AttachmentFields{}
+	}
+	if to.ID != from.ID && fields.DispatchedBy == "" {
+		if old := beads.ParseAttachmentFields(from); old != nil {
+			fields.DispatchedBy = old.DispatchedBy
+		}
+	}
+	fields.AttachedArgs = e.Args
+	fields.Metadata = e.Meta
+	fields.DependsOn = e.DependsOn
+	fields.NoMerge = e.NoMerge
+	fields.Pinned = e.Pinned
+	fields.ModifiedAt = now.UTC().Format(time.RFC3339)
+	return beads.SetAttachmentFields(to, fields)
+}
@@ -0,0 +1,71 @@
+package wisp
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestHookEditValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		edit    HookEdit
+		wantErr string
+	}{
+		{"valid", HookEdit{Bead: "gt-abc", Args: "patch release", Meta: map[string]string{"pr": "42"}, DependsOn: []string{"gt-def"}}, ""},
+		{"malformed bead", HookEdit{Bead: "abc"}, "malformed bead ID"},
+		{"multi-line args", HookEdit{Bead: "gt-abc", Args: "one\ntwo"}, "args must be a single line"},
+		{"meta key with colon", HookEdit{Bead: "gt-abc", Meta: map[string]string{"a:b": "x"}}, `meta key "a:b"`},
+		{"empty meta value", HookEdit{Bead: "gt-abc", Meta: map[string]string{"pr": ""}}, `meta "pr"`},
+		{"self dependency", HookEdit{Bead: "gt-abc", DependsOn: []string{"gt-abc"}}, "can't depend on itself"},
+		{"malformed dependency", HookEdit{Bead: "gt-abc", DependsOn: []string{"gt-a, gt-b"}}, "malformed dependency"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.edit.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidHook) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() = %v, want ErrInvalidHook containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEditedHookDescription(t *testing.T) {
+	fields := &beads.AttachmentFields{DispatchedBy: "mayor", AttachedArgs: "wrong context"}
+	hooked := &beads.Issue{ID: "gt-abc", Description: beads.SetAttachmentFields(&beads.Issue{Description: "Fix the widget"}, fields)}
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	edit := NewHookEdit(hooked)
+	if edit.Bead != "gt-abc" || edit.Args != "wrong context" {
+		t.Fatalf("NewHookEdit() = %+v", edit)
+	}
+	edit.Args = "right context"
+	edit.Pinned = true
+
+	got := beads.ParseAttachmentFields(&beads.Issue{Description: EditedHookDescription(hooked, hooked, edit, now)})
+	if got.AttachedArgs != "right context" || !got.Pinned || got.DispatchedBy != "mayor" || got.ModifiedAt != "2026-01-02T03:04:05Z" {
+		t.Errorf("edited fields = %+v", got)
+	}
+
+	// Swapping in another bead carries the dispatcher over and keeps the
+	// new bead's own text.
+	other := &beads.Issue{ID: "gt-def", Description: "Fix the gadget"}
+	edit.Bead = "gt-def"
+	desc := EditedHookDescription(hooked, other, edit, now)
+	got = beads.ParseAttachmentFields(&beads.Issue{Description: desc})
+	if got.DispatchedBy != "mayor" || got.AttachedArgs != "right context" {
+		t.Errorf("swapped fields = %+v", got)
+	}
+	if !strings.Contains(desc, "Fix the gadget") {
+		t.Errorf("swapped description lost the bead's text: %q", desc)
+	}
+}
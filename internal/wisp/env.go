@@ -0,0 +1,109 @@
+package wisp
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// CapturedEnvVars are the environment variables 'gt sling --capture-env'
+// records with a hook. Only context that shapes how the work runs is on
+// the list; identity (GT_ROLE, BD_ACTOR, ...) belongs to whoever picks the
+// work up, and anything that could hold a secret is never captured.
+var CapturedEnvVars = []string{
+	"BEADS_DIR",
+	"GOFLAGS",
+	"GT_AGENT_MODE",
+	"GT_THEME",
+	"NODE_ENV",
+	"VIRTUAL_ENV",
+}
+
+// EnvSnapshot is the context captured with a hook at sling time, so an
+// agent resuming the work can pick up where it was slung from.
+type EnvSnapshot struct {
+	Cwd string
+	Env map[string]string // CapturedEnvVars that were set
+}
+
+// CaptureEnv snapshots the current working directory and CapturedEnvVars.
+func CaptureEnv() (EnvSnapshot, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return EnvSnapshot{}, fmt.Errorf("getting working directory: %w", err)
+	}
+	return captureEnv(cwd, os.LookupEnv), nil
+}
+
+// captureEnv builds a snapshot of cwd and the CapturedEnvVars lookup finds.
+// Empty and multi-line values are left out, since a hook field is one line.
+func captureEnv(cwd string, lookup func(string) (string, bool)) EnvSnapshot {
+	var snap EnvSnapshot
+	if !strings.ContainsAny(cwd, "\r\n") {
+		snap.Cwd = cwd
+	}
+	for _, name := range CapturedEnvVars {
+		value, ok := lookup(name)
+		if !ok || value == "" || strings.ContainsAny(value, "\r\n") {
+			continue
+		}
+		if snap.Env == nil {
+			snap.Env = make(map[string]string)
+		}
+		snap.Env[name] = value
+	}
+	return snap
+}
+
+// HookEnv returns the snapshot captured with issue's hook, if any.
+func HookEnv(issue *beads.Issue) (EnvSnapshot, bool) {
+	fields := beads.ParseAttachmentFields(issue)
+	if fields == nil || (fields.CapturedCwd == "" && len(fields.CapturedEnv) == 0) {
+		return EnvSnapshot{}, false
+	}
+	return EnvSnapshot{Cwd: fields.CapturedCwd, Env: fields.CapturedEnv}, true
+}
+
+// SetHookEnv records snap in issue's description, using b for the update.
+func SetHookEnv(b *beads.Beads, issue *beads.Issue, snap EnvSnapshot) error {
+	fields := beads.ParseAttachmentFields(issue)
+	if fields == nil {
+		fields = &beads.AttachmentFields{}
+	}
+	fields.CapturedCwd = snap.Cwd
+	fields.CapturedEnv = snap.Env
+
+	desc := beads.SetAttachmentFields(issue, fields)
+	if err := b.Update(issue.ID, beads.UpdateOptions{Description: &desc}); err != nil {
+		return fmt.Errorf("updating %s: %w", issue.ID, err)
+	}
+	issue.Description = desc
+	return nil
+}
+
+// ShellCommands returns the POSIX shell commands that restore the snapshot
+// in a session whose working directory is workDir: a cd when the captured
+// directory differs, then an export per variable, in name order.
+func (s EnvSnapshot) ShellCommands(workDir string) []string {
+	var cmds []string
+	if s.Cwd != "" && s.Cwd != workDir {
+		cmds = append(cmds, "cd "+shellQuote(s.Cwd))
+	}
+	names := make([]string, 0, len(s.Env))
+	for name := range s.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cmds = append(cmds, "export "+name+"="+shellQuote(s.Env[name]))
+	}
+	return cmds
+}
+
+// shellQuote single-quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,58 @@
+package wisp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestCaptureEnv(t *testing.T) {
+	env := map[string]string{
+		"GOFLAGS":       "-tags=integration",
+		"GT_AGENT_MODE": "",
+		"NODE_ENV":      "line one\nline two",
+		"AWS_SECRET":    "hunter2",
+	}
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+
+	snap := captureEnv("/town/gastown/crew/max", lookup)
+	if snap.Cwd != "/town/gastown/crew/max" {
+		t.Errorf("Cwd = %q", snap.Cwd)
+	}
+	if len(snap.Env) != 1 || snap.Env["GOFLAGS"] != "-tags=integration" {
+		t.Errorf("Env = %v, want only GOFLAGS (empty, multi-line and unlisted vars left out)", snap.Env)
+	}
+}
+
+func TestHookEnvRoundTrip(t *testing.T) {
+	snap := EnvSnapshot{Cwd: "/town/gastown/crew/max", Env: map[string]string{"GOFLAGS": "-race", "VIRTUAL_ENV": "/venv"}}
+	fields := &beads.AttachmentFields{CapturedCwd: snap.Cwd, CapturedEnv: snap.Env}
+	issue := &beads.Issue{Description: beads.SetAttachmentFields(&beads.Issue{Description: "Fix the widget"}, fields)}
+
+	got, ok := HookEnv(issue)
+	if !ok || got.Cwd != snap.Cwd || got.Env["GOFLAGS"] != "-race" || got.Env["VIRTUAL_ENV"] != "/venv" {
+		t.Fatalf("HookEnv() = %+v, %v; want %+v", got, ok, snap)
+	}
+	if !strings.HasSuffix(issue.Description, "Fix the widget") {
+		t.Errorf("description lost its content:\n%s", issue.Description)
+	}
+	if _, ok := HookEnv(&beads.Issue{Description: "Fix the widget"}); ok {
+		t.Error("HookEnv() found a snapshot in a bead without one")
+	}
+}
+
+func TestEnvSnapshotShellCommands(t *testing.T) {
+	snap := EnvSnapshot{Cwd: "/town/it's here", Env: map[string]string{"VIRTUAL_ENV": "/venv", "GOFLAGS": "-race"}}
+	got := strings.Join(snap.ShellCommands("/town"), "\n")
+	want := "cd '/town/it'\\''s here'\nexport GOFLAGS='-race'\nexport VIRTUAL_ENV='/venv'"
+	if got != want {
+		t.Errorf("ShellCommands() =\n%s\nwant\n%s", got, want)
+	}
+	if cmds := snap.ShellCommands("/town/it's here"); len(cmds) != 2 {
+		t.Errorf("ShellCommands() in the captured directory = %v, want no cd", cmds)
+	}
+}
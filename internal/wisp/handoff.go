@@ -0,0 +1,52 @@
+package wisp
+
+import "strings"
+
+// Handoff mail defaults, used when 'gt handoff' is given no subject or
+// message.
+const (
+	HandoffSubjectPrefix  = "🤝 HANDOFF: "
+	DefaultHandoffSubject = HandoffSubjectPrefix + "Session cycling"
+	DefaultHandoffBody    = "Context cycling. Check bd ready for pending work."
+)
+
+// HandoffMail is the mail a handoff sends to the agent's next session,
+// which finds it on its hook.
+type HandoffMail struct {
+	Subject string
+	Body    string
+}
+
+// RenderHandoffMail builds the handoff mail for a subject and body as the
+// user gave them: an empty subject or body gets the default, and a subject
+// without "HANDOFF" gets the handoff prefix.
+func RenderHandoffMail(subject, body string) HandoffMail {
+	switch {
+	case subject == "":
+		subject = DefaultHandoffSubject
+	case !strings.Contains(subject, "HANDOFF"):
+		subject = HandoffSubjectPrefix + subject
+	}
+	if body == "" {
+		body = DefaultHandoffBody
+	}
+	return HandoffMail{Subject: subject, Body: body}
+}
+
+// String formats the mail for proofreading or editing: a "Subject:" line,
+// a blank line, then the body. ParseHandoffMail reads it back.
+func (m HandoffMail) String() string {
+	return "Subject: " + m.Subject + "\n\n" + m.Body + "\n"
+}
+
+// ParseHandoffMail reads a mail in the String format, as edited by the
+// user, and renders it again with RenderHandoffMail. Text without a
+// "Subject:" first line is all body.
+func ParseHandoffMail(text string) HandoffMail {
+	var subject string
+	first, rest, _ := strings.Cut(text, "\n")
+	if s, ok := strings.CutPrefix(first, "Subject:"); ok {
+		subject, text = strings.TrimSpace(s), rest
+	}
+	return RenderHandoffMail(subject, strings.TrimSpace(text))
+}
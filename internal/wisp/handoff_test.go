@@ -0,0 +1,35 @@
+package wisp
+
+import "testing"
+
+func TestRenderHandoffMail(t *testing.T) {
+	tests := []struct {
+		subject, body string
+		want          HandoffMail
+	}{
+		{"", "", HandoffMail{DefaultHandoffSubject, DefaultHandoffBody}},
+		{"Fix it", "Notes", HandoffMail{"🤝 HANDOFF: Fix it", "Notes"}},
+		{"HANDOFF: done", "", HandoffMail{"HANDOFF: done", DefaultHandoffBody}},
+	}
+	for _, tt := range tests {
+		if got := RenderHandoffMail(tt.subject, tt.body); got != tt.want {
+			t.Errorf("RenderHandoffMail(%q, %q) = %+v, want %+v", tt.subject, tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestParseHandoffMail(t *testing.T) {
+	m := RenderHandoffMail("🪝 HOOKED: gt-abc", "Line one\nLine two")
+	if got := ParseHandoffMail(m.String()); got != m {
+		t.Errorf("ParseHandoffMail(String()) = %+v, want %+v", got, m)
+	}
+
+	edited := "Subject: Rewritten\n\nNew notes\n"
+	if got := ParseHandoffMail(edited); got != (HandoffMail{"🤝 HANDOFF: Rewritten", "New notes"}) {
+		t.Errorf("ParseHandoffMail(edited) = %+v", got)
+	}
+	// Without a subject line the text is the body and the subject defaults
+	if got := ParseHandoffMail("just notes\n"); got != (HandoffMail{DefaultHandoffSubject, "just notes"}) {
+		t.Errorf("ParseHandoffMail(body only) = %+v", got)
+	}
+}
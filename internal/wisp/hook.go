@@ -0,0 +1,398 @@
+package wisp
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// ErrNoHook is returned when an agent has no work to resume.
+var ErrNoHook = errors.New("nothing on hook")
+
+// IdentityFromEnv infers the current agent's hook identity from the
+// session environment written by the session managers (see config.AgentEnv):
+//
+//   - GT_ROLE     full identity ("gastown/crew/max", "mayor") or a bare role
+//     ("crew", "polecat", "witness", "refinery", "mayor", "deacon")
+//   - GT_RIG      rig name; required with a bare rig-scoped role
+//   - GT_CREW     crew worker name; used with role "crew"
+//   - GT_POLECAT  polecat name; used with role "polecat"
+//
+// When GT_ROLE is unset, GT_RIG plus GT_CREW or GT_POLECAT is enough. The
+// result is normalized with NormalizeIdentity.
+func IdentityFromEnv() (string, error) {
+	role := strings.TrimSpace(os.Getenv("GT_ROLE"))
+	rig := os.Getenv("GT_RIG")
+	crew := os.Getenv("GT_CREW")
+	polecat := os.Getenv("GT_POLECAT")
+
+	if role == "" {
+		switch {
+		case crew != "":
+			role = "crew"
+		case polecat != "":
+			role = "polecat"
+		default:
+			return "", fmt.Errorf("cannot infer agent identity: GT_ROLE is not set")
+		}
+	}
+
+	var id string
+	switch strings.ToLower(role) {
+	case "mayor", "deacon":
+		id = role
+	case "witness", "refinery":
+		id = rig + "/" + role
+	case "crew":
+		id = rig + "/crew/" + crew
+	case "polecat", "polecats":
+		id = rig + "/polecats/" + polecat
+	case "deacon/boot":
+		return "", fmt.Errorf("%w: boot is a watchdog and never hooks work", ErrNoHook)
+	default:
+		id = role // already a full identity
+	}
+
+	if err := ValidateIdentity(id); err != nil {
+		return "", fmt.Errorf("cannot infer agent identity from GT_ROLE=%q: %w", role, err)
+	}
+	return NormalizeIdentity(id), nil
+}
+
+// AgentHook returns the bead on identity's hook in the beads database at
+// workDir. Work that was claimed (in_progress) but not finished before a
+// restart still counts as hooked. Returns ErrNoHook if there is none.
+func AgentHook(workDir, identity string) (*beads.Issue, error) {
+	b := beads.New(workDir)
+	for _, status := range []string{beads.StatusHooked, "in_progress"} {
+		issues, err := b.List(beads.ListOptions{
+			Status:   status,
+			Assignee: identity,
+			Priority: -1,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(issues) > 0 {
+			return issues[0], nil
+		}
+	}
+	return nil, ErrNoHook
+}
+
+// RoleHookIdentity returns the role hook identity is eligible for, or ""
+// if it has none. Work slung to a role ("gastown/crew") rather than to a
+// named worker waits there until any crew member in that rig picks it up.
+func RoleHookIdentity(identity string) string {
+	parts := strings.Split(NormalizeIdentity(identity), "/")
+	if len(parts) == 3 && parts[1] == "crew" && parts[0] != "" && parts[2] != "" {
+		return parts[0] + "/crew"
+	}
+	return ""
+}
+
+// IsRoleHookIdentity reports whether s names a role hook ("<rig>/crew"),
+// returning the rig.
+func IsRoleHookIdentity(s string) (rig string, ok bool) {
+	parts := strings.Split(strings.Trim(strings.TrimSpace(s), "/"), "/")
+	if len(parts) == 2 && parts[1] == "crew" && parts[0] != "" {
+		return parts[0], true
+	}
+	return "", false
+}
+
+// ErrAlreadyClaimed is returned by Claim when another agent took the bead
+// off its role hook first.
+var ErrAlreadyClaimed = errors.New("already claimed")
+
+// ClaimRoleHook moves the oldest bead waiting on identity's role hook onto
+// identity's own hook and returns it. Claims are serialized with a lock in
+// the beads directory, so when several crew members look at once each bead
+// goes to exactly one of them. Beads whose dependencies are not done are
+// passed over. Returns ErrNoHook if identity has no role hook or nothing
+// waiting on it is ready.
+func ClaimRoleHook(workDir, identity string) (*beads.Issue, error) {
+	role := RoleHookIdentity(identity)
+	if role == "" {
+		return nil, ErrNoHook
+	}
+	identity = NormalizeIdentity(identity)
+
+	unlock, err := lockRoleHook(workDir)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	b := beads.New(workDir)
+	issues, err := b.List(beads.ListOptions{
+		Status:   beads.StatusHooked,
+		Assignee: role,
+		Priority: -1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range issues {
+		if CheckHookReady(workDir, issue) != nil {
+			continue // waits for its dependencies (see HookDependencies)
+		}
+		if err := claimIssue(b, issue, identity); err != nil {
+			return nil, err
+		}
+		return issue, nil
+	}
+	return nil, ErrNoHook
+}
+
+// Claim takes the bead beadID off the role hook identity qualifies for and
+// puts it on identity's own hook, recording claimed_by and claimed_at. The
+// check and the claim happen under the role hook lock (see ClaimRoleHook),
+// so of several agents claiming the same bead exactly one wins; the others
+// get ErrAlreadyClaimed naming the winner. Claiming a bead identity already
+// holds returns it unchanged.
+func Claim(workDir, beadID, identity string) (*beads.Issue, error) {
+	identity = NormalizeIdentity(identity)
+	unlock, err := lockRoleHook(workDir)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	b := beads.New(workDir)
+	issue, err := b.Show(beadID)
+	if err != nil {
+		return nil, err
+	}
+	if claimed, err := claimState(issue, identity); err != nil {
+		return nil, err
+	} else if claimed {
+		return issue, nil
+	}
+	if err := claimIssue(b, issue, identity); err != nil {
+		return nil, err
+	}
+	return issue, nil
+}
+
+// claimState decides a claim of issue by identity: claimed is true if
+// identity already holds it, and the error says why identity can't claim
+// it. Neither means the bead is waiting on identity's role hook.
+func claimState(issue *beads.Issue, identity string) (claimed bool, err error) {
+	if issue.Status != beads.StatusHooked && issue.Status != "in_progress" {
+		return false, fmt.Errorf("%s is %s, not on a hook", issue.ID, issue.Status)
+	}
+	assignee := NormalizeIdentity(issue.Assignee)
+	switch role := RoleHookIdentity(identity); {
+	case assignee == identity:
+		return true, nil
+	case role != "" && assignee == role:
+		return false, nil
+	case assignee == "":
+		return false, fmt.Errorf("%s is not on anyone's hook", issue.ID)
+	}
+	if _, ok := IsRoleHookIdentity(assignee); ok {
+		return false, fmt.Errorf("%s is waiting on %s, which %s can't claim from", issue.ID, assignee, identity)
+	}
+	return false, fmt.Errorf("%w: %s is on %s's hook", ErrAlreadyClaimed, issue.ID, assignee)
+}
+
+// lockRoleHook takes the role hook lock in workDir's beads directory,
+// creating the directory if needed, and returns its release.
+func lockRoleHook(workDir string) (unlock func(), err error) {
+	beadsDir := beads.ResolveBeadsDir(workDir)
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		return nil, fmt.Errorf("creating beads directory: %w", err)
+	}
+	lock := flock.New(filepath.Join(beadsDir, "role-hook.lock"))
+	if err := lock.Lock(); err != nil {
+		return nil, fmt.Errorf("locking role hook: %w", err)
+	}
+	return func() { _ = lock.Unlock() }, nil
+}
+
+// claimIssue moves issue from its role hook onto identity's hook, recording
+// claimed_by and claimed_at in its description. Call it with the role hook
+// lock held.
+func claimIssue(b *beads.Beads, issue *beads.Issue, identity string) error {
+	fields := beads.ParseAttachmentFields(issue)
+	if fields == nil {
+		fields = &beads.AttachmentFields{}
+	}
+	fields.ClaimedBy = identity
+	fields.ClaimedAt = time.Now().UTC().Format(time.RFC3339)
+
+	desc := beads.SetAttachmentFields(issue, fields)
+	if err := b.Update(issue.ID, beads.UpdateOptions{Assignee: &identity, Description: &desc}); err != nil {
+		return fmt.Errorf("claiming %s from %s: %w", issue.ID, issue.Assignee, err)
+	}
+	issue.Assignee = identity
+	issue.Description = desc
+	return nil
+}
+
+// PickUpHook returns the bead on identity's personal hook. If that is
+// empty, it claims the next bead waiting on a role hook identity qualifies
+// for (see ClaimRoleHook). Unlike AgentHook it may reassign work, so use it
+// only on behalf of the agent itself, not when inspecting another agent.
+// A waiting personal hook whose dependencies are not done is not picked
+// up: the error wraps ErrHookBlocked (see CheckHookReady).
+func PickUpHook(workDir, identity string) (*beads.Issue, error) {
+	issue, err := AgentHook(workDir, identity)
+	if err == nil {
+		if err := CheckHookReady(workDir, issue); err != nil {
+			return nil, err
+		}
+		return issue, nil
+	}
+	if !errors.Is(err, ErrNoHook) {
+		return nil, err
+	}
+	return ClaimRoleHook(workDir, identity)
+}
+
+// Hook states. A bead on an agent's hook moves through:
+//
+//	waiting      status=hooked, no picked_up_at (slung, not yet started);
+//	             held back until its depends_on beads are done (see CheckHookReady)
+//	in progress  status=hooked with picked_up_at set by MarkPickedUp when the
+//	             agent's session primes, or status=in_progress (claimed
+//	             with bd update)
+//	done         closed by 'gt done'
+//
+// Unslinging returns the bead to open and clears picked_up_at. Nothing is
+// removed on pickup, so an agent that restarts mid-work finds the same bead
+// on its hook and resumes it.
+//
+// Independently of its state, a hook can be pinned (SetHookPinned): the
+// deacon's stale-hook sweep then leaves it hooked however old it gets, for
+// work that is deliberately waiting on something external.
+
+// ErrInvalidHook is returned for a bead whose hook fields are malformed.
+var ErrInvalidHook = errors.New("invalid hook")
+
+// ValidateHook checks a bead loaded from an agent's hook: a well-formed
+// bead ID, an assignee that is a valid identity or role hook, a creation
+// time, and a hooked (or claimed, in_progress) status. The error, wrapping
+// ErrInvalidHook, lists every problem found.
+func ValidateHook(issue *beads.Issue) error {
+	problems := hookTargetProblems(issue.ID, issue.Assignee)
+	if issue.CreatedAt == "" {
+		problems = append(problems, "missing created_at")
+	}
+	if issue.Status != beads.StatusHooked && issue.Status != "in_progress" {
+		problems = append(problems, fmt.Sprintf("status %q is not %s", issue.Status, beads.StatusHooked))
+	}
+	return hookError(issue.ID, problems)
+}
+
+// ValidateHookTarget checks a bead ID and agent identity before the bead
+// is hooked to the agent: the part of ValidateHook known before writing.
+func ValidateHookTarget(beadID, identity string) error {
+	return hookError(beadID, hookTargetProblems(beadID, identity))
+}
+
+// hookTargetProblems describes what is wrong with a hook's bead ID and
+// assignee. Deacon dogs ("deacon/dogs/<name>") are accepted as assignees.
+func hookTargetProblems(beadID, identity string) []string {
+	problems := beadIDProblems(beadID)
+	_, roleHook := IsRoleHookIdentity(identity)
+	if err := ValidateIdentity(identity); err != nil && !roleHook && !strings.HasPrefix(NormalizeIdentity(identity), IdentityDeacon) {
+		problems = append(problems, "assignee: "+err.Error())
+	}
+	return problems
+}
+
+// beadIDProblems describes what is wrong with a bead ID: it must be a
+// prefix and a rest joined by '-', with no whitespace or slashes.
+func beadIDProblems(beadID string) []string {
+	if prefix, rest, ok := strings.Cut(beadID, "-"); !ok || prefix == "" || rest == "" || strings.ContainsAny(beadID, " \t\n/") {
+		return []string{fmt.Sprintf("malformed bead ID %q", beadID)}
+	}
+	return nil
+}
+
+// hookError combines a hook's problems into one ErrInvalidHook error, or
+// returns nil if there are none.
+func hookError(beadID string, problems []string) error {
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w %s: %s", ErrInvalidHook, beadID, strings.Join(problems, "; "))
+}
+
+// HookInProgress reports whether work on a hooked bead has started.
+func HookInProgress(issue *beads.Issue) bool {
+	if issue == nil {
+		return false
+	}
+	if issue.Status == "in_progress" {
+		return true
+	}
+	fields := beads.ParseAttachmentFields(issue)
+	return fields != nil && fields.PickedUpAt != ""
+}
+
+// MarkPickedUp moves a waiting hook to in progress by recording
+// picked_up_at in the bead's description. It returns resumed=true, without
+// changing anything, if the work had already been picked up (an agent
+// restarting mid-work).
+func MarkPickedUp(workDir string, issue *beads.Issue) (resumed bool, err error) {
+	if HookInProgress(issue) {
+		return true, nil
+	}
+	fields := beads.ParseAttachmentFields(issue)
+	if fields == nil {
+		fields = &beads.AttachmentFields{}
+	}
+	fields.PickedUpAt = time.Now().UTC().Format(time.RFC3339)
+
+	desc := beads.SetAttachmentFields(issue, fields)
+	if err := beads.New(workDir).Update(issue.ID, beads.UpdateOptions{Description: &desc}); err != nil {
+		return false, fmt.Errorf("marking %s picked up: %w", issue.ID, err)
+	}
+	issue.Description = desc
+	return false, nil
+}
+
+// HookPinned reports whether a hooked bead is pinned against stale-hook
+// sweeps.
+func HookPinned(issue *beads.Issue) bool {
+	fields := beads.ParseAttachmentFields(issue)
+	return fields != nil && fields.Pinned
+}
+
+// SetHookPinned pins or unpins a hooked bead by recording pinned in its
+// description, using b for the update.
+func SetHookPinned(b *beads.Beads, issue *beads.Issue, pinned bool) error {
+	fields := beads.ParseAttachmentFields(issue)
+	if fields == nil {
+		fields = &beads.AttachmentFields{}
+	}
+	fields.Pinned = pinned
+
+	desc := beads.SetAttachmentFields(issue, fields)
+	if err := b.Update(issue.ID, beads.UpdateOptions{Description: &desc}); err != nil {
+		return fmt.Errorf("updating %s: %w", issue.ID, err)
+	}
+	issue.Description = desc
+	return nil
+}
+
+// CurrentAgentHook returns the hooked bead for the agent running in this
+// session, inferring its identity with IdentityFromEnv. The agent's personal
+// hook is checked first, then any role hook it qualifies for; work found on
+// a role hook is claimed for the agent (see PickUpHook).
+func CurrentAgentHook(workDir string) (*beads.Issue, error) {
+	identity, err := IdentityFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return PickUpHook(workDir, identity)
+}
@@ -0,0 +1,213 @@
+package wisp
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestIdentityFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    string
+		wantErr bool
+	}{
+		{"compound crew role", map[string]string{"GT_ROLE": "gastown/crew/max"}, "gastown/crew/max", false},
+		{"compound polecat role", map[string]string{"GT_ROLE": "gastown/polecats/Toast"}, "gastown/polecats/Toast", false},
+		{"mayor", map[string]string{"GT_ROLE": "mayor"}, IdentityMayor, false},
+		{"deacon", map[string]string{"GT_ROLE": "deacon"}, IdentityDeacon, false},
+		{"bare witness", map[string]string{"GT_ROLE": "witness", "GT_RIG": "gastown"}, "gastown/witness", false},
+		{"bare crew", map[string]string{"GT_ROLE": "crew", "GT_RIG": "gastown", "GT_CREW": "max"}, "gastown/crew/max", false},
+		{"bare polecat", map[string]string{"GT_ROLE": "polecat", "GT_RIG": "gastown", "GT_POLECAT": "Toast"}, "gastown/polecats/Toast", false},
+		{"no role, crew env", map[string]string{"GT_RIG": "gastown", "GT_CREW": "max"}, "gastown/crew/max", false},
+		{"no role, polecat env", map[string]string{"GT_RIG": "gastown", "GT_POLECAT": "Toast"}, "gastown/polecats/Toast", false},
+		{"boot has no hook", map[string]string{"GT_ROLE": "deacon/boot"}, "", true},
+		{"nothing set", map[string]string{}, "", true},
+		{"bare witness without rig", map[string]string{"GT_ROLE": "witness"}, "", true},
+		{"bare crew without name", map[string]string{"GT_ROLE": "crew", "GT_RIG": "gastown"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, k := range []string{"GT_ROLE", "GT_RIG", "GT_CREW", "GT_POLECAT"} {
+				t.Setenv(k, tt.env[k])
+			}
+			got, err := IdentityFromEnv()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("IdentityFromEnv() = %q, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("IdentityFromEnv() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IdentityFromEnv() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoleHookIdentity(t *testing.T) {
+	tests := []struct {
+		identity string
+		want     string
+	}{
+		{"gastown/crew/max", "gastown/crew"},
+		{"gastown/crew/max/", "gastown/crew"},
+		{"gastown/polecats/Toast", ""},
+		{"gastown/witness", ""},
+		{"gastown/crew", ""},
+		{"mayor", ""},
+	}
+	for _, tt := range tests {
+		if got := RoleHookIdentity(tt.identity); got != tt.want {
+			t.Errorf("RoleHookIdentity(%q) = %q, want %q", tt.identity, got, tt.want)
+		}
+	}
+}
+
+func TestIsRoleHookIdentity(t *testing.T) {
+	tests := []struct {
+		target  string
+		wantRig string
+		wantOK  bool
+	}{
+		{"gastown/crew", "gastown", true},
+		{"gastown/crew/", "gastown", true},
+		{"gastown/crew/max", "", false},
+		{"crew", "", false},
+		{"/crew", "", false},
+		{"gastown/polecats", "", false},
+	}
+	for _, tt := range tests {
+		rig, ok := IsRoleHookIdentity(tt.target)
+		if rig != tt.wantRig || ok != tt.wantOK {
+			t.Errorf("IsRoleHookIdentity(%q) = (%q, %v), want (%q, %v)", tt.target, rig, ok, tt.wantRig, tt.wantOK)
+		}
+	}
+}
+
+func TestClaimRoleHookNoRole(t *testing.T) {
+	if _, err := ClaimRoleHook(t.TempDir(), "gastown/polecats/Toast"); !errors.Is(err, ErrNoHook) {
+		t.Errorf("ClaimRoleHook(polecat) error = %v, want ErrNoHook", err)
+	}
+}
+
+func TestClaimState(t *testing.T) {
+	me := "gastown/crew/max"
+	tests := []struct {
+		name        string
+		issue       beads.Issue
+		wantClaimed bool
+		wantErr     string // "", "claimed" (ErrAlreadyClaimed) or "refused" (any other error)
+	}{
+		{"waiting on my role hook", beads.Issue{Status: beads.StatusHooked, Assignee: "gastown/crew"}, false, ""},
+		{"already mine", beads.Issue{Status: beads.StatusHooked, Assignee: me}, true, ""},
+		{"claimed by another", beads.Issue{Status: beads.StatusHooked, Assignee: "gastown/crew/joe"}, false, "claimed"},
+		{"another rig's role hook", beads.Issue{Status: beads.StatusHooked, Assignee: "beads/crew"}, false, "refused"},
+		{"not hooked", beads.Issue{Status: "open", Assignee: "gastown/crew"}, false, "refused"},
+		{"unassigned", beads.Issue{Status: beads.StatusHooked}, false, "refused"},
+	}
+	for _, tt := range tests {
+		tt.issue.ID = "gt-abc"
+		claimed, err := claimState(&tt.issue, me)
+		if claimed != tt.wantClaimed {
+			t.Errorf("%s: claimed = %v, want %v", tt.name, claimed, tt.wantClaimed)
+		}
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%s: error = %v, want none", tt.name, err)
+		case tt.wantErr == "claimed" && !errors.Is(err, ErrAlreadyClaimed):
+			t.Errorf("%s: error = %v, want ErrAlreadyClaimed", tt.name, err)
+		case tt.wantErr == "refused" && (err == nil || errors.Is(err, ErrAlreadyClaimed)):
+			t.Errorf("%s: error = %v, want a refusal other than ErrAlreadyClaimed", tt.name, err)
+		}
+	}
+
+	// A worker without a role hook can't claim unassigned-role work
+	if _, err := claimState(&beads.Issue{ID: "gt-abc", Status: beads.StatusHooked, Assignee: "gastown/crew"}, "gastown/polecats/Toast"); err == nil {
+		t.Error("polecat claimed from the crew role hook")
+	}
+}
+
+func TestHookInProgress(t *testing.T) {
+	tests := []struct {
+		name  string
+		issue *beads.Issue
+		want  bool
+	}{
+		{"nil", nil, false},
+		{"waiting", &beads.Issue{Status: beads.StatusHooked, Description: "dispatched_by: mayor"}, false},
+		{"picked up", &beads.Issue{Status: beads.StatusHooked, Description: "picked_up_at: 2026-01-02T03:04:05Z"}, true},
+		{"claimed with bd", &beads.Issue{Status: "in_progress"}, true},
+	}
+	for _, tt := range tests {
+		if got := HookInProgress(tt.issue); got != tt.want {
+			t.Errorf("%s: HookInProgress() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMarkPickedUpResumes(t *testing.T) {
+	// Already picked up: reported as a resume without touching the database
+	issue := &beads.Issue{ID: "gt-1", Status: beads.StatusHooked, Description: "picked_up_at: 2026-01-02T03:04:05Z"}
+	resumed, err := MarkPickedUp(t.TempDir(), issue)
+	if err != nil || !resumed {
+		t.Fatalf("MarkPickedUp() = %v, %v; want resumed", resumed, err)
+	}
+}
+
+func TestHookPinned(t *testing.T) {
+	tests := []struct {
+		name  string
+		issue *beads.Issue
+		want  bool
+	}{
+		{"nil", nil, false},
+		{"unpinned", &beads.Issue{Status: beads.StatusHooked, Description: "dispatched_by: mayor"}, false},
+		{"pinned", &beads.Issue{Status: beads.StatusHooked, Description: "dispatched_by: mayor\npinned: true"}, true},
+		{"pinned false", &beads.Issue{Status: beads.StatusHooked, Description: "pinned: false"}, false},
+	}
+	for _, tt := range tests {
+		if got := HookPinned(tt.issue); got != tt.want {
+			t.Errorf("%s: HookPinned() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestValidateHook(t *testing.T) {
+	valid := []*beads.Issue{
+		{ID: "gt-abc", Status: beads.StatusHooked, Assignee: "gastown/crew/max", CreatedAt: "2026-01-02T03:04:05Z"},
+		{ID: "hq-cv-1", Status: "in_progress", Assignee: "mayor/", CreatedAt: "2026-01-02T03:04:05Z"},
+		{ID: "gt-abc.2", Status: beads.StatusHooked, Assignee: "gastown/crew", CreatedAt: "2026-01-02T03:04:05Z"},
+		{ID: "gt-abc", Status: beads.StatusHooked, Assignee: "deacon/dogs/alpha", CreatedAt: "2026-01-02T03:04:05Z"},
+	}
+	for _, issue := range valid {
+		if err := ValidateHook(issue); err != nil {
+			t.Errorf("ValidateHook(%+v) = %v, want nil", *issue, err)
+		}
+	}
+
+	// Every problem is reported at once
+	err := ValidateHook(&beads.Issue{ID: "nodash", Status: "open", Assignee: "crew/max"})
+	if !errors.Is(err, ErrInvalidHook) {
+		t.Fatalf("ValidateHook() = %v, want ErrInvalidHook", err)
+	}
+	for _, want := range []string{"malformed bead ID", "assignee", "created_at", "status"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+
+	if err := ValidateHookTarget("gt-abc", "gastown/polecats/Toast"); err != nil {
+		t.Errorf("ValidateHookTarget() = %v, want nil", err)
+	}
+	if err := ValidateHookTarget("gt-", "gastown/polecats/Toast"); !errors.Is(err, ErrInvalidHook) {
+		t.Errorf("ValidateHookTarget(gt-) = %v, want ErrInvalidHook", err)
+	}
+}
@@ -0,0 +1,109 @@
+package wisp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Special identities that are not scoped to a rig.
+const (
+	IdentityMayor    = "mayor/"
+	IdentityDeacon   = "deacon/"
+	IdentityOverseer = "overseer"
+)
+
+// NormalizeIdentity returns the canonical form of an agent identity as stored
+// in hook assignees (e.g. the --assignee of a hooked bead).
+//
+// Canonical grammar:
+//   - "mayor/", "deacon/", "overseer"   (town-level / human)
+//   - "<rig>/witness", "<rig>/refinery" (rig singletons)
+//   - "<rig>/crew/<name>"               (crew workers)
+//   - "<rig>/polecats/<name>"           (polecats)
+//
+// Special identities and role segments are matched case-insensitively and
+// surrounding whitespace and slashes are trimmed. Rig and worker names are
+// kept as-is, since polecat names are case-sensitive. The "<rig>/<name>"
+// shorthand resolves to a polecat, matching mail addressing.
+//
+// Inputs that don't fit the grammar are returned trimmed but otherwise
+// unchanged; use ValidateIdentity to reject them.
+func NormalizeIdentity(s string) string {
+	s = strings.Trim(strings.TrimSpace(s), "/")
+
+	switch strings.ToLower(s) {
+	case "mayor":
+		return IdentityMayor
+	case "deacon":
+		return IdentityDeacon
+	case "overseer":
+		return IdentityOverseer
+	}
+
+	parts := strings.Split(s, "/")
+	if isRoleSegment(parts[0]) {
+		return s // missing rig (e.g. "crew/joe"); left for ValidateIdentity
+	}
+
+	switch len(parts) {
+	case 2:
+		switch role := strings.ToLower(parts[1]); role {
+		case "witness", "refinery":
+			return parts[0] + "/" + role
+		case "crew", "polecats":
+			return s // missing worker name; left for ValidateIdentity
+		default:
+			return parts[0] + "/polecats/" + parts[1]
+		}
+	case 3:
+		switch role := strings.ToLower(parts[1]); role {
+		case "crew", "polecats":
+			return parts[0] + "/" + role + "/" + parts[2]
+		}
+	}
+	return s
+}
+
+// isRoleSegment reports whether seg is a role keyword rather than a rig name.
+func isRoleSegment(seg string) bool {
+	switch strings.ToLower(seg) {
+	case "crew", "polecats", "witness", "refinery":
+		return true
+	}
+	return false
+}
+
+// ValidateIdentity reports whether s normalizes to a well-formed identity.
+func ValidateIdentity(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return fmt.Errorf("empty identity")
+	}
+
+	n := NormalizeIdentity(s)
+	switch n {
+	case IdentityMayor, IdentityDeacon, IdentityOverseer:
+		return nil
+	}
+
+	parts := strings.Split(n, "/")
+	if isRoleSegment(parts[0]) {
+		return fmt.Errorf("invalid identity %q: missing rig (e.g. gastown/%s)", s, n)
+	}
+	for _, p := range parts {
+		if p == "" {
+			return fmt.Errorf("invalid identity %q: empty path segment", s)
+		}
+	}
+
+	switch len(parts) {
+	case 2:
+		if parts[1] == "witness" || parts[1] == "refinery" {
+			return nil
+		}
+	case 3:
+		if parts[1] == "crew" || parts[1] == "polecats" {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid identity %q: expected mayor, deacon, overseer, <rig>/witness, <rig>/refinery, <rig>/crew/<name>, or <rig>/polecats/<name>", s)
+}
@@ -0,0 +1,60 @@
+package wisp
+
+import "testing"
+
+func TestNormalizeIdentity(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"mayor", "mayor/"},
+		{"mayor/", "mayor/"},
+		{"Mayor", "mayor/"},
+		{"deacon", "deacon/"},
+		{" deacon/ ", "deacon/"},
+		{"overseer", "overseer"},
+		{"gastown/witness", "gastown/witness"},
+		{"gastown/Witness", "gastown/witness"},
+		{"gastown/refinery/", "gastown/refinery"},
+		{"gastown/crew/joe", "gastown/crew/joe"},
+		{"gastown/Crew/joe", "gastown/crew/joe"},
+		{"gastown/polecats/Toast", "gastown/polecats/Toast"},
+		{"gastown/Toast", "gastown/polecats/Toast"},
+		// Not normalizable - returned trimmed
+		{"crew/joe", "crew/joe"},
+		{"Crew/Joe", "Crew/Joe"},
+		{"joe", "joe"},
+		{"gastown/crew", "gastown/crew"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := NormalizeIdentity(tt.input); got != tt.want {
+				t.Errorf("NormalizeIdentity(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateIdentity(t *testing.T) {
+	valid := []string{
+		"mayor", "deacon/", "overseer",
+		"gastown/witness", "gastown/refinery",
+		"gastown/crew/joe", "gastown/polecats/Toast", "gastown/Toast",
+	}
+	for _, s := range valid {
+		if err := ValidateIdentity(s); err != nil {
+			t.Errorf("ValidateIdentity(%q) = %v, want nil", s, err)
+		}
+	}
+
+	invalid := []string{
+		"", "  ", "joe", "crew/joe", "Crew/Joe", "gastown/crew",
+		"gastown/crew/joe/extra", "gastown//joe", "gastown/other/joe",
+	}
+	for _, s := range invalid {
+		if err := ValidateIdentity(s); err == nil {
+			t.Errorf("ValidateIdentity(%q) = nil, want error", s)
+		}
+	}
+}
@@ -3,14 +3,19 @@ package wisp
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"path/filepath"
+
+	"github.com/steveyegge/gastown/internal/fsys"
 )
 
+// fileSystem is used for all wisp file IO. Tests can swap in an in-memory
+// fsys.Mem.
+var fileSystem fsys.FS = fsys.OS{}
+
 // EnsureDir ensures the .beads directory exists in the given root.
 func EnsureDir(root string) (string, error) {
 	dir := filepath.Join(root, WispDir)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	if err := fileSystem.MkdirAll(dir, 0755); err != nil {
 		return "", fmt.Errorf("create beads dir: %w", err)
 	}
 	return dir, nil
@@ -30,12 +35,12 @@ func writeJSON(path string, v interface{}) error {
 
 	// Write to temp file then rename for atomicity
 	tmp := path + ".tmp"
-	if err := os.WriteFile(tmp, data, 0644); err != nil { //nolint:gosec // G306: wisp messages are non-sensitive operational data
+	if err := fileSystem.WriteFile(tmp, data, 0644); err != nil { //nolint:gosec // G306: wisp messages are non-sensitive operational data
 		return fmt.Errorf("write temp: %w", err)
 	}
 
-	if err := os.Rename(tmp, path); err != nil {
-		_ = os.Remove(tmp) // cleanup on failure
+	if err := fileSystem.Rename(tmp, path); err != nil {
+		_ = fileSystem.Remove(tmp) // cleanup on failure
 		return fmt.Errorf("rename: %w", err)
 	}
 
@@ -2,7 +2,8 @@
 //
 // This package was originally for "hook files" but those are now deprecated
 // in favor of pinned beads. The remaining utilities help with directory
-// management for the beads system.
+// management for the beads system, agent identities, and finding the bead
+// on an agent's hook.
 package wisp
 
 // WispDir is the directory where beads data is stored.
@@ -168,7 +168,7 @@ func (m *Manager) Start(foreground bool, agentOverride string, envOverrides []st
 
 	// Apply Gas Town theming (non-fatal: theming failure doesn't affect operation)
 	theme := tmux.AssignTheme(m.rig.Name)
-	_ = t.ConfigureGasTownSession(sessionID, theme, m.rig.Name, "witness", "witness")
+	_ = t.ConfigureGasTownSession(sessionID, theme, m.rig.DisplayName(), "witness", "witness")
 
 	// Wait for Claude to start - fatal if Claude fails to launch
 	if err := t.WaitForCommand(sessionID, constants.SupportedShells, constants.ClaudeStartTimeout); err != nil {